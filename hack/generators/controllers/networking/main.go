@@ -412,6 +412,7 @@ import (
 	ctrlref "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/reference"
 	ctrlutils "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/utils"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object/status"
 	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
@@ -544,8 +545,10 @@ func (r *{{.PackageAlias}}{{.Kind}}Reconciler) SetLogger(l logr.Logger) {
 {{- end}}
 
 // Reconcile processes the watched objects
-func (r *{{.PackageAlias}}{{.Kind}}Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *{{.PackageAlias}}{{.Kind}}Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("{{.PackageAlias}}{{.Kind}}", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "{{.Kind}}", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new({{.PackageImportAlias}}.{{.Kind}})
@@ -0,0 +1,149 @@
+package adminapi
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
+)
+
+// MaxCapturedRequestBodySize limits the size of request bodies captured by AuditRoundTripper, so that large
+// configurations don't make records huge.
+const MaxCapturedRequestBodySize = 64 * 1024
+
+// redactedHeaders are headers carrying credentials that are not captured.
+var redactedHeaders = []string{HeaderNameAdminToken, "Authorization", "Cookie"}
+
+// AuditRoundTripper records mutating requests made via RT in an audit log. When request capture is enabled, raw
+// requests (headers with credentials redacted and bodies up to MaxCapturedRequestBodySize) are recorded as well.
+type AuditRoundTripper struct {
+	logger          logr.Logger
+	auditLogger     *audit.Logger
+	captureRequests bool
+	rt              http.RoundTripper
+}
+
+// NewAuditRoundTripper creates an AuditRoundTripper recording requests made via rt.
+func NewAuditRoundTripper(
+	logger logr.Logger, auditLogger *audit.Logger, captureRequests bool, rt http.RoundTripper,
+) *AuditRoundTripper {
+	return &AuditRoundTripper{
+		logger:          logger,
+		auditLogger:     auditLogger,
+		captureRequests: captureRequests,
+		rt:              rt,
+	}
+}
+
+// RoundTrip satisfies the RoundTripper interface.
+func (t *AuditRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isMutatingRequest(req) {
+		return t.rt.RoundTrip(req)
+	}
+
+	record := audit.Record{
+		Time:       time.Now(),
+		Kind:       audit.KindAdminAPIRequest,
+		GatewayURL: req.URL.Scheme + "://" + req.URL.Host,
+		Method:     req.Method,
+		Path:       req.URL.Path,
+	}
+
+	var body *cappedBuffer
+	if t.captureRequests {
+		record.Request = &audit.CapturedRequest{Headers: redactHeaders(req.Header)}
+		if req.Body != nil && req.Body != http.NoBody {
+			// The body is captured while it's being sent, so that it's not kept in memory as a whole.
+			body = &cappedBuffer{limit: MaxCapturedRequestBodySize}
+			newRequest := new(http.Request)
+			*newRequest = *req
+			newRequest.Body = readCloser{Reader: io.TeeReader(req.Body, body), Closer: req.Body}
+			req = newRequest
+		}
+	}
+
+	resp, err := t.rt.RoundTrip(req)
+
+	record.DurationMilliseconds = float64(time.Since(record.Time).Microseconds()) / 1000
+	switch {
+	case err != nil:
+		record.Outcome = audit.OutcomeFailure
+		record.Error = err.Error()
+	case resp.StatusCode >= http.StatusBadRequest:
+		record.Outcome = audit.OutcomeFailure
+		record.StatusCode = resp.StatusCode
+	default:
+		record.Outcome = audit.OutcomeSuccess
+		record.StatusCode = resp.StatusCode
+	}
+	if body != nil {
+		record.Request.Body, record.Request.BodyTruncated = body.contents()
+	}
+	if auditErr := t.auditLogger.Record(record); auditErr != nil {
+		t.logger.Error(auditErr, "failed to record Admin API request in the audit log")
+	}
+
+	return resp, err
+}
+
+func isMutatingRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+func redactHeaders(headers http.Header) map[string][]string {
+	redacted := make(map[string][]string, len(headers))
+	for k, v := range headers {
+		redacted[k] = append([]string(nil), v...)
+	}
+	for _, h := range redactedHeaders {
+		if _, ok := headers[http.CanonicalHeaderKey(h)]; ok {
+			redacted[http.CanonicalHeaderKey(h)] = []string{"[redacted]"}
+		}
+	}
+	return redacted
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// cappedBuffer keeps up to limit bytes written to it and discards the rest. It's safe for concurrent use, as
+// the transport may still be sending the body when the response is received.
+type cappedBuffer struct {
+	limit int
+
+	lock      sync.Mutex
+	buf       bytes.Buffer
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if remaining := b.limit - b.buf.Len(); len(p) > remaining {
+		b.buf.Write(p[:remaining])
+		b.truncated = true
+	} else {
+		b.buf.Write(p)
+	}
+	return len(p), nil
+}
+
+func (b *cappedBuffer) contents() (string, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return strings.ToValidUTF8(b.buf.String(), "�"), b.truncated
+}
@@ -0,0 +1,100 @@
+package adminapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
+)
+
+func TestAuditRoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		if r.URL.Path == "/invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	send := func(t *testing.T, rt http.RoundTripper, method, path, body string) {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set(adminapi.HeaderNameAdminToken, "secret")
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := rt.RoundTrip(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	readRecords := func(t *testing.T, buf *bytes.Buffer) []audit.Record {
+		t.Helper()
+		var records []audit.Record
+		decoder := json.NewDecoder(buf)
+		for decoder.More() {
+			var r audit.Record
+			require.NoError(t, decoder.Decode(&r))
+			records = append(records, r)
+		}
+		return records
+	}
+
+	t.Run("only mutating requests are recorded", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		rt := adminapi.NewAuditRoundTripper(logr.Discard(), audit.NewLogger(buf), false, http.DefaultTransport)
+
+		send(t, rt, http.MethodGet, "/status", "")
+		send(t, rt, http.MethodPost, "/config", `{"services":[]}`)
+		send(t, rt, http.MethodPost, "/invalid", "")
+
+		records := readRecords(t, buf)
+		require.Len(t, records, 2)
+		require.Equal(t, audit.KindAdminAPIRequest, records[0].Kind)
+		require.Equal(t, server.URL, records[0].GatewayURL)
+		require.Equal(t, http.MethodPost, records[0].Method)
+		require.Equal(t, "/config", records[0].Path)
+		require.Equal(t, http.StatusOK, records[0].StatusCode)
+		require.Equal(t, audit.OutcomeSuccess, records[0].Outcome)
+		require.Nil(t, records[0].Request, "requests should not be captured unless enabled")
+
+		require.Equal(t, http.StatusBadRequest, records[1].StatusCode)
+		require.Equal(t, audit.OutcomeFailure, records[1].Outcome)
+	})
+
+	t.Run("requests are captured with credentials redacted", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		rt := adminapi.NewAuditRoundTripper(logr.Discard(), audit.NewLogger(buf), true, http.DefaultTransport)
+
+		send(t, rt, http.MethodPost, "/config", `{"services":[]}`)
+		send(t, rt, http.MethodPost, "/config", strings.Repeat("a", adminapi.MaxCapturedRequestBodySize+1))
+
+		records := readRecords(t, buf)
+		require.Len(t, records, 2)
+		require.NotNil(t, records[0].Request)
+		require.Equal(t, []string{"[redacted]"}, records[0].Request.Headers[adminapi.HeaderNameAdminToken])
+		require.Equal(t, []string{"application/json"}, records[0].Request.Headers["Content-Type"])
+		require.Equal(t, `{"services":[]}`, records[0].Request.Body)
+		require.False(t, records[0].Request.BodyTruncated)
+
+		require.Len(t, records[1].Request.Body, adminapi.MaxCapturedRequestBodySize)
+		require.True(t, records[1].Request.BodyTruncated)
+	})
+}
+
+func TestClientFactory_WithAuditLog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	cf := adminapi.NewClientFactoryForWorkspace("", adminapi.HTTPClientOpts{}, "",
+		adminapi.WithAuditLog(logr.Discard(), audit.NewLogger(buf), false),
+	)
+	httpClient, err := cf.HTTPClient()
+	require.NoError(t, err)
+	require.IsType(t, &adminapi.AuditRoundTripper{}, httpClient.Transport)
+}
@@ -0,0 +1,169 @@
+package adminapi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jpillora/backoff"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckerrors"
+)
+
+const (
+	DefaultGatewayBackoffInitialInterval = time.Second
+	DefaultGatewayBackoffMaxInterval     = time.Minute * 5
+	GatewayBackoffMultiplier             = 2
+)
+
+// GatewayBackoffConfig configures GatewayBackoffStrategy.
+type GatewayBackoffConfig struct {
+	// InitialInterval is the interval of the first backoff after a server or network error.
+	InitialInterval time.Duration
+	// MaxInterval caps intervals of consecutive backoffs.
+	MaxInterval time.Duration
+}
+
+// GatewayBackoffStrategy is a circuit breaker of config updates of a single self-hosted Kong Gateway.
+//
+// It takes into account classes of update errors:
+//   - a client error (e.g. the gateway rejecting an invalid config) opens the circuit for the failed config hash
+//     only, so the same faulty config is not sent again until it changes,
+//   - a 5xx or a network error opens the circuit for all configs, backing off exponentially until an update succeeds,
+//   - a 429 error opens the circuit until the time suggested by its Retry-After header.
+//
+// Unlike Konnect, a gateway is configured with a complete config, so a changed config is allowed straight away after
+// a client error, as it may fix the problem.
+type GatewayBackoffStrategy struct {
+	b                    *backoff.Backoff
+	nextAttempt          time.Time
+	clock                Clock
+	lastFailedConfigHash []byte
+
+	lock sync.RWMutex
+}
+
+// GatewayBackoffStatus describes the state of a GatewayBackoffStrategy.
+type GatewayBackoffStatus struct {
+	// Open tells whether the circuit is open, i.e. whether all updates are currently blocked after a server error,
+	// a network error or a rate-limited update. A config rejected by the gateway (a client error) doesn't open
+	// the circuit, as it blocks only the same config while the gateway accepts other ones.
+	Open bool
+	// Reason is a human-readable explanation of why the circuit is open.
+	Reason string
+}
+
+func NewGatewayBackoffStrategy(config GatewayBackoffConfig, clock Clock) *GatewayBackoffStrategy {
+	exponentialBackoff := &backoff.Backoff{
+		Min:    config.InitialInterval,
+		Max:    config.MaxInterval,
+		Factor: GatewayBackoffMultiplier,
+	}
+	exponentialBackoff.Reset()
+
+	return &GatewayBackoffStrategy{
+		b:     exponentialBackoff,
+		clock: clock,
+	}
+}
+
+func (s *GatewayBackoffStrategy) CanUpdate(configHash []byte) (bool, string) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	timeLeft := s.nextAttempt.Sub(s.clock.Now())
+	isTheSameFaultyConfig := s.lastFailedConfigHash != nil && bytes.Equal(s.lastFailedConfigHash, configHash)
+	if timeLeft <= 0 && !isTheSameFaultyConfig {
+		return true, ""
+	}
+	return false, s.whyCannotUpdate(timeLeft, isTheSameFaultyConfig)
+}
+
+func (s *GatewayBackoffStrategy) RegisterUpdateFailure(err error, configHash []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	apiErrs := deckerrors.ExtractAPIErrors(err)
+	tooManyRequestsErr, isTooManyRequests := lo.Find(apiErrs, func(err *kong.APIError) bool {
+		return err.Code() == http.StatusTooManyRequests
+	})
+	if isTooManyRequests {
+		if details, ok := tooManyRequestsErr.Details().(kong.ErrTooManyRequestsDetails); ok && details.RetryAfter != 0 {
+			s.nextAttempt = s.clock.Now().Add(details.RetryAfter)
+		} else {
+			s.incrementExponentialBackoff()
+		}
+		s.lastFailedConfigHash = nil
+		return
+	}
+
+	isClientError := len(apiErrs) > 0 && lo.EveryBy(apiErrs, func(err *kong.APIError) bool {
+		return err.Code() >= 400 && err.Code() < 500
+	})
+	if isClientError {
+		// The gateway is healthy, but rejects the config. Don't delay other configs.
+		s.b.Reset()
+		s.nextAttempt = time.Time{}
+		s.lastFailedConfigHash = configHash
+		return
+	}
+
+	// Server errors and network errors (i.e. errors without an API error) may be caused by an overloaded or
+	// failing gateway, back off regardless of the config.
+	s.incrementExponentialBackoff()
+	s.lastFailedConfigHash = nil
+}
+
+func (s *GatewayBackoffStrategy) RegisterUpdateSuccess() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.b.Reset()
+	s.nextAttempt = time.Time{}
+	s.lastFailedConfigHash = nil
+}
+
+// Status returns the current state of the circuit breaker.
+func (s *GatewayBackoffStrategy) Status() GatewayBackoffStatus {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	timeLeft := s.nextAttempt.Sub(s.clock.Now())
+	if timeLeft <= 0 {
+		return GatewayBackoffStatus{}
+	}
+	return GatewayBackoffStatus{
+		Open:   true,
+		Reason: s.whyCannotUpdate(timeLeft, false),
+	}
+}
+
+func (s *GatewayBackoffStrategy) incrementExponentialBackoff() {
+	s.nextAttempt = s.clock.Now().Add(s.b.Duration())
+}
+
+func (s *GatewayBackoffStrategy) whyCannotUpdate(
+	timeLeft time.Duration,
+	isTheSameFaultyConfig bool,
+) string {
+	var reasons []string
+
+	if isTheSameFaultyConfig {
+		reasons = append(reasons, fmt.Sprintf(
+			"config has to be changed: %q hash has already been rejected by the gateway",
+			hex.EncodeToString(s.lastFailedConfigHash),
+		))
+	}
+
+	if timeLeft > 0 {
+		reasons = append(reasons, fmt.Sprintf("next attempt allowed in %s", timeLeft))
+	}
+
+	return strings.Join(reasons, ", ")
+}
@@ -0,0 +1,112 @@
+package adminapi_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+)
+
+func TestGatewayBackoffStrategy(t *testing.T) {
+	someTestHash := func(s string) []byte {
+		h := sha256.Sum256([]byte(s))
+		return h[:]
+	}
+
+	var (
+		clock   = newMockClock()
+		hashOne = someTestHash("1")
+		hashTwo = someTestHash("2")
+		config  = adminapi.GatewayBackoffConfig{
+			InitialInterval: time.Second,
+			MaxInterval:     time.Second * 4,
+		}
+	)
+
+	t.Run("on init allows any updates and the circuit is closed", func(t *testing.T) {
+		strategy := adminapi.NewGatewayBackoffStrategy(config, clock)
+
+		canUpdate, whyNot := strategy.CanUpdate(hashOne)
+		assert.True(t, canUpdate)
+		assert.Empty(t, whyNot)
+		assert.Equal(t, adminapi.GatewayBackoffStatus{}, strategy.Status())
+	})
+
+	t.Run("network error opens the circuit with an exponential backoff", func(t *testing.T) {
+		strategy := adminapi.NewGatewayBackoffStrategy(config, clock)
+
+		strategy.RegisterUpdateFailure(errors.New("connection refused"), hashOne)
+		canUpdate, whyNot := strategy.CanUpdate(hashTwo)
+		assert.False(t, canUpdate, "should not allow any config until the backoff passes")
+		assert.Equal(t, "next attempt allowed in 1s", whyNot)
+		assert.Equal(t, adminapi.GatewayBackoffStatus{Open: true, Reason: "next attempt allowed in 1s"}, strategy.Status())
+
+		clock.MoveBy(time.Second)
+		canUpdate, _ = strategy.CanUpdate(hashOne)
+		assert.True(t, canUpdate, "should allow the same config once the backoff passes")
+		assert.False(t, strategy.Status().Open)
+
+		strategy.RegisterUpdateFailure(errors.New("connection refused"), hashOne)
+		_, whyNot = strategy.CanUpdate(hashOne)
+		assert.Equal(t, "next attempt allowed in 2s", whyNot, "backoff should grow with consecutive failures")
+
+		clock.MoveBy(time.Second * 2)
+		strategy.RegisterUpdateFailure(errors.New("connection refused"), hashOne)
+		clock.MoveBy(time.Second * 4)
+		strategy.RegisterUpdateFailure(errors.New("connection refused"), hashOne)
+		_, whyNot = strategy.CanUpdate(hashOne)
+		assert.Equal(t, "next attempt allowed in 4s", whyNot, "backoff should be capped")
+
+		strategy.RegisterUpdateSuccess()
+		canUpdate, _ = strategy.CanUpdate(hashOne)
+		assert.True(t, canUpdate, "success should close the circuit")
+	})
+
+	t.Run("server error opens the circuit with an exponential backoff", func(t *testing.T) {
+		strategy := adminapi.NewGatewayBackoffStrategy(config, clock)
+
+		strategy.RegisterUpdateFailure(kong.NewAPIError(http.StatusServiceUnavailable, ""), hashOne)
+		canUpdate, whyNot := strategy.CanUpdate(hashTwo)
+		assert.False(t, canUpdate)
+		assert.Equal(t, "next attempt allowed in 1s", whyNot)
+	})
+
+	t.Run("client error blocks only the failed config", func(t *testing.T) {
+		strategy := adminapi.NewGatewayBackoffStrategy(config, clock)
+
+		strategy.RegisterUpdateFailure(kong.NewAPIError(http.StatusBadRequest, ""), hashOne)
+		clock.MoveBy(time.Minute)
+		canUpdate, whyNot := strategy.CanUpdate(hashOne)
+		assert.False(t, canUpdate, "should not allow the same faulty config regardless of time passed")
+		assert.Contains(t, whyNot, "config has to be changed")
+		assert.False(t, strategy.Status().Open, "a rejected config should not open the circuit for other configs")
+
+		canUpdate, whyNot = strategy.CanUpdate(hashTwo)
+		assert.True(t, canUpdate, "should allow a changed config straight away")
+		assert.Empty(t, whyNot)
+
+		strategy.RegisterUpdateSuccess()
+		canUpdate, _ = strategy.CanUpdate(hashOne)
+		assert.True(t, canUpdate, "success should forget the faulty config")
+	})
+
+	t.Run("too many requests error with details respects Retry-After", func(t *testing.T) {
+		strategy := adminapi.NewGatewayBackoffStrategy(config, clock)
+
+		tooManyRequestsAPIErr := kong.NewAPIError(http.StatusTooManyRequests, "")
+		tooManyRequestsAPIErr.SetDetails(kong.ErrTooManyRequestsDetails{RetryAfter: time.Second * 10})
+		strategy.RegisterUpdateFailure(tooManyRequestsAPIErr, hashOne)
+		_, whyNot := strategy.CanUpdate(hashTwo)
+		assert.Equal(t, "next attempt allowed in 10s", whyNot)
+
+		clock.MoveBy(time.Second * 10)
+		canUpdate, _ := strategy.CanUpdate(hashOne)
+		assert.True(t, canUpdate)
+	})
+}
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/go-logr/logr"
 	"github.com/kong/go-kong/kong"
 	"github.com/samber/lo"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/clock"
 )
@@ -28,6 +31,12 @@ type Client struct {
 
 	// podRef (optional) describes the Pod that the Client communicates with.
 	podRef *k8stypes.NamespacedName
+
+	// serviceRef (optional) describes the Service the Client's Admin API was discovered from.
+	serviceRef *k8stypes.NamespacedName
+
+	// backoffStrategy (optional) is a circuit breaker of config updates sent to the Admin API.
+	backoffStrategy *GatewayBackoffStrategy
 }
 
 // NewClient creates an Admin API client that is to be used with a regular Admin API exposed by Kong Gateways.
@@ -178,22 +187,142 @@ func (c *Client) PodReference() (k8stypes.NamespacedName, bool) {
 	return k8stypes.NamespacedName{}, false
 }
 
+// AttachServiceReference allows attaching a reference to the Service the client's Admin API was discovered from.
+func (c *Client) AttachServiceReference(serviceNN k8stypes.NamespacedName) {
+	c.serviceRef = &serviceNN
+}
+
+// ServiceReference returns an optional reference to the Service the client's Admin API was discovered from.
+func (c *Client) ServiceReference() (k8stypes.NamespacedName, bool) {
+	if c.serviceRef != nil {
+		return *c.serviceRef, true
+	}
+	return k8stypes.NamespacedName{}, false
+}
+
+// AttachBackoffStrategy allows attaching a circuit breaker of config updates sent to the Admin API.
+func (c *Client) AttachBackoffStrategy(s *GatewayBackoffStrategy) {
+	c.backoffStrategy = s
+}
+
+// BackoffStrategy returns the client's update backoff strategy or nil if it has none attached.
+func (c *Client) BackoffStrategy() UpdateBackoffStrategy {
+	if c.backoffStrategy == nil {
+		return nil
+	}
+	return c.backoffStrategy
+}
+
+// BackoffStatus returns the state of the client's circuit breaker. The circuit of a client without a backoff
+// strategy attached is always closed.
+func (c *Client) BackoffStatus() GatewayBackoffStatus {
+	if c.backoffStrategy == nil {
+		return GatewayBackoffStatus{}
+	}
+	return c.backoffStrategy.Status()
+}
+
 type ClientFactory struct {
 	workspace      string
 	httpClientOpts HTTPClientOpts
 	adminToken     string
+
+	// transportReloader, when set, provides the transport shared by all created clients.
+	transportReloader *TransportReloader
+
+	// backoffConfig, when set, makes each of the created clients back off config updates on failures.
+	backoffConfig *GatewayBackoffConfig
+
+	// auditLogger, when set, records mutating requests of the created clients. Failures to record them are logged
+	// with logger.
+	auditLogger *audit.Logger
+	logger      logr.Logger
+	// captureRequests makes raw requests recorded by auditLogger.
+	captureRequests bool
+
+	// tracing makes the created clients record their requests as spans and propagate the trace context to Kong.
+	tracing bool
+}
+
+// ClientFactoryOption is an option of ClientFactory.
+type ClientFactoryOption func(*ClientFactory)
+
+// WithTransportReloader makes ClientFactory create clients sharing the transport of the reloader, so that their
+// TLS material and token are reloaded in place. HTTP client options and admin token of the factory are not used then.
+func WithTransportReloader(r *TransportReloader) ClientFactoryOption {
+	return func(cf *ClientFactory) {
+		cf.transportReloader = r
+	}
+}
+
+// WithGatewayBackoff makes ClientFactory attach a GatewayBackoffStrategy configured with config to each of
+// the created clients.
+func WithGatewayBackoff(config GatewayBackoffConfig) ClientFactoryOption {
+	return func(cf *ClientFactory) {
+		cf.backoffConfig = &config
+	}
+}
+
+// WithAuditLog makes ClientFactory create clients recording their mutating requests in the audit log. When
+// captureRequests is set, raw requests are recorded as well.
+func WithAuditLog(logger logr.Logger, auditLogger *audit.Logger, captureRequests bool) ClientFactoryOption {
+	return func(cf *ClientFactory) {
+		cf.logger = logger
+		cf.auditLogger = auditLogger
+		cf.captureRequests = captureRequests
+	}
+}
+
+// WithTracing makes ClientFactory create clients recording their requests as OpenTelemetry spans and propagating
+// the trace context in request headers, so that Kong can correlate its own traces.
+func WithTracing() ClientFactoryOption {
+	return func(cf *ClientFactory) {
+		cf.tracing = true
+	}
 }
 
-func NewClientFactoryForWorkspace(workspace string, httpClientOpts HTTPClientOpts, adminToken string) ClientFactory {
-	return ClientFactory{
+func NewClientFactoryForWorkspace(
+	workspace string, httpClientOpts HTTPClientOpts, adminToken string, opts ...ClientFactoryOption,
+) ClientFactory {
+	cf := ClientFactory{
 		workspace:      workspace,
 		httpClientOpts: httpClientOpts,
 		adminToken:     adminToken,
 	}
+	for _, opt := range opts {
+		opt(&cf)
+	}
+	return cf
+}
+
+// HTTPClient returns an HTTP client for Admin APIs the factory creates clients with.
+func (cf ClientFactory) HTTPClient() (*http.Client, error) {
+	var (
+		httpClient *http.Client
+		err        error
+	)
+	if cf.transportReloader != nil {
+		httpClient = cf.transportReloader.HTTPClient()
+	} else if httpClient, err = MakeHTTPClient(&cf.httpClientOpts, cf.adminToken); err != nil {
+		return nil, err
+	}
+
+	if httpClient.Transport == nil && (cf.auditLogger != nil || cf.tracing) {
+		httpClient.Transport = http.DefaultTransport
+	}
+	if cf.auditLogger != nil {
+		httpClient.Transport = NewAuditRoundTripper(cf.logger, cf.auditLogger, cf.captureRequests, httpClient.Transport)
+	}
+	// The trace context is injected before the request is audited, so that captured requests can be correlated
+	// with traces.
+	if cf.tracing {
+		httpClient.Transport = tracing.NewTransport(httpClient.Transport)
+	}
+	return httpClient, nil
 }
 
 func (cf ClientFactory) CreateAdminAPIClient(ctx context.Context, discoveredAdminAPI DiscoveredAdminAPI) (*Client, error) {
-	httpclient, err := MakeHTTPClient(&cf.httpClientOpts, cf.adminToken)
+	httpclient, err := cf.HTTPClient()
 	if err != nil {
 		return nil, err
 	}
@@ -201,6 +330,15 @@ func (cf ClientFactory) CreateAdminAPIClient(ctx context.Context, discoveredAdmi
 	if err != nil {
 		return nil, err
 	}
-	cl.AttachPodReference(discoveredAdminAPI.PodRef)
+	// Admin APIs discovered outside of Kubernetes (e.g. through DNS) are not backed by Pods.
+	if discoveredAdminAPI.PodRef != (k8stypes.NamespacedName{}) {
+		cl.AttachPodReference(discoveredAdminAPI.PodRef)
+	}
+	if discoveredAdminAPI.ServiceRef != (k8stypes.NamespacedName{}) {
+		cl.AttachServiceReference(discoveredAdminAPI.ServiceRef)
+	}
+	if cf.backoffConfig != nil {
+		cl.AttachBackoffStrategy(NewGatewayBackoffStrategy(*cf.backoffConfig, clock.System{}))
+	}
 	return cl, nil
 }
@@ -12,7 +12,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/test/mocks"
 )
 
-func TestClientFactory_CreateAdminAPIClientAttachesPodReference(t *testing.T) {
+func TestClientFactory_CreateAdminAPIClientAttachesReferences(t *testing.T) {
 	factory := adminapi.NewClientFactoryForWorkspace("workspace", adminapi.HTTPClientOpts{}, "")
 
 	adminAPIHandler := mocks.NewAdminAPIHandler(t)
@@ -25,6 +25,10 @@ func TestClientFactory_CreateAdminAPIClientAttachesPodReference(t *testing.T) {
 			Namespace: "namespace",
 			Name:      "name",
 		},
+		ServiceRef: k8stypes.NamespacedName{
+			Namespace: "namespace",
+			Name:      "admin",
+		},
 	})
 	require.NoError(t, err)
 	require.NotNil(t, client)
@@ -35,4 +39,38 @@ func TestClientFactory_CreateAdminAPIClientAttachesPodReference(t *testing.T) {
 		Namespace: "namespace",
 		Name:      "name",
 	}, ref)
+
+	serviceRef, ok := client.ServiceReference()
+	require.True(t, ok, "expected service reference to be attached to the client")
+	require.Equal(t, k8stypes.NamespacedName{
+		Namespace: "namespace",
+		Name:      "admin",
+	}, serviceRef)
+}
+
+func TestClientFactory_CreateAdminAPIClientAttachesBackoffStrategy(t *testing.T) {
+	adminAPIHandler := mocks.NewAdminAPIHandler(t)
+	adminAPIServer := httptest.NewServer(adminAPIHandler)
+	t.Cleanup(func() { adminAPIServer.Close() })
+
+	t.Run("without backoff", func(t *testing.T) {
+		factory := adminapi.NewClientFactoryForWorkspace("workspace", adminapi.HTTPClientOpts{}, "")
+		client, err := factory.CreateAdminAPIClient(context.Background(), adminapi.DiscoveredAdminAPI{Address: adminAPIServer.URL})
+		require.NoError(t, err)
+		require.Nil(t, client.BackoffStrategy())
+		require.False(t, client.BackoffStatus().Open)
+	})
+
+	t.Run("with backoff", func(t *testing.T) {
+		factory := adminapi.NewClientFactoryForWorkspace("workspace", adminapi.HTTPClientOpts{}, "",
+			adminapi.WithGatewayBackoff(adminapi.GatewayBackoffConfig{
+				InitialInterval: adminapi.DefaultGatewayBackoffInitialInterval,
+				MaxInterval:     adminapi.DefaultGatewayBackoffMaxInterval,
+			}),
+		)
+		client, err := factory.CreateAdminAPIClient(context.Background(), adminapi.DiscoveredAdminAPI{Address: adminAPIServer.URL})
+		require.NoError(t, err)
+		require.NotNil(t, client.BackoffStrategy())
+		require.False(t, client.BackoffStatus().Open)
+	})
 }
@@ -0,0 +1,242 @@
+package adminapi
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// DefaultDiscoverySourcePollInterval is the default interval of polling discovery sources for changes.
+const DefaultDiscoverySourcePollInterval = 10 * time.Second
+
+// DiscoverySource discovers Admin APIs of Kong Gateways, e.g. ones running outside of the cluster.
+type DiscoverySource interface {
+	// Discover returns all Admin APIs currently discovered by the source.
+	Discover(ctx context.Context) ([]DiscoveredAdminAPI, error)
+	// String returns a human-readable description of the source.
+	String() string
+}
+
+// DiscoveredAdminAPIsNotifier is notified about all Admin APIs currently discovered by a discovery source.
+type DiscoveredAdminAPIsNotifier interface {
+	Notify(adminAPIs []DiscoveredAdminAPI)
+}
+
+// DNSSRVDiscoverySource discovers Admin APIs from targets of a DNS SRV record. Admin APIs are assumed to be served
+// over HTTPS, the same way as ones discovered from Kubernetes Services.
+type DNSSRVDiscoverySource struct {
+	name      string
+	lookupSRV func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// NewDNSSRVDiscoverySource creates a DNSSRVDiscoverySource looking up the SRV record with the given name
+// (e.g. "_kong-admin._tcp.gateways.example.com").
+func NewDNSSRVDiscoverySource(name string) *DNSSRVDiscoverySource {
+	return &DNSSRVDiscoverySource{
+		name:      name,
+		lookupSRV: net.DefaultResolver.LookupSRV,
+	}
+}
+
+// Discover implements DiscoverySource.
+func (s *DNSSRVDiscoverySource) Discover(ctx context.Context) ([]DiscoveredAdminAPI, error) {
+	_, records, err := s.lookupSRV(ctx, "", "", s.name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up DNS SRV record %s: %w", s.name, err)
+	}
+	adminAPIs := make([]DiscoveredAdminAPI, 0, len(records))
+	for _, r := range records {
+		host := net.JoinHostPort(strings.TrimSuffix(r.Target, "."), strconv.Itoa(int(r.Port)))
+		adminAPIs = append(adminAPIs, DiscoveredAdminAPI{Address: "https://" + host})
+	}
+	return adminAPIs, nil
+}
+
+// String implements DiscoverySource.
+func (s *DNSSRVDiscoverySource) String() string {
+	return "DNS SRV record " + s.name
+}
+
+// FileDiscoverySource discovers Admin APIs listed in a file, one URL per line. Empty lines and lines starting
+// with # are ignored.
+type FileDiscoverySource struct {
+	path string
+}
+
+// NewFileDiscoverySource creates a FileDiscoverySource reading Admin API URLs from the file at path.
+func NewFileDiscoverySource(path string) *FileDiscoverySource {
+	return &FileDiscoverySource{path: path}
+}
+
+// Discover implements DiscoverySource.
+func (s *FileDiscoverySource) Discover(context.Context) ([]DiscoveredAdminAPI, error) {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Admin API URLs file %s: %w", s.path, err)
+	}
+	return adminAPIsFromURLsList(string(b))
+}
+
+// String implements DiscoverySource.
+func (s *FileDiscoverySource) String() string {
+	return "file " + s.path
+}
+
+// ConfigMapDiscoverySource discovers Admin APIs listed in values of a ConfigMap, one URL per line. Empty lines and
+// lines starting with # are ignored.
+type ConfigMapDiscoverySource struct {
+	kubeClient client.Reader
+	configMap  k8stypes.NamespacedName
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+
+// NewConfigMapDiscoverySource creates a ConfigMapDiscoverySource reading Admin API URLs from the ConfigMap
+// using kubeClient.
+func NewConfigMapDiscoverySource(kubeClient client.Reader, configMap k8stypes.NamespacedName) *ConfigMapDiscoverySource {
+	return &ConfigMapDiscoverySource{
+		kubeClient: kubeClient,
+		configMap:  configMap,
+	}
+}
+
+// Discover implements DiscoverySource.
+func (s *ConfigMapDiscoverySource) Discover(ctx context.Context) ([]DiscoveredAdminAPI, error) {
+	var configMap corev1.ConfigMap
+	if err := s.kubeClient.Get(ctx, s.configMap, &configMap); err != nil {
+		return nil, fmt.Errorf("failed to get Admin API URLs ConfigMap %s: %w", s.configMap, err)
+	}
+	// Sort keys to make the order of discovered Admin APIs stable.
+	keys := lo.Keys(configMap.Data)
+	sort.Strings(keys)
+	var adminAPIs []DiscoveredAdminAPI
+	for _, k := range keys {
+		fromKey, err := adminAPIsFromURLsList(configMap.Data[k])
+		if err != nil {
+			return nil, fmt.Errorf("invalid Admin API URLs in ConfigMap %s key %q: %w", s.configMap, k, err)
+		}
+		adminAPIs = append(adminAPIs, fromKey...)
+	}
+	return adminAPIs, nil
+}
+
+// String implements DiscoverySource.
+func (s *ConfigMapDiscoverySource) String() string {
+	return "ConfigMap " + s.configMap.String()
+}
+
+// adminAPIsFromURLsList parses a list of Admin API URLs, one per line. Empty lines and lines starting with #
+// are ignored.
+func adminAPIsFromURLsList(list string) ([]DiscoveredAdminAPI, error) {
+	var adminAPIs []DiscoveredAdminAPI
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		u, err := url.Parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Admin API URL %q: %w", line, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf(`invalid Admin API URL %q: the expected format is "protocol://address:port"`, line)
+		}
+		adminAPIs = append(adminAPIs, DiscoveredAdminAPI{Address: strings.TrimSuffix(line, "/")})
+	}
+	return adminAPIs, scanner.Err()
+}
+
+// DiscoverySourcePoller is a controller-runtime Runnable that periodically polls a discovery source and notifies
+// about Admin APIs it discovers whenever they change. When polling fails, the previously discovered Admin APIs
+// are kept.
+type DiscoverySourcePoller struct {
+	logger   logr.Logger
+	source   DiscoverySource
+	notifier DiscoveredAdminAPIsNotifier
+	interval time.Duration
+
+	// lastDiscovered are addresses of Admin APIs the notifier was most recently notified about.
+	lastDiscovered []string
+}
+
+// NewDiscoverySourcePoller creates a DiscoverySourcePoller polling the source with the given interval.
+// initialAdminAPIs are Admin APIs the source discovered before, so that the notifier is notified only when they
+// change.
+func NewDiscoverySourcePoller(
+	logger logr.Logger,
+	source DiscoverySource,
+	notifier DiscoveredAdminAPIsNotifier,
+	interval time.Duration,
+	initialAdminAPIs []DiscoveredAdminAPI,
+) *DiscoverySourcePoller {
+	return &DiscoverySourcePoller{
+		logger:         logger,
+		source:         source,
+		notifier:       notifier,
+		interval:       interval,
+		lastDiscovered: sortedAddresses(initialAdminAPIs),
+	}
+}
+
+// Start implements the controller-runtime Runnable interface. It blocks until ctx is done.
+func (p *DiscoverySourcePoller) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.logger.Info("polling Admin API discovery source", "source", p.source.String(), "interval", p.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			p.poll(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection implements the controller-runtime LeaderElectionRunnable interface. Discovered Admin APIs are
+// used only by the leader, the same way as ones discovered from Kubernetes Services.
+func (p *DiscoverySourcePoller) NeedLeaderElection() bool {
+	return true
+}
+
+func (p *DiscoverySourcePoller) poll(ctx context.Context) {
+	timedCtx, cancel := context.WithTimeout(ctx, p.interval)
+	defer cancel()
+	adminAPIs, err := p.source.Discover(timedCtx)
+	if err != nil {
+		p.logger.Error(err, "failed to discover Admin APIs, keeping the previously discovered ones", "source", p.source.String())
+		return
+	}
+
+	addresses := sortedAddresses(adminAPIs)
+	if slices.Equal(p.lastDiscovered, addresses) {
+		return
+	}
+	p.logger.V(util.DebugLevel).Info("notifying about discovered Admin APIs", "source", p.source.String(), "admin_apis", addresses)
+	p.lastDiscovered = addresses
+	p.notifier.Notify(adminAPIs)
+}
+
+func sortedAddresses(adminAPIs []DiscoveredAdminAPI) []string {
+	addresses := lo.Uniq(lo.Map(adminAPIs, func(d DiscoveredAdminAPI, _ int) string { return d.Address }))
+	sort.Strings(addresses)
+	return addresses
+}
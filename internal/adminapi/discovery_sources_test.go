@@ -0,0 +1,117 @@
+package adminapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDNSSRVDiscoverySource(t *testing.T) {
+	source := NewDNSSRVDiscoverySource("_kong-admin._tcp.gateways.example.com")
+	source.lookupSRV = func(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		require.Empty(t, service)
+		require.Empty(t, proto)
+		require.Equal(t, "_kong-admin._tcp.gateways.example.com", name)
+		return name, []*net.SRV{
+			{Target: "kong-1.gateways.example.com.", Port: 8444},
+			{Target: "kong-2.gateways.example.com.", Port: 8445},
+		}, nil
+	}
+
+	adminAPIs, err := source.Discover(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []DiscoveredAdminAPI{
+		{Address: "https://kong-1.gateways.example.com:8444"},
+		{Address: "https://kong-2.gateways.example.com:8445"},
+	}, adminAPIs)
+}
+
+func TestFileDiscoverySource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "urls")
+	require.NoError(t, os.WriteFile(path, []byte("# Kong Gateways running on VMs\nhttps://10.0.0.1:8444/\n\n  http://kong-2.example.com:8001  \n"), 0o600))
+
+	adminAPIs, err := NewFileDiscoverySource(path).Discover(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []DiscoveredAdminAPI{
+		{Address: "https://10.0.0.1:8444"},
+		{Address: "http://kong-2.example.com:8001"},
+	}, adminAPIs)
+
+	t.Run("invalid URL is rejected", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("kong-1.example.com:8444\n"), 0o600))
+		_, err := NewFileDiscoverySource(path).Discover(context.Background())
+		require.ErrorContains(t, err, "the expected format is")
+	})
+}
+
+func TestConfigMapDiscoverySource(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "gateways"},
+		Data: map[string]string{
+			"zone-b": "https://10.0.1.1:8444",
+			"zone-a": "https://10.0.0.1:8444\nhttps://10.0.0.2:8444",
+		},
+	}
+	kubeClient := fake.NewClientBuilder().WithObjects(configMap).Build()
+
+	adminAPIs, err := NewConfigMapDiscoverySource(kubeClient, k8stypes.NamespacedName{Namespace: "kong", Name: "gateways"}).
+		Discover(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []DiscoveredAdminAPI{
+		{Address: "https://10.0.0.1:8444"},
+		{Address: "https://10.0.0.2:8444"},
+		{Address: "https://10.0.1.1:8444"},
+	}, adminAPIs)
+}
+
+type mockDiscoverySource struct {
+	adminAPIs []DiscoveredAdminAPI
+	err       error
+}
+
+func (s *mockDiscoverySource) Discover(context.Context) ([]DiscoveredAdminAPI, error) {
+	return s.adminAPIs, s.err
+}
+
+func (s *mockDiscoverySource) String() string {
+	return "mock"
+}
+
+type mockDiscoveredAdminAPIsNotifier struct {
+	notifications [][]DiscoveredAdminAPI
+}
+
+func (n *mockDiscoveredAdminAPIsNotifier) Notify(adminAPIs []DiscoveredAdminAPI) {
+	n.notifications = append(n.notifications, adminAPIs)
+}
+
+func TestDiscoverySourcePoller(t *testing.T) {
+	initial := []DiscoveredAdminAPI{{Address: "https://10.0.0.1:8444"}}
+	source := &mockDiscoverySource{adminAPIs: initial}
+	notifier := &mockDiscoveredAdminAPIsNotifier{}
+	poller := NewDiscoverySourcePoller(logr.Discard(), source, notifier, DefaultDiscoverySourcePollInterval, initial)
+
+	t.Log("no notification when discovered Admin APIs didn't change")
+	poller.poll(context.Background())
+	require.Empty(t, notifier.notifications)
+
+	t.Log("notification when discovered Admin APIs changed")
+	source.adminAPIs = []DiscoveredAdminAPI{{Address: "https://10.0.0.1:8444"}, {Address: "https://10.0.0.2:8444"}}
+	poller.poll(context.Background())
+	require.Equal(t, [][]DiscoveredAdminAPI{source.adminAPIs}, notifier.notifications)
+
+	t.Log("previously discovered Admin APIs are kept when discovery fails")
+	source.err = errors.New("lookup failed")
+	poller.poll(context.Background())
+	require.Len(t, notifier.notifications, 1)
+}
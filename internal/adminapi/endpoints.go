@@ -19,6 +19,9 @@ import (
 type DiscoveredAdminAPI struct {
 	Address string
 	PodRef  k8stypes.NamespacedName
+	// ServiceRef is the Service the Admin API was discovered from. It's empty when the EndpointSlice the Admin API
+	// was discovered from has no Service owner reference.
+	ServiceRef k8stypes.NamespacedName
 }
 
 type Discoverer struct {
@@ -144,6 +147,9 @@ func (d *Discoverer) AdminAPIsFromEndpointSlice(
 			if err != nil {
 				return nil, err
 			}
+			if serviceName != "" {
+				adminAPI.ServiceRef = svc
+			}
 			discoveredAdminAPIs = discoveredAdminAPIs.Insert(adminAPI)
 		}
 	}
@@ -162,6 +162,7 @@ func TestDiscoverer_AddressesFromEndpointSlice(t *testing.T) {
 					PodRef: k8stypes.NamespacedName{
 						Name: "pod-1", Namespace: namespaceName,
 					},
+					ServiceRef: k8stypes.NamespacedName{Namespace: namespaceName, Name: serviceName},
 				},
 			),
 			dnsStrategy: cfgtypes.ServiceScopedPodDNSStrategy,
@@ -310,6 +311,7 @@ func TestDiscoverer_AddressesFromEndpointSlice(t *testing.T) {
 						Namespace: namespaceName,
 						Name:      "pod-1",
 					},
+					ServiceRef: k8stypes.NamespacedName{Namespace: namespaceName, Name: serviceName},
 				},
 				DiscoveredAdminAPI{
 					Address: "https://10-0-1-1.kong-admin.ns.svc:8444",
@@ -317,6 +319,7 @@ func TestDiscoverer_AddressesFromEndpointSlice(t *testing.T) {
 						Namespace: namespaceName,
 						Name:      "pod-2",
 					},
+					ServiceRef: k8stypes.NamespacedName{Namespace: namespaceName, Name: serviceName},
 				},
 			),
 			dnsStrategy: cfgtypes.ServiceScopedPodDNSStrategy,
@@ -122,6 +122,17 @@ const (
 
 // MakeHTTPClient returns an HTTP client with the specified mTLS/headers configuration.
 func MakeHTTPClient(opts *HTTPClientOpts, kongAdminToken string) (*http.Client, error) {
+	rt, err := makeRoundTripper(opts, kongAdminToken)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: rt,
+	}, nil
+}
+
+// makeRoundTripper returns an HTTP round tripper with the specified mTLS/headers configuration.
+func makeRoundTripper(opts *HTTPClientOpts, kongAdminToken string) (*HeaderRoundTripper, error) {
 	var tlsConfig tls.Config
 
 	if opts.TLSSkipVerify {
@@ -169,11 +180,9 @@ func MakeHTTPClient(opts *HTTPClientOpts, kongAdminToken string) (*http.Client,
 
 	transport := http.DefaultTransport.(*http.Transport).Clone()
 	transport.TLSClientConfig = &tlsConfig
-	return &http.Client{
-		Transport: &HeaderRoundTripper{
-			headers: prepareHeaders(opts.Headers, kongAdminToken),
-			rt:      transport,
-		},
+	return &HeaderRoundTripper{
+		headers: prepareHeaders(opts.Headers, kongAdminToken),
+		rt:      transport,
 	}, nil
 }
 
@@ -0,0 +1,79 @@
+package adminapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServiceSelector selects Kong Admin API Services used for gateway discovery. A Service is selected when it's listed
+// in Services or, if LabelSelector is set, when its labels match it, regardless of its namespace.
+type ServiceSelector struct {
+	Services      []k8stypes.NamespacedName
+	LabelSelector labels.Selector
+}
+
+// IsEmpty tells whether the selector selects no Services at all.
+func (s ServiceSelector) IsEmpty() bool {
+	return len(s.Services) == 0 && s.LabelSelector == nil
+}
+
+// String returns a human-readable description of the selector.
+func (s ServiceSelector) String() string {
+	parts := make([]string, 0, len(s.Services)+1)
+	for _, svc := range s.Services {
+		parts = append(parts, svc.String())
+	}
+	if s.LabelSelector != nil {
+		parts = append(parts, fmt.Sprintf("labels %q", s.LabelSelector.String()))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// MatchesEndpointSlice tells whether the EndpointSlice belongs to a selected Service. Listed Services are matched by
+// the EndpointSlice's owner references, while EndpointSlices managed by Kubernetes inherit labels of their Services,
+// so they're matched against the label selector directly.
+func (s ServiceSelector) MatchesEndpointSlice(endpoints *discoveryv1.EndpointSlice) bool {
+	for _, svc := range s.Services {
+		if endpoints.Namespace != svc.Namespace {
+			continue
+		}
+		if lo.ContainsBy(endpoints.OwnerReferences, func(ref metav1.OwnerReference) bool {
+			return ref.Kind == "Service" && ref.Name == svc.Name
+		}) {
+			return true
+		}
+	}
+	return s.LabelSelector != nil && s.LabelSelector.Matches(labels.Set(endpoints.Labels))
+}
+
+// Resolve returns the selected Services sorted by their namespaced names. Services matching the label selector are
+// listed using the provided kubeClient.
+func (s ServiceSelector) Resolve(ctx context.Context, kubeClient client.Client) ([]k8stypes.NamespacedName, error) {
+	services := sets.New(s.Services...)
+	if s.LabelSelector != nil {
+		var serviceList corev1.ServiceList
+		if err := kubeClient.List(ctx, &serviceList, &client.ListOptions{LabelSelector: s.LabelSelector}); err != nil {
+			return nil, fmt.Errorf("failed to list Services matching labels %q: %w", s.LabelSelector.String(), err)
+		}
+		for _, svc := range serviceList.Items {
+			services.Insert(k8stypes.NamespacedName{Namespace: svc.Namespace, Name: svc.Name})
+		}
+	}
+
+	resolved := services.UnsortedList()
+	sort.Slice(resolved, func(i, j int) bool {
+		return resolved[i].String() < resolved[j].String()
+	})
+	return resolved, nil
+}
@@ -0,0 +1,73 @@
+package adminapi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+)
+
+func TestServiceSelector_MatchesEndpointSlice(t *testing.T) {
+	selector := adminapi.ServiceSelector{
+		Services:      []k8stypes.NamespacedName{{Namespace: "kong", Name: "admin"}},
+		LabelSelector: labels.SelectorFromSet(labels.Set{"app": "kong-admin"}),
+	}
+	endpointSlice := func(namespace, service string, lbls map[string]string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      service + "-abcde",
+				Labels:    lbls,
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "v1", Kind: "Service", Name: service},
+				},
+			},
+		}
+	}
+
+	require.True(t, selector.MatchesEndpointSlice(endpointSlice("kong", "admin", nil)),
+		"listed Service should be matched")
+	require.False(t, selector.MatchesEndpointSlice(endpointSlice("other", "admin", nil)),
+		"Service with the same name in another namespace should not be matched")
+	require.True(t, selector.MatchesEndpointSlice(endpointSlice("team-a", "gateway-admin", map[string]string{"app": "kong-admin"})),
+		"Service matching labels should be matched in any namespace")
+	require.False(t, selector.MatchesEndpointSlice(endpointSlice("team-a", "gateway-admin", map[string]string{"app": "other"})),
+		"Service not matching labels should not be matched")
+}
+
+func TestServiceSelector_Resolve(t *testing.T) {
+	service := func(namespace, name string, lbls map[string]string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: lbls}}
+	}
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		service("team-b", "admin", map[string]string{"app": "kong-admin"}),
+		service("team-a", "admin", map[string]string{"app": "kong-admin"}),
+		service("team-c", "admin", map[string]string{"app": "other"}),
+	).Build()
+
+	selector := adminapi.ServiceSelector{
+		Services: []k8stypes.NamespacedName{
+			{Namespace: "kong", Name: "admin"},
+			{Namespace: "team-a", Name: "admin"},
+		},
+		LabelSelector: labels.SelectorFromSet(labels.Set{"app": "kong-admin"}),
+	}
+	services, err := selector.Resolve(context.Background(), kubeClient)
+	require.NoError(t, err)
+	require.Equal(t, []k8stypes.NamespacedName{
+		{Namespace: "kong", Name: "admin"},
+		{Namespace: "team-a", Name: "admin"},
+		{Namespace: "team-b", Name: "admin"},
+	}, services)
+	require.Equal(t, `kong/admin, team-a/admin, labels "app=kong-admin"`, selector.String())
+	require.False(t, selector.IsEmpty())
+	require.True(t, adminapi.ServiceSelector{}.IsEmpty())
+}
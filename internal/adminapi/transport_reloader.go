@@ -0,0 +1,196 @@
+package adminapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// DefaultTransportReloadInterval is the default interval of checking files the Admin API HTTP transport is built
+// from for changes.
+const DefaultTransportReloadInterval = 10 * time.Second
+
+// TransportReloader is an HTTP round tripper for Kong Admin APIs that is rebuilt in place whenever files it's built
+// from (CA certificate, client certificate and key, admin token) change, e.g. when they're rotated by cert-manager.
+// All HTTP clients it makes share it, so they pick up the new TLS material and token without being recreated.
+// Requests that are in flight during a rebuild are completed using the previous transport.
+type TransportReloader struct {
+	logger         logr.Logger
+	opts           HTTPClientOpts
+	adminToken     string
+	adminTokenPath string
+	interval       time.Duration
+	metrics        *metrics.AdminAPITransportMetrics
+
+	current atomic.Pointer[HeaderRoundTripper]
+
+	// lock guards filesHash and serializes reloads.
+	lock sync.Mutex
+	// filesHash is the hash of contents of the files the transport was most recently attempted to be built from.
+	filesHash []byte
+}
+
+// NewTransportReloader creates a TransportReloader building the transport from opts and the admin token. When
+// adminTokenPath is set, the token is read from it instead and the file is watched along with TLS material files.
+func NewTransportReloader(
+	logger logr.Logger,
+	opts HTTPClientOpts,
+	adminToken string,
+	adminTokenPath string,
+	interval time.Duration,
+) (*TransportReloader, error) {
+	r := &TransportReloader{
+		logger:         logger,
+		opts:           opts,
+		adminToken:     adminToken,
+		adminTokenPath: adminTokenPath,
+		interval:       interval,
+		metrics:        metrics.NewAdminAPITransportMetrics(),
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// RoundTrip satisfies the RoundTripper interface using the most recently built transport.
+func (r *TransportReloader) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r.current.Load().RoundTrip(req)
+}
+
+// HTTPClient returns an HTTP client using the reloaded transport.
+func (r *TransportReloader) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Start implements the controller-runtime Runnable interface. It checks the watched files for changes with
+// the configured interval and rebuilds the transport when they change. It blocks until ctx is done.
+func (r *TransportReloader) Start(ctx context.Context) error {
+	files := r.watchedFiles()
+	if len(files) == 0 || r.interval <= 0 {
+		r.logger.V(util.DebugLevel).Info("no Admin API TLS material or token files to watch")
+		return nil
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.logger.Info("watching Admin API TLS material and token files for changes", "files", files, "interval", r.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.reloadIfChanged()
+		}
+	}
+}
+
+// NeedLeaderElection implements the controller-runtime LeaderElectionRunnable interface. All replicas communicate
+// with Admin APIs, so they all have to reload the transport.
+func (r *TransportReloader) NeedLeaderElection() bool {
+	return false
+}
+
+// reloadIfChanged rebuilds the transport when any of the watched files changed since the last attempt. When files
+// can't be loaded (e.g. a certificate was rotated without its key yet), the previous transport keeps being used until
+// the files change again.
+func (r *TransportReloader) reloadIfChanged() {
+	hash, err := r.hashWatchedFiles()
+	if err != nil {
+		r.logger.Error(err, "failed to read Admin API TLS material or token files")
+		return
+	}
+	r.lock.Lock()
+	changed := !bytes.Equal(hash, r.filesHash)
+	r.lock.Unlock()
+	if !changed {
+		return
+	}
+
+	err = r.reload()
+	r.metrics.RecordTransportReload(err)
+	if err != nil {
+		r.logger.Error(err, "failed to reload Admin API TLS material or token, keeping the previous ones")
+		return
+	}
+	r.logger.Info("reloaded Admin API TLS material and token after their files changed")
+}
+
+// reload rebuilds the transport from the current contents of the watched files.
+func (r *TransportReloader) reload() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	hash, err := r.hashWatchedFiles()
+	if err != nil {
+		return err
+	}
+	r.filesHash = hash
+
+	token := r.adminToken
+	if r.adminTokenPath != "" {
+		b, err := os.ReadFile(r.adminTokenPath)
+		if err != nil {
+			return fmt.Errorf("failed to read --kong-admin-token-file from path '%s': %w", r.adminTokenPath, err)
+		}
+		token = string(b)
+	}
+	rt, err := makeRoundTripper(&r.opts, token)
+	if err != nil {
+		return err
+	}
+
+	if previous := r.current.Swap(rt); previous != nil {
+		// Connections of in-flight requests are not idle, so they're not affected.
+		if t, ok := previous.rt.(interface{ CloseIdleConnections() }); ok {
+			t.CloseIdleConnections()
+		}
+	}
+	return nil
+}
+
+// watchedFiles returns paths of files the transport is built from.
+func (r *TransportReloader) watchedFiles() []string {
+	var files []string
+	for _, f := range []string{
+		r.opts.CACertPath,
+		r.opts.TLSClient.CertFile,
+		r.opts.TLSClient.KeyFile,
+		r.adminTokenPath,
+	} {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// hashWatchedFiles returns a hash of contents of the watched files. Contents are compared instead of modification
+// times, as files of mounted Kubernetes Secrets are replaced by swapping symlinks.
+func (r *TransportReloader) hashWatchedFiles() ([]byte, error) {
+	h := sha256.New()
+	for _, f := range r.watchedFiles() {
+		b, err := os.ReadFile(f)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		// A missing file (e.g. in the middle of a rotation) is hashed as empty, so it's reported once loading it fails.
+		_, _ = h.Write([]byte(f))
+		_, _ = h.Write(b)
+	}
+	return h.Sum(nil), nil
+}
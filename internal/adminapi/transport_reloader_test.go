@@ -0,0 +1,86 @@
+package adminapi_test
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/test/helpers/certificate"
+)
+
+func TestTransportReloader(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get(adminapi.HeaderNameAdminToken)))
+	}))
+	t.Cleanup(server.Close)
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	unrelatedCA, _ := certificate.MustGenerateSelfSignedCertPEMFormat()
+
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.crt")
+	tokenPath := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(caPath, unrelatedCA, 0o600))
+	require.NoError(t, os.WriteFile(tokenPath, []byte("token-1"), 0o600))
+
+	reloader, err := adminapi.NewTransportReloader(
+		logr.Discard(),
+		adminapi.HTTPClientOpts{CACertPath: caPath},
+		"",
+		tokenPath,
+		10*time.Millisecond,
+	)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		assert.NoError(t, reloader.Start(ctx))
+	}()
+
+	// The client is created once and expected to pick up the rotated files.
+	httpClient := reloader.HTTPClient()
+	get := func() (string, error) {
+		resp, err := httpClient.Get(server.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		return string(b), err
+	}
+
+	t.Log("server's certificate is not trusted by the unrelated CA")
+	_, err = get()
+	require.Error(t, err)
+
+	t.Log("CA certificate rotated")
+	require.NoError(t, os.WriteFile(caPath, serverCA, 0o600))
+	require.Eventually(t, func() bool {
+		token, err := get()
+		return err == nil && token == "token-1"
+	}, time.Second, 10*time.Millisecond)
+
+	t.Log("token rotated")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("token-2"), 0o600))
+	require.Eventually(t, func() bool {
+		token, err := get()
+		return err == nil && token == "token-2"
+	}, time.Second, 10*time.Millisecond)
+
+	t.Log("invalid CA certificate is rejected and the previous transport is kept")
+	require.NoError(t, os.WriteFile(caPath, []byte("not a certificate"), 0o600))
+	require.Never(t, func() bool {
+		token, err := get()
+		return err != nil || token != "token-2"
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
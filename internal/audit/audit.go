@@ -0,0 +1,204 @@
+// Package audit implements a structured audit log of changes the controller makes to Kong: configuration pushes
+// and mutating Admin API requests. Each record is written as a single line of JSON.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Kind is a kind of audit record.
+type Kind string
+
+const (
+	// KindConfigPush is a record of a configuration push to Kong Gateway or Konnect.
+	KindConfigPush Kind = "config_push"
+	// KindAdminAPIRequest is a record of a mutating request sent to the Kong Admin API.
+	KindAdminAPIRequest Kind = "admin_api_request"
+)
+
+// Outcome is an outcome of an audited action.
+type Outcome string
+
+const (
+	// OutcomeSuccess means the action succeeded.
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure means the action failed.
+	OutcomeFailure Outcome = "failure"
+	// OutcomeSkipped means the action was not attempted (e.g. due to a backoff of configuration updates).
+	OutcomeSkipped Outcome = "skipped"
+)
+
+// Record is a single audit log record.
+type Record struct {
+	// Time is the time the action started.
+	Time time.Time `json:"time"`
+	// Kind is the kind of the record.
+	Kind Kind `json:"kind"`
+	// GatewayURL is the Admin API URL of Kong Gateway (or Konnect) the action targeted.
+	GatewayURL string `json:"gateway_url"`
+	// Outcome is the outcome of the action.
+	Outcome Outcome `json:"outcome"`
+	// DurationMilliseconds is how long the action took.
+	DurationMilliseconds float64 `json:"duration_ms"`
+	// StatusCode is the HTTP status code of the Admin API response, when known.
+	StatusCode int `json:"status_code,omitempty"`
+	// Error is the error the action failed with.
+	Error string `json:"error,omitempty"`
+
+	// ConfigHash is the hash of the pushed configuration (config pushes only).
+	ConfigHash string `json:"config_hash,omitempty"`
+	// EntityCounts are counts of Kong entities in the pushed configuration by their type (config pushes only).
+	EntityCounts map[string]int `json:"entity_counts,omitempty"`
+	// UpdateStrategy is the strategy the configuration was pushed with (config pushes only).
+	UpdateStrategy string `json:"update_strategy,omitempty"`
+	// TriggeredBy are Kubernetes objects whose changes triggered the push (config pushes only). It's empty for
+	// pushes done periodically or retried without any changes.
+	TriggeredBy *Triggers `json:"triggered_by,omitempty"`
+
+	// Method is the HTTP method of the request (Admin API requests only).
+	Method string `json:"method,omitempty"`
+	// Path is the path of the request URL (Admin API requests only).
+	Path string `json:"path,omitempty"`
+	// Request is the raw request captured in debug mode (Admin API requests only).
+	Request *CapturedRequest `json:"request,omitempty"`
+}
+
+// CapturedRequest is a raw Admin API request captured for debugging.
+type CapturedRequest struct {
+	// Headers are headers of the request with credentials redacted.
+	Headers map[string][]string `json:"headers"`
+	// Body is the body of the request, up to a limit.
+	Body string `json:"body,omitempty"`
+	// BodyTruncated tells whether the body was longer than the limit.
+	BodyTruncated bool `json:"body_truncated,omitempty"`
+}
+
+// Logger writes audit records as lines of JSON to a sink. It's safe for concurrent use.
+type Logger struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewLogger creates a Logger writing to w.
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{encoder: json.NewEncoder(w)}
+}
+
+// Record writes the record to the sink.
+func (l *Logger) Record(r Record) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if err := l.encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+// ObjectRef refers to a Kubernetes object.
+type ObjectRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// NewObjectRef creates an ObjectRef referring to obj. When obj doesn't have its kind set (as typed objects read from
+// a cache usually don't), the name of its Go type is used instead.
+func NewObjectRef(obj client.Object) ObjectRef {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		kind = reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+	}
+	return ObjectRef{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
+// Triggers are Kubernetes objects whose changes triggered a configuration push.
+type Triggers struct {
+	// Objects are the changed objects, up to MaxTriggeringObjects.
+	Objects []ObjectRef `json:"objects"`
+	// Omitted is the number of changed objects that are not listed in Objects due to the limit.
+	Omitted int `json:"omitted,omitempty"`
+}
+
+// MaxTriggeringObjects limits the number of objects tracked by ChangedObjects, so that a bulk change (e.g. on
+// the controller's startup) doesn't make records huge.
+const MaxTriggeringObjects = 100
+
+// ChangedObjects collects Kubernetes objects changed since the last configuration push. It's not safe for concurrent
+// use.
+type ChangedObjects struct {
+	objects map[ObjectRef]struct{}
+	omitted int
+}
+
+// Add records a change of obj.
+func (c *ChangedObjects) Add(obj client.Object) {
+	c.add(NewObjectRef(obj))
+}
+
+func (c *ChangedObjects) add(ref ObjectRef) {
+	if c.objects == nil {
+		c.objects = map[ObjectRef]struct{}{}
+	}
+	if _, ok := c.objects[ref]; ok {
+		return
+	}
+	if len(c.objects) >= MaxTriggeringObjects {
+		c.omitted++
+		return
+	}
+	c.objects[ref] = struct{}{}
+}
+
+// Take returns objects changed since the last Take and forgets them.
+func (c *ChangedObjects) Take() Triggers {
+	t := Triggers{Objects: make([]ObjectRef, 0, len(c.objects)), Omitted: c.omitted}
+	for ref := range c.objects {
+		t.Objects = append(t.Objects, ref)
+	}
+	sort.Slice(t.Objects, func(i, j int) bool {
+		a, b := t.Objects[i], t.Objects[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	c.objects, c.omitted = nil, 0
+	return t
+}
+
+// Restore records again changes returned by Take, e.g. when pushing them failed.
+func (c *ChangedObjects) Restore(t Triggers) {
+	for _, ref := range t.Objects {
+		c.add(ref)
+	}
+	c.omitted += t.Omitted
+}
+
+type triggersContextKey struct{}
+
+// WithTriggers returns a context carrying Kubernetes objects whose changes triggered configuration pushes done with it.
+func WithTriggers(ctx context.Context, t Triggers) context.Context {
+	return context.WithValue(ctx, triggersContextKey{}, t)
+}
+
+// TriggersFromContext returns Kubernetes objects whose changes triggered configuration pushes done with ctx.
+func TriggersFromContext(ctx context.Context) (Triggers, bool) {
+	t, ok := ctx.Value(triggersContextKey{}).(Triggers)
+	return t, ok
+}
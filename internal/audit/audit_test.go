@@ -0,0 +1,127 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
+)
+
+func TestLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := audit.NewLogger(buf)
+
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, logger.Record(audit.Record{
+		Time:                 now,
+		Kind:                 audit.KindConfigPush,
+		GatewayURL:           "https://10.0.0.1:8444",
+		Outcome:              audit.OutcomeSuccess,
+		DurationMilliseconds: 12.5,
+		ConfigHash:           "abc",
+		EntityCounts:         map[string]int{"services": 2},
+		TriggeredBy: &audit.Triggers{
+			Objects: []audit.ObjectRef{{Kind: "Ingress", Namespace: "default", Name: "echo"}},
+		},
+	}))
+	require.NoError(t, logger.Record(audit.Record{
+		Time:       now,
+		Kind:       audit.KindAdminAPIRequest,
+		GatewayURL: "https://10.0.0.1:8444",
+		Outcome:    audit.OutcomeFailure,
+		Method:     "POST",
+		Path:       "/config",
+		StatusCode: 400,
+	}))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2, "each record should be written as a single line")
+	require.JSONEq(t, `{
+		"time": "2023-10-01T12:00:00Z",
+		"kind": "config_push",
+		"gateway_url": "https://10.0.0.1:8444",
+		"outcome": "success",
+		"duration_ms": 12.5,
+		"config_hash": "abc",
+		"entity_counts": {"services": 2},
+		"triggered_by": {"objects": [{"kind": "Ingress", "namespace": "default", "name": "echo"}]}
+	}`, string(lines[0]))
+	require.JSONEq(t, `{
+		"time": "2023-10-01T12:00:00Z",
+		"kind": "admin_api_request",
+		"gateway_url": "https://10.0.0.1:8444",
+		"outcome": "failure",
+		"duration_ms": 0,
+		"status_code": 400,
+		"method": "POST",
+		"path": "/config"
+	}`, string(lines[1]))
+}
+
+func TestNewObjectRef(t *testing.T) {
+	t.Run("kind is taken from the object's type meta", func(t *testing.T) {
+		obj := &netv1.Ingress{
+			TypeMeta:   metav1.TypeMeta{Kind: "Ingress", APIVersion: "networking.k8s.io/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "echo", Namespace: "default"},
+		}
+		require.Equal(t, audit.ObjectRef{Kind: "Ingress", Namespace: "default", Name: "echo"}, audit.NewObjectRef(obj))
+	})
+
+	t.Run("kind falls back to the object's Go type", func(t *testing.T) {
+		obj := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "echo", Namespace: "default"}}
+		require.Equal(t, audit.ObjectRef{Kind: "Service", Namespace: "default", Name: "echo"}, audit.NewObjectRef(obj))
+	})
+}
+
+func TestChangedObjects(t *testing.T) {
+	newService := func(name string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+
+	var changed audit.ChangedObjects
+	changed.Add(newService("b"))
+	changed.Add(newService("a"))
+	changed.Add(newService("b"))
+
+	triggers := changed.Take()
+	require.Equal(t, audit.Triggers{Objects: []audit.ObjectRef{
+		{Kind: "Service", Namespace: "default", Name: "a"},
+		{Kind: "Service", Namespace: "default", Name: "b"},
+	}}, triggers)
+	require.Empty(t, changed.Take().Objects, "taken objects should be forgotten")
+
+	changed.Add(newService("c"))
+	changed.Restore(triggers)
+	require.Len(t, changed.Take().Objects, 3, "restored objects should be taken again")
+
+	for i := 0; i < audit.MaxTriggeringObjects+10; i++ {
+		changed.Add(newService(fmt.Sprintf("service-%d", i)))
+	}
+	triggers = changed.Take()
+	require.Len(t, triggers.Objects, audit.MaxTriggeringObjects)
+	require.Equal(t, 10, triggers.Omitted)
+}
+
+func TestTriggersContext(t *testing.T) {
+	_, ok := audit.TriggersFromContext(context.Background())
+	require.False(t, ok)
+
+	triggers := audit.Triggers{Objects: []audit.ObjectRef{{Kind: "Ingress", Name: "echo"}}}
+	got, ok := audit.TriggersFromContext(audit.WithTriggers(context.Background(), triggers))
+	require.True(t, ok)
+	require.Equal(t, triggers, got)
+
+	// Records are plain JSON, so that they can be processed by log collectors.
+	b, err := json.Marshal(got)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"objects": [{"kind": "Ingress", "name": "echo"}]}`, string(b))
+}
@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+const (
+	// StdoutSinkPath is the sink path making audit records written to the standard output.
+	StdoutSinkPath = "-"
+
+	// DefaultMaxFileSizeMegabytes is the default size of an audit log file after which it's rotated.
+	DefaultMaxFileSizeMegabytes = 100
+	// DefaultMaxFileBackups is the default number of rotated audit log files that are kept.
+	DefaultMaxFileBackups = 5
+)
+
+// NewSink opens a sink audit records are written to. When path is StdoutSinkPath, records are written to the standard
+// output. Otherwise, they're written to a file at path that is rotated once it grows over maxSizeMegabytes, keeping
+// up to maxBackups rotated files.
+func NewSink(path string, maxSizeMegabytes, maxBackups int) (io.WriteCloser, error) {
+	if path == StdoutSinkPath {
+		return nopCloser{os.Stdout}, nil
+	}
+	return NewRotatingFile(path, int64(maxSizeMegabytes)*1024*1024, maxBackups)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// RotatingFile is a file that is rotated once it grows over a limit: the file is renamed with a ".1" suffix (shifting
+// previously rotated files to ".2", ".3" and so on) and a new one is created in its place. It's safe for concurrent use.
+type RotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	lock sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewRotatingFile opens (or creates) a file at path, appending to it. It's rotated once it grows over maxSize bytes,
+// keeping up to maxBackups rotated files.
+func NewRotatingFile(path string, maxSize int64, maxBackups int) (*RotatingFile, error) {
+	f := &RotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write writes p to the file, rotating it first when p would make it grow over the limit. p is never split between
+// files, so that records are not broken.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the file.
+func (f *RotatingFile) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.file.Close()
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat audit log file: %w", err)
+	}
+	f.file, f.size = file, info.Size()
+	return nil
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log file: %w", err)
+	}
+
+	if f.maxBackups > 0 {
+		// The oldest backup is overwritten by the next one.
+		for i := f.maxBackups - 1; i > 0; i-- {
+			if err := renameIfExists(f.backupPath(i), f.backupPath(i+1)); err != nil {
+				return err
+			}
+		}
+		if err := renameIfExists(f.path, f.backupPath(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove audit log file: %w", err)
+	}
+
+	return f.open()
+}
+
+func (f *RotatingFile) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", f.path, i)
+}
+
+func renameIfExists(from, to string) error {
+	if err := os.Rename(from, to); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate audit log file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package audit_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	readFile := func(t *testing.T, path string) string {
+		t.Helper()
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	f, err := audit.NewRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+
+	t.Log("writes within the limit go to the same file")
+	_, err = f.Write([]byte("11111\n"))
+	require.NoError(t, err)
+	_, err = f.Write([]byte("222\n"))
+	require.NoError(t, err)
+	require.Equal(t, "11111\n222\n", readFile(t, path))
+
+	t.Log("a write over the limit rotates the file")
+	_, err = f.Write([]byte("33333\n"))
+	require.NoError(t, err)
+	require.Equal(t, "33333\n", readFile(t, path))
+	require.Equal(t, "11111\n222\n", readFile(t, path+".1"))
+
+	t.Log("only the configured number of rotated files is kept")
+	_, err = f.Write([]byte("44444\n"))
+	require.NoError(t, err)
+	_, err = f.Write([]byte("55555\n"))
+	require.NoError(t, err)
+	require.Equal(t, "55555\n", readFile(t, path))
+	require.Equal(t, "44444\n", readFile(t, path+".1"))
+	require.Equal(t, "33333\n", readFile(t, path+".2"))
+	require.NoFileExists(t, path+".3")
+	require.NoError(t, f.Close())
+
+	t.Log("reopened file is appended to")
+	f, err = audit.NewRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("6\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Equal(t, "55555\n6\n", readFile(t, path))
+}
+
+func TestRotatingFile_WithoutBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	f, err := audit.NewRotatingFile(path, 10, 0)
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte(strings.Repeat("1", 8) + "\n"))
+	require.NoError(t, err)
+	_, err = f.Write([]byte("2\n"))
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "2\n", string(b))
+	require.NoFileExists(t, path+".1")
+}
@@ -0,0 +1,69 @@
+package clients
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/samber/lo"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+)
+
+// DiscoveredAdminAPIsMerger merges Admin APIs discovered by multiple discovery sources (e.g. EndpointSlices of
+// Kong Admin API Services and DNS SRV records). AdminAPIClientsManager.Notify expects all Admin APIs that should be
+// used, so a notification from a single source can't be passed to it directly.
+type DiscoveredAdminAPIsMerger struct {
+	notifier adminapi.DiscoveredAdminAPIsNotifier
+
+	// lock guards discovered and serializes notifications, so that they're delivered in order.
+	lock sync.Mutex
+	// discovered are Admin APIs most recently discovered by each of the sources, keyed by source names.
+	discovered map[string][]adminapi.DiscoveredAdminAPI
+}
+
+// NewDiscoveredAdminAPIsMerger creates a DiscoveredAdminAPIsMerger notifying the notifier (AdminAPIClientsManager
+// in particular) about Admin APIs discovered by all the sources.
+func NewDiscoveredAdminAPIsMerger(notifier adminapi.DiscoveredAdminAPIsNotifier) *DiscoveredAdminAPIsMerger {
+	return &DiscoveredAdminAPIsMerger{
+		notifier:   notifier,
+		discovered: make(map[string][]adminapi.DiscoveredAdminAPI),
+	}
+}
+
+// SourceNotifier returns a notifier of the named source. Every notification replaces Admin APIs previously
+// discovered by the source. initialAdminAPIs are Admin APIs the source discovered before it was registered
+// (e.g. on startup), so that they're not dropped when other sources notify first.
+func (m *DiscoveredAdminAPIsMerger) SourceNotifier(
+	source string, initialAdminAPIs []adminapi.DiscoveredAdminAPI,
+) adminapi.DiscoveredAdminAPIsNotifier {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.discovered[source] = initialAdminAPIs
+	return sourceNotifier{merger: m, source: source}
+}
+
+func (m *DiscoveredAdminAPIsMerger) notify(source string, adminAPIs []adminapi.DiscoveredAdminAPI) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.discovered[source] = adminAPIs
+
+	// Iterate over sources in a stable order, so that an Admin API discovered by multiple sources is always
+	// taken from the same one.
+	sources := lo.Keys(m.discovered)
+	sort.Strings(sources)
+	var merged []adminapi.DiscoveredAdminAPI
+	for _, s := range sources {
+		merged = append(merged, m.discovered[s]...)
+	}
+	m.notifier.Notify(lo.UniqBy(merged, func(d adminapi.DiscoveredAdminAPI) string { return d.Address }))
+}
+
+// sourceNotifier notifies DiscoveredAdminAPIsMerger about Admin APIs discovered by a single source.
+type sourceNotifier struct {
+	merger *DiscoveredAdminAPIsMerger
+	source string
+}
+
+func (n sourceNotifier) Notify(adminAPIs []adminapi.DiscoveredAdminAPI) {
+	n.merger.notify(n.source, adminAPIs)
+}
@@ -0,0 +1,38 @@
+package clients_test
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/clients"
+)
+
+type recordingNotifier struct {
+	notified [][]string
+}
+
+func (n *recordingNotifier) Notify(adminAPIs []adminapi.DiscoveredAdminAPI) {
+	n.notified = append(n.notified, lo.Map(adminAPIs, func(d adminapi.DiscoveredAdminAPI, _ int) string { return d.Address }))
+}
+
+func TestDiscoveredAdminAPIsMerger(t *testing.T) {
+	notifier := &recordingNotifier{}
+	merger := clients.NewDiscoveredAdminAPIsMerger(notifier)
+	services := merger.SourceNotifier("services", []adminapi.DiscoveredAdminAPI{{Address: "https://10.0.0.1:8444"}})
+	dns := merger.SourceNotifier("dns-srv", nil)
+
+	t.Log("initial Admin APIs of a source are kept when another source notifies")
+	dns.Notify([]adminapi.DiscoveredAdminAPI{{Address: "https://kong-1.example.com:8444"}})
+	require.Equal(t, []string{"https://kong-1.example.com:8444", "https://10.0.0.1:8444"}, notifier.notified[0])
+
+	t.Log("notification of a source replaces its previously discovered Admin APIs")
+	services.Notify([]adminapi.DiscoveredAdminAPI{{Address: "https://10.0.0.2:8444"}})
+	require.Equal(t, []string{"https://kong-1.example.com:8444", "https://10.0.0.2:8444"}, notifier.notified[1])
+
+	t.Log("Admin APIs discovered by multiple sources are deduplicated")
+	dns.Notify([]adminapi.DiscoveredAdminAPI{{Address: "https://10.0.0.2:8444"}})
+	require.Equal(t, []string{"https://10.0.0.2:8444"}, notifier.notified[2])
+}
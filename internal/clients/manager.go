@@ -31,6 +31,13 @@ type AdminAPIClientsProvider interface {
 	GatewayClients() []*adminapi.Client
 }
 
+// LaggingGatewayClientsTracker keeps track of gateway clients that failed to get the most recent configuration,
+// so that sending it can be retried only for them.
+type LaggingGatewayClientsTracker interface {
+	MarkLaggingGatewayClients(urls ...string)
+	LaggingGatewayClients() []*adminapi.Client
+}
+
 // Ticker is an interface that allows to control a ticker.
 type Ticker interface {
 	Stop()
@@ -60,6 +67,9 @@ type AdminAPIClientsManager struct {
 	// configured.
 	pendingGatewayClients map[string]adminapi.DiscoveredAdminAPI
 
+	// laggingGatewayClients are URLs of Kong Gateway data-planes that failed to get the most recent configuration.
+	laggingGatewayClients map[string]struct{}
+
 	// readinessChecker is used to check readiness of the clients.
 	readinessChecker ReadinessChecker
 
@@ -70,6 +80,9 @@ type AdminAPIClientsManager struct {
 	// This client is used to synchronise configuration with Konnect's Control Plane Admin API.
 	konnectClient *adminapi.KonnectClient
 
+	// allowNoInitialClients allows creating the manager without initial clients.
+	allowNoInitialClients bool
+
 	// lock prevents concurrent access to the manager's fields.
 	lock sync.RWMutex
 
@@ -85,6 +98,15 @@ func WithReadinessReconciliationTicker(ticker Ticker) AdminAPIClientsManagerOpti
 	}
 }
 
+// WithNoInitialClientsAllowed allows creating the manager without initial clients. It's meant to be used when
+// the configuration is not sent to Kong Gateways via Admin API (e.g. when it's written to declarative configuration
+// outputs only).
+func WithNoInitialClientsAllowed() AdminAPIClientsManagerOption {
+	return func(m *AdminAPIClientsManager) {
+		m.allowNoInitialClients = true
+	}
+}
+
 func NewAdminAPIClientsManager(
 	ctx context.Context,
 	logger logr.Logger,
@@ -92,16 +114,13 @@ func NewAdminAPIClientsManager(
 	readinessChecker ReadinessChecker,
 	opts ...AdminAPIClientsManagerOption,
 ) (*AdminAPIClientsManager, error) {
-	if len(initialClients) == 0 {
-		return nil, errors.New("at least one initial client must be provided")
-	}
-
 	readyClients := lo.SliceToMap(initialClients, func(c *adminapi.Client) (string, *adminapi.Client) {
 		return c.BaseRootURL(), c
 	})
 	c := &AdminAPIClientsManager{
 		readyGatewayClients:           readyClients,
 		pendingGatewayClients:         make(map[string]adminapi.DiscoveredAdminAPI),
+		laggingGatewayClients:         make(map[string]struct{}),
 		readinessChecker:              readinessChecker,
 		readinessReconciliationTicker: clock.NewTicker(),
 		discoveredAdminAPIsNotifyChan: make(chan []adminapi.DiscoveredAdminAPI),
@@ -114,6 +133,10 @@ func NewAdminAPIClientsManager(
 		opt(c)
 	}
 
+	if len(initialClients) == 0 && !c.allowNoInitialClients {
+		return nil, errors.New("at least one initial client must be provided")
+	}
+
 	return c, nil
 }
 
@@ -173,6 +196,28 @@ func (c *AdminAPIClientsManager) GatewayClients() []*adminapi.Client {
 	return lo.Values(c.readyGatewayClients)
 }
 
+// MarkLaggingGatewayClients marks gateway clients with the given URLs as lagging, i.e. not having the most recent
+// configuration applied. It replaces previously marked clients, so calling it without URLs marks all clients
+// as having the most recent configuration applied.
+func (c *AdminAPIClientsManager) MarkLaggingGatewayClients(urls ...string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	maps.Clear(c.laggingGatewayClients)
+	for _, url := range urls {
+		c.laggingGatewayClients[url] = struct{}{}
+	}
+}
+
+// LaggingGatewayClients returns ready gateway clients that were marked as lagging with MarkLaggingGatewayClients.
+func (c *AdminAPIClientsManager) LaggingGatewayClients() []*adminapi.Client {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return lo.Filter(lo.Values(c.readyGatewayClients), func(cl *adminapi.Client, _ int) bool {
+		_, lagging := c.laggingGatewayClients[cl.BaseRootURL()]
+		return lagging
+	})
+}
+
 func (c *AdminAPIClientsManager) GatewayClientsCount() int {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
@@ -162,6 +162,14 @@ func TestNewAdminAPIClientsManager_NoInitialClientsDisallowed(t *testing.T) {
 	require.ErrorContains(t, err, "at least one initial client must be provided")
 }
 
+func TestNewAdminAPIClientsManager_NoInitialClientsAllowedWithOption(t *testing.T) {
+	m, err := clients.NewAdminAPIClientsManager(context.Background(), zapr.NewLogger(zap.NewNop()), nil, &mockReadinessChecker{},
+		clients.WithNoInitialClientsAllowed(),
+	)
+	require.NoError(t, err)
+	require.Empty(t, m.GatewayClients())
+}
+
 func TestAdminAPIClientsManager_NotRunningNotifyLoop(t *testing.T) {
 	t.Parallel()
 
@@ -487,3 +495,26 @@ func testDiscoveredAdminAPI(address string) adminapi.DiscoveredAdminAPI {
 		PodRef:  k8stypes.NamespacedName{Name: "pod-1", Namespace: "ns"},
 	}
 }
+
+func TestAdminAPIClientsManager_LaggingGatewayClients(t *testing.T) {
+	testClient1, err := adminapi.NewTestClient(testURL1)
+	require.NoError(t, err)
+	testClient2, err := adminapi.NewTestClient(testURL2)
+	require.NoError(t, err)
+	m, err := clients.NewAdminAPIClientsManager(
+		context.Background(),
+		zapr.NewLogger(zap.NewNop()),
+		[]*adminapi.Client{testClient1, testClient2},
+		&mockReadinessChecker{},
+	)
+	require.NoError(t, err)
+	require.Empty(t, m.LaggingGatewayClients(), "no clients should be lagging initially")
+
+	m.MarkLaggingGatewayClients(testURL1, "https://not-a-ready-client:8444")
+	lagging := m.LaggingGatewayClients()
+	require.Len(t, lagging, 1, "only ready clients should be returned as lagging")
+	require.Equal(t, testURL1, lagging[0].BaseRootURL())
+
+	m.MarkLaggingGatewayClients()
+	require.Empty(t, m.LaggingGatewayClients(), "marking no clients should mark all clients as having the most recent configuration")
+}
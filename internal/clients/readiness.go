@@ -2,7 +2,6 @@ package clients
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -50,6 +49,7 @@ type ReadinessChecker interface {
 type AlreadyCreatedClient interface {
 	IsReady(context.Context) error
 	PodReference() (k8stypes.NamespacedName, bool)
+	ServiceReference() (k8stypes.NamespacedName, bool)
 	BaseRootURL() string
 }
 
@@ -118,19 +118,16 @@ func (c DefaultReadinessChecker) checkAlreadyExistingClients(ctx context.Context
 	for _, client := range alreadyCreatedClients {
 		// For ready clients we check readiness by calling the Status endpoint.
 		if ready := c.checkAlreadyCreatedClient(ctx, client); !ready {
-			podRef, ok := client.PodReference()
-			if !ok {
-				// This should never happen, but if it does, we want to log it.
-				c.logger.Error(
-					errors.New("missing pod reference"),
-					fmt.Sprintf("failed to get PodReference for client %q", client.BaseRootURL()),
-				)
-				continue
+			// Clients of Admin APIs discovered outside of Kubernetes (e.g. through DNS) have no Pod or Service
+			// references, their readiness is checked the same way though.
+			adminAPI := adminapi.DiscoveredAdminAPI{Address: client.BaseRootURL()}
+			if podRef, ok := client.PodReference(); ok {
+				adminAPI.PodRef = podRef
 			}
-			turnedPending = append(turnedPending, adminapi.DiscoveredAdminAPI{
-				Address: client.BaseRootURL(),
-				PodRef:  podRef,
-			})
+			if serviceRef, ok := client.ServiceReference(); ok {
+				adminAPI.ServiceRef = serviceRef
+			}
+			turnedPending = append(turnedPending, adminAPI)
 		}
 	}
 	return turnedPending
@@ -58,6 +58,8 @@ func (cf mockClientFactory) CreateAdminAPIClient(_ context.Context, adminAPI adm
 type mockAlreadyCreatedClient struct {
 	url     string
 	isReady bool
+	// noPodRef makes the client mimic ones of Admin APIs discovered outside of Kubernetes (e.g. through DNS).
+	noPodRef bool
 }
 
 func (m mockAlreadyCreatedClient) IsReady(context.Context) error {
@@ -68,9 +70,16 @@ func (m mockAlreadyCreatedClient) IsReady(context.Context) error {
 }
 
 func (m mockAlreadyCreatedClient) PodReference() (k8stypes.NamespacedName, bool) {
+	if m.noPodRef {
+		return k8stypes.NamespacedName{}, false
+	}
 	return testPodRef, true
 }
 
+func (m mockAlreadyCreatedClient) ServiceReference() (k8stypes.NamespacedName, bool) {
+	return k8stypes.NamespacedName{}, false
+}
+
 func (m mockAlreadyCreatedClient) BaseRootURL() string {
 	return m.url
 }
@@ -96,6 +105,17 @@ func TestDefaultReadinessChecker(t *testing.T) {
 			},
 			expectedTurnedPending: []string{testURL1},
 		},
+		{
+			name: "ready without pod reference turning pending",
+			alreadyCreatedClients: []clients.AlreadyCreatedClient{
+				mockAlreadyCreatedClient{
+					url:      testURL1,
+					isReady:  false,
+					noPodRef: true,
+				},
+			},
+			expectedTurnedPending: []string{testURL1},
+		},
 		{
 			name: "pending turning ready",
 			pendingClients: []adminapi.DiscoveredAdminAPI{
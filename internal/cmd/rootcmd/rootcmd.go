@@ -15,11 +15,13 @@ import (
 // Execute is the entry point to the controller manager.
 func Execute() {
 	var (
-		cfg        manager.Config
-		rootCmd    = GetRootCmd(&cfg)
-		versionCmd = GetVersionCmd()
+		cfg          manager.Config
+		rootCmd      = GetRootCmd(&cfg)
+		versionCmd   = GetVersionCmd()
+		translateCmd = GetTranslateCmd()
+		validateCmd  = GetValidateCmd()
 	)
-	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(versionCmd, translateCmd, validateCmd)
 	cobra.CheckErr(rootCmd.Execute())
 }
 
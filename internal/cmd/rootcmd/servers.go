@@ -2,7 +2,6 @@ package rootcmd
 
 import (
 	"context"
-	"sync"
 
 	"github.com/go-logr/logr"
 
@@ -26,24 +25,25 @@ func StartDiagnosticsServer(
 	port int,
 	c *manager.Config,
 	logger logr.Logger,
-) (diagnostics.Server, error) {
+) (*diagnostics.Server, error) {
 	if !c.EnableProfiling && !c.EnableConfigDumps {
 		logger.Info("diagnostics server disabled")
-		return diagnostics.Server{}, nil
+		return &diagnostics.Server{}, nil
 	}
 	logger.Info("starting diagnostics server")
 
-	s := diagnostics.Server{
-		Logger:           logger,
-		ProfilingEnabled: c.EnableProfiling,
-		ConfigLock:       &sync.RWMutex{},
-	}
+	var configDumps util.ConfigDumpDiagnostic
 	if c.EnableConfigDumps {
-		s.ConfigDumps = util.ConfigDumpDiagnostic{
+		configDumps = util.ConfigDumpDiagnostic{
 			DumpsIncludeSensitive: c.DumpSensitiveConfig,
 			Configs:               make(chan util.ConfigDump, DiagnosticConfigBufferDepth),
+			GatewaySyncStatuses:   make(chan []util.GatewaySyncStatus, DiagnosticConfigBufferDepth),
+			GatewayConfigDrifts:   make(chan []util.GatewayConfigDrift, DiagnosticConfigBufferDepth),
+			GatewayVersions:       make(chan util.GatewayVersions, DiagnosticConfigBufferDepth),
+			FailingObjects:        make(chan []util.FailingObject, DiagnosticConfigBufferDepth),
 		}
 	}
+	s := diagnostics.NewServer(logger, c.EnableProfiling, configDumps, c.ConfigDumpHistorySize)
 	go func() {
 		if err := s.Listen(ctx, port); err != nil {
 			logger.Error(err, "unable to start diagnostics server")
@@ -0,0 +1,124 @@
+package rootcmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	cliflag "k8s.io/component-base/cli/flag"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/featuregates"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/offline"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/versions"
+)
+
+const (
+	// translateOutputFormatDeck makes the translate command print the configuration in decK format.
+	translateOutputFormatDeck = "deck"
+	// translateOutputFormatDBLess makes the translate command print the configuration as sent to DB-less Kong.
+	translateOutputFormatDBLess = "dbless"
+
+	defaultRouterFlavor = "traditional_compatible"
+)
+
+// GetTranslateCmd returns a command translating Kubernetes manifests into Kong configuration without
+// connecting to a Kubernetes cluster or Kong Gateway.
+func GetTranslateCmd() *cobra.Command {
+	var (
+		featureGates map[string]bool
+		ingressClass string
+		kongVersion  string
+		routerFlavor string
+		outputFormat string
+		filterTags   []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "translate [file...]",
+		Short: "Translate Kubernetes manifests into Kong configuration",
+		Long: "Translate Kubernetes manifests read from files (or stdin when no files or \"-\" are given) into " +
+			"Kong configuration the same way the controller does, without connecting to a Kubernetes cluster " +
+			"or Kong Gateway. The configuration is printed to stdout and translation failures to stderr. " +
+			"The command fails if any translation failures occur.",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat != translateOutputFormatDeck && outputFormat != translateOutputFormatDBLess {
+				return fmt.Errorf("invalid output format %q, expected %q or %q",
+					outputFormat, translateOutputFormatDeck, translateOutputFormatDBLess)
+			}
+			version, err := semver.ParseTolerant(kongVersion)
+			if err != nil {
+				return fmt.Errorf("invalid kong version %q: %w", kongVersion, err)
+			}
+			fg, err := featuregates.New(logr.Discard(), featureGates)
+			if err != nil {
+				return err
+			}
+
+			manifests, err := offline.ReadManifests(cmd.InOrStdin(), args...)
+			if err != nil {
+				return err
+			}
+			result, err := offline.Translate(cmd.Context(), logr.Discard(), manifests, offline.TranslateOptions{
+				IngressClass: ingressClass,
+				FeatureGates: fg,
+				KongVersion:  version,
+				RouterFlavor: routerFlavor,
+				SelectorTags: filterTags,
+			})
+			if err != nil {
+				return err
+			}
+
+			var out any = result.Content
+			if outputFormat == translateOutputFormatDBLess {
+				out = sendconfig.DefaultContentToDBLessConfigConverter{}.Convert(result.Content)
+			}
+			b, err := yaml.Marshal(out)
+			if err != nil {
+				return fmt.Errorf("failed to marshal configuration: %w", err)
+			}
+			if _, err := cmd.OutOrStdout().Write(b); err != nil {
+				return err
+			}
+
+			for _, m := range result.SkippedManifests {
+				fmt.Fprintf(cmd.ErrOrStderr(), "skipped %s from %s: kind not used for translation\n", m, m.Source)
+			}
+			if n := len(result.TranslationFailures); n > 0 {
+				printResourceFailures(cmd.ErrOrStderr(), "translation failure", result.TranslationFailures)
+				return fmt.Errorf("%d translation failure(s) occurred", n)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.Var(cliflag.NewMapStringBool(&featureGates), "feature-gates", "A set of key=value pairs that describe feature gates for alpha/beta/experimental features. "+
+		fmt.Sprintf("See the Feature Gates documentation for information and available options: %s", featuregates.DocsURL))
+	flags.StringVar(&ingressClass, "ingress-class", annotations.DefaultIngressClass, `Name of the ingress class to translate objects for.`)
+	flags.StringVar(&kongVersion, "kong-version", versions.KICv3VersionCutoff.String(), `Version of Kong Gateway to generate the configuration for.`)
+	flags.StringVar(&routerFlavor, "kong-router-flavor", defaultRouterFlavor, `Router flavor of Kong Gateway to generate the configuration for.`)
+	flags.StringVar(&outputFormat, "output-format", translateOutputFormatDeck,
+		fmt.Sprintf("Format of the printed configuration. One of: %s, %s.", translateOutputFormatDeck, translateOutputFormatDBLess))
+	flags.StringSliceVar(&filterTags, "kong-admin-filter-tag", []string{"managed-by-ingress-controller"}, "The tag used to manage and filter entities in Kong.")
+
+	return cmd
+}
+
+// printResourceFailures prints each of the failures along with all the objects causing it.
+func printResourceFailures(w io.Writer, prefix string, resourceFailures []failures.ResourceFailure) {
+	for _, f := range resourceFailures {
+		for _, obj := range f.CausingObjects() {
+			fmt.Fprintf(w, "%s: %s %s/%s: %s\n",
+				prefix, obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName(), f.Message())
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package rootcmd
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	cliflag "k8s.io/component-base/cli/flag"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/gateway"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/featuregates"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/offline"
+)
+
+// GetValidateCmd returns a command validating Kubernetes manifests the same way the admission webhook does,
+// without connecting to a Kubernetes cluster or Kong Gateway.
+func GetValidateCmd() *cobra.Command {
+	var (
+		featureGates          map[string]bool
+		ingressClass          string
+		routerFlavor          string
+		gatewayControllerName string
+		pluginSchemasDir      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "validate [file...]",
+		Short: "Validate Kubernetes manifests",
+		Long: "Validate Kubernetes manifests read from files (or stdin when no files or \"-\" are given) the same way " +
+			"the admission webhook does, without connecting to a Kubernetes cluster or Kong Gateway. Referenced " +
+			"objects (e.g. credentials Secrets, Gateways and GatewayClasses) are looked up only among the manifests. " +
+			"Plugins' configurations are validated only when a directory with plugins' schemas exported from " +
+			"Kong Gateway is provided. Problems are printed to stderr and make the command fail. Warnings about " +
+			"possible problems that can't be confirmed without Kong Gateway (e.g. regex paths using PCRE syntax) " +
+			"are printed to stderr too, but don't make the command fail.",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fg, err := featuregates.New(logr.Discard(), featureGates)
+			if err != nil {
+				return err
+			}
+			gateway.SetControllerName(gatewayapi.GatewayController(gatewayControllerName))
+
+			var schemas offline.PluginSchemas
+			if pluginSchemasDir != "" {
+				if schemas, err = offline.LoadPluginSchemas(pluginSchemasDir); err != nil {
+					return err
+				}
+			}
+
+			manifests, err := offline.ReadManifests(cmd.InOrStdin(), args...)
+			if err != nil {
+				return err
+			}
+			result, err := offline.Validate(cmd.Context(), logr.Discard(), manifests, offline.ValidateOptions{
+				IngressClass:  ingressClass,
+				FeatureGates:  fg,
+				RouterFlavor:  routerFlavor,
+				PluginSchemas: schemas,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, m := range result.SkippedManifests {
+				fmt.Fprintf(cmd.ErrOrStderr(), "skipped %s from %s: unknown kind\n", m, m.Source)
+			}
+			for _, w := range result.Warnings {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s from %s: %s\n", w.Manifest, w.Manifest.Source, w.Message)
+			}
+			for _, p := range result.Problems {
+				fmt.Fprintf(cmd.ErrOrStderr(), "invalid: %s from %s: %s\n", p.Manifest, p.Manifest.Source, p.Message)
+			}
+			if n := len(result.Problems); n > 0 {
+				return fmt.Errorf("%d invalid object(s) found", n)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.Var(cliflag.NewMapStringBool(&featureGates), "feature-gates", "A set of key=value pairs that describe feature gates for alpha/beta/experimental features. "+
+		fmt.Sprintf("See the Feature Gates documentation for information and available options: %s", featuregates.DocsURL))
+	flags.StringVar(&ingressClass, "ingress-class", annotations.DefaultIngressClass, `Name of the ingress class to validate objects for.`)
+	flags.StringVar(&routerFlavor, "kong-router-flavor", defaultRouterFlavor, `Router flavor of Kong Gateway to validate routes for.`)
+	flags.StringVar(&gatewayControllerName, "gateway-api-controller-name", string(gateway.GetControllerName()), "The controller name to match on Gateway API resources.")
+	flags.StringVar(&pluginSchemasDir, "plugin-schemas-dir", "",
+		`Directory with plugins' schemas exported from Kong Gateway ("<plugin-name>.json" files with responses of `+
+			`GET /schemas/plugins/<plugin-name>). When not set, plugins' configurations are not validated against schemas.`)
+
+	return cmd
+}
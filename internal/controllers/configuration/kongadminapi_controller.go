@@ -9,12 +9,12 @@ import (
 	"github.com/samber/lo"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -30,8 +30,8 @@ import (
 type KongAdminAPIServiceReconciler struct {
 	client.Client
 
-	// ServiceNN is the service NamespacedName to watch EndpointSlices for.
-	ServiceNN        k8stypes.NamespacedName
+	// Services selects the Services to watch EndpointSlices for.
+	Services         adminapi.ServiceSelector
 	Log              logr.Logger
 	CacheSyncTimeout time.Duration
 	// EndpointsNotifier is used to notify about Admin API endpoints changes.
@@ -77,7 +77,16 @@ func (r *KongAdminAPIServiceReconciler) SetupWithManager(mgr ctrl.Manager) error
 	return c.Watch(
 		source.Kind(mgr.GetCache(), &discoveryv1.EndpointSlice{}),
 		&handler.EnqueueRequestForObject{},
-		predicate.NewPredicateFuncs(r.shouldReconcileEndpointSlice),
+		predicate.Funcs{
+			CreateFunc:  func(e event.CreateEvent) bool { return r.shouldReconcileEndpointSlice(e.Object) },
+			DeleteFunc:  func(e event.DeleteEvent) bool { return r.shouldReconcileEndpointSlice(e.Object) },
+			GenericFunc: func(e event.GenericEvent) bool { return r.shouldReconcileEndpointSlice(e.Object) },
+			// EndpointSlices that stopped matching (e.g. their Service was relabeled) are reconciled too, so that
+			// they're removed from the cache.
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return r.shouldReconcileEndpointSlice(e.ObjectOld) || r.shouldReconcileEndpointSlice(e.ObjectNew)
+			},
+		},
 	)
 }
 
@@ -92,17 +101,7 @@ func (r *KongAdminAPIServiceReconciler) shouldReconcileEndpointSlice(obj client.
 		return false
 	}
 
-	if endpoints.Namespace != r.ServiceNN.Namespace {
-		return false
-	}
-
-	if !lo.ContainsBy(endpoints.OwnerReferences, func(ref metav1.OwnerReference) bool {
-		return ref.Kind == "Service" && ref.Name == r.ServiceNN.Name
-	}) {
-		return false
-	}
-
-	return true
+	return r.Services.MatchesEndpointSlice(endpoints)
 }
 
 // +kubebuilder:rbac:groups="discovery.k8s.io",resources=endpointslices,verbs=get;list;watch
@@ -123,6 +122,20 @@ func (r *KongAdminAPIServiceReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 	r.Log.Info("reconciling Admin API EndpointSlice", "namespace", req.Namespace, "name", req.Name)
 
+	if !r.Services.MatchesEndpointSlice(&endpoints) {
+		r.Log.V(util.DebugLevel).Info("EndpointSlice doesn't belong to selected Services anymore",
+			"type", "EndpointSlice", "namespace", req.Namespace, "name", req.Name,
+		)
+
+		// If we have an entry for this EndpointSlice, remove it and notify about the change.
+		if _, ok := r.Cache[req.NamespacedName]; ok {
+			delete(r.Cache, req.NamespacedName)
+			r.notify()
+		}
+
+		return ctrl.Result{}, nil
+	}
+
 	if !endpoints.DeletionTimestamp.IsZero() {
 		r.Log.V(util.DebugLevel).Info("EndpointSlice is being deleted",
 			"type", "EndpointSlice", "namespace", req.Namespace, "name", req.Name,
@@ -19,6 +19,7 @@ import (
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/controllers"
 	ctrlref "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/reference"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 )
 
@@ -100,8 +101,10 @@ func (r *CoreV1SecretReconciler) shouldReconcileSecret(obj client.Object) bool {
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=list;watch
 
 // Reconcile processes the watched objects.
-func (r *CoreV1SecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *CoreV1SecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("CoreV1Secret", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "Secret", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	secret := new(corev1.Secret)
@@ -43,6 +43,7 @@ import (
 	ctrlref "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/reference"
 	ctrlutils "github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/utils"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object/status"
 	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
@@ -94,8 +95,10 @@ func (r *CoreV1ServiceReconciler) SetLogger(l logr.Logger) {
 //+kubebuilder:rbac:groups="",resources=services/status,verbs=get;update;patch
 
 // Reconcile processes the watched objects
-func (r *CoreV1ServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *CoreV1ServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("CoreV1Service", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "Service", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new(corev1.Service)
@@ -198,8 +201,10 @@ func (r *DiscoveryV1EndpointSliceReconciler) SetLogger(l logr.Logger) {
 //+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=list;watch
 
 // Reconcile processes the watched objects
-func (r *DiscoveryV1EndpointSliceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *DiscoveryV1EndpointSliceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("DiscoveryV1EndpointSlice", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "EndpointSlice", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new(discoveryv1.EndpointSlice)
@@ -336,8 +341,10 @@ func (r *NetV1IngressReconciler) SetLogger(l logr.Logger) {
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=get;update;patch
 
 // Reconcile processes the watched objects
-func (r *NetV1IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *NetV1IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("NetV1Ingress", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "Ingress", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new(netv1.Ingress)
@@ -485,8 +492,10 @@ func (r *NetV1IngressClassReconciler) SetLogger(l logr.Logger) {
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
 
 // Reconcile processes the watched objects
-func (r *NetV1IngressClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *NetV1IngressClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("NetV1IngressClass", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "IngressClass", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new(netv1.IngressClass)
@@ -570,8 +579,10 @@ func (r *KongV1KongIngressReconciler) SetLogger(l logr.Logger) {
 //+kubebuilder:rbac:groups=configuration.konghq.com,resources=kongingresses/status,verbs=get;update;patch
 
 // Reconcile processes the watched objects
-func (r *KongV1KongIngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *KongV1KongIngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("KongV1KongIngress", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "KongIngress", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new(kongv1.KongIngress)
@@ -656,8 +667,10 @@ func (r *KongV1KongPluginReconciler) SetLogger(l logr.Logger) {
 //+kubebuilder:rbac:groups=configuration.konghq.com,resources=kongplugins/status,verbs=get;update;patch
 
 // Reconcile processes the watched objects
-func (r *KongV1KongPluginReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *KongV1KongPluginReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("KongV1KongPlugin", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "KongPlugin", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new(kongv1.KongPlugin)
@@ -798,8 +811,10 @@ func (r *KongV1KongClusterPluginReconciler) SetLogger(l logr.Logger) {
 //+kubebuilder:rbac:groups=configuration.konghq.com,resources=kongclusterplugins/status,verbs=get;update;patch
 
 // Reconcile processes the watched objects
-func (r *KongV1KongClusterPluginReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *KongV1KongClusterPluginReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("KongV1KongClusterPlugin", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "KongClusterPlugin", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new(kongv1.KongClusterPlugin)
@@ -974,8 +989,10 @@ func (r *KongV1KongConsumerReconciler) SetLogger(l logr.Logger) {
 //+kubebuilder:rbac:groups=configuration.konghq.com,resources=kongconsumers/status,verbs=get;update;patch
 
 // Reconcile processes the watched objects
-func (r *KongV1KongConsumerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *KongV1KongConsumerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("KongV1KongConsumer", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "KongConsumer", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new(kongv1.KongConsumer)
@@ -1161,8 +1178,10 @@ func (r *KongV1Beta1KongConsumerGroupReconciler) SetLogger(l logr.Logger) {
 //+kubebuilder:rbac:groups=configuration.konghq.com,resources=kongconsumergroups/status,verbs=get;update;patch
 
 // Reconcile processes the watched objects
-func (r *KongV1Beta1KongConsumerGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *KongV1Beta1KongConsumerGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("KongV1Beta1KongConsumerGroup", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "KongConsumerGroup", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new(kongv1beta1.KongConsumerGroup)
@@ -1350,8 +1369,10 @@ func (r *KongV1Beta1TCPIngressReconciler) SetLogger(l logr.Logger) {
 //+kubebuilder:rbac:groups=configuration.konghq.com,resources=tcpingresses/status,verbs=get;update;patch
 
 // Reconcile processes the watched objects
-func (r *KongV1Beta1TCPIngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *KongV1Beta1TCPIngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("KongV1Beta1TCPIngress", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "TCPIngress", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new(kongv1beta1.TCPIngress)
@@ -1552,8 +1573,10 @@ func (r *KongV1Beta1UDPIngressReconciler) SetLogger(l logr.Logger) {
 //+kubebuilder:rbac:groups=configuration.konghq.com,resources=udpingresses/status,verbs=get;update;patch
 
 // Reconcile processes the watched objects
-func (r *KongV1Beta1UDPIngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *KongV1Beta1UDPIngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("KongV1Beta1UDPIngress", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "UDPIngress", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new(kongv1beta1.UDPIngress)
@@ -1681,8 +1704,10 @@ func (r *KongV1Alpha1IngressClassParametersReconciler) SetLogger(l logr.Logger)
 //+kubebuilder:rbac:groups=configuration.konghq.com,resources=ingressclassparameterses,verbs=get;list;watch
 
 // Reconcile processes the watched objects
-func (r *KongV1Alpha1IngressClassParametersReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *KongV1Alpha1IngressClassParametersReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	log := r.Log.WithValues("KongV1Alpha1IngressClassParameters", req.NamespacedName)
+	ctx, span := tracing.StartReconcileSpan(ctx, "IngressClassParameters", req.NamespacedName)
+	defer func() { tracing.EndSpan(span, err) }()
 
 	// get the relevant object
 	obj := new(kongv1alpha1.IngressClassParameters)
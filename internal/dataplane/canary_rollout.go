@@ -0,0 +1,349 @@
+package dataplane
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+const (
+	// DefaultCanaryBakePeriod is the default period canary gateways are observed for before the configuration
+	// is rolled out to the rest of gateways.
+	DefaultCanaryBakePeriod = time.Minute
+
+	// DefaultCanaryCheckInterval is the default interval of health checks of canary gateways during the bake period.
+	DefaultCanaryCheckInterval = 5 * time.Second
+
+	// DefaultCanaryMaxProxyErrorRate is the default maximum rate of requests proxied by a canary gateway between
+	// its health checks that may get a 5xx response (see ProxyErrorRateCanaryHealthChecker).
+	DefaultCanaryMaxProxyErrorRate = 0.05
+)
+
+const (
+	// CanaryHealthCheckStatus selects StatusCanaryHealthChecker for canary gateways' health checks.
+	CanaryHealthCheckStatus = "status"
+	// CanaryHealthCheckProxyErrorRate selects ProxyErrorRateCanaryHealthChecker for canary gateways' health checks.
+	CanaryHealthCheckProxyErrorRate = "proxy-error-rate"
+)
+
+// errRolloutHalted is the error reported for gateways the configuration was not rolled out to because
+// canary gateways didn't accept it.
+var errRolloutHalted = errors.New("configuration rollout halted as canary gateways didn't accept it")
+
+// CanaryRolloutConfig configures staged rollout of configuration to gateway clients. When enabled, a changed
+// configuration is sent to a subset of gateway clients (canaries) first. The canaries are then health-checked
+// for the bake period and, only when the ratio of failed checks doesn't exceed MaxErrorRate, the configuration
+// is sent to the rest of gateway clients. Otherwise, canaries are reverted to the last valid configuration
+// and the configuration is rejected until it changes.
+type CanaryRolloutConfig struct {
+	// CanaryPercent is the percentage of gateway clients that receive the configuration first. Zero disables
+	// the staged rollout. At least one gateway client is always a canary, and at least one is not.
+	CanaryPercent int
+
+	// BakePeriod is the period canaries are health-checked for before continuing the rollout.
+	BakePeriod time.Duration
+
+	// CheckInterval is the interval of canaries' health checks during the bake period.
+	CheckInterval time.Duration
+
+	// MaxErrorRate is the maximum ratio (0 to 1) of failed health checks of canaries during the bake period
+	// that still allows continuing the rollout.
+	MaxErrorRate float64
+}
+
+// Enabled tells whether the staged rollout is enabled.
+func (c CanaryRolloutConfig) Enabled() bool {
+	return c.CanaryPercent > 0
+}
+
+// CanaryHealthChecker checks the health of a canary gateway during the bake period of a staged rollout.
+type CanaryHealthChecker interface {
+	CheckCanaryHealth(ctx context.Context, client *adminapi.Client) error
+}
+
+// StatusCanaryHealthChecker checks the health of a canary gateway using its Admin API's /status endpoint.
+type StatusCanaryHealthChecker struct {
+	timeout time.Duration
+}
+
+func NewStatusCanaryHealthChecker(timeout time.Duration) StatusCanaryHealthChecker {
+	return StatusCanaryHealthChecker{timeout: timeout}
+}
+
+func (c StatusCanaryHealthChecker) CheckCanaryHealth(ctx context.Context, client *adminapi.Client) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return client.IsReady(ctx)
+}
+
+// ProxyErrorRateCanaryHealthChecker checks the health of a canary gateway using the rate of requests it proxied
+// since the previous check that got a 5xx response. The rate is calculated from kong_http_requests_total counters
+// exposed on the Admin API's /metrics endpoint by the Prometheus plugin, so the plugin has to be enabled. The first
+// check of each canary only takes the counters as a baseline.
+type ProxyErrorRateCanaryHealthChecker struct {
+	timeout      time.Duration
+	maxErrorRate float64
+
+	lock sync.Mutex
+	// previous are request counts of canaries seen by the previous check, keyed by their URLs.
+	previous map[string]proxiedRequestCounts
+}
+
+// proxiedRequestCounts are counts of requests proxied by a gateway since it started.
+type proxiedRequestCounts struct {
+	total, failed float64
+}
+
+func NewProxyErrorRateCanaryHealthChecker(timeout time.Duration, maxErrorRate float64) *ProxyErrorRateCanaryHealthChecker {
+	return &ProxyErrorRateCanaryHealthChecker{
+		timeout:      timeout,
+		maxErrorRate: maxErrorRate,
+		previous:     make(map[string]proxiedRequestCounts),
+	}
+}
+
+func (c *ProxyErrorRateCanaryHealthChecker) CheckCanaryHealth(ctx context.Context, client *adminapi.Client) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	counts, err := fetchProxiedRequestCounts(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	previous, ok := c.previous[client.BaseRootURL()]
+	c.previous[client.BaseRootURL()] = counts
+	c.lock.Unlock()
+	if !ok {
+		return nil
+	}
+	// Counters are reset when the gateway restarts, so all the requests counted by now were proxied since then.
+	if counts.total < previous.total || counts.failed < previous.failed {
+		previous = proxiedRequestCounts{}
+	}
+
+	total, failed := counts.total-previous.total, counts.failed-previous.failed
+	if total == 0 {
+		return nil
+	}
+	if errorRate := failed / total; errorRate > c.maxErrorRate {
+		return fmt.Errorf("%.0f of %.0f proxied requests got a 5xx response (error rate %.2f exceeds %.2f)",
+			failed, total, errorRate, c.maxErrorRate)
+	}
+	return nil
+}
+
+// fetchProxiedRequestCounts sums kong_http_requests_total counters exposed on the gateway's /metrics endpoint.
+func fetchProxiedRequestCounts(ctx context.Context, client *adminapi.Client) (proxiedRequestCounts, error) {
+	kongClient := client.AdminAPIClient()
+	req, err := kongClient.NewRequest(http.MethodGet, "/metrics", nil, nil)
+	if err != nil {
+		return proxiedRequestCounts{}, fmt.Errorf("failed creating metrics request: %w", err)
+	}
+	resp, err := kongClient.DoRAW(ctx, req)
+	if err != nil {
+		return proxiedRequestCounts{}, fmt.Errorf("failed fetching metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return proxiedRequestCounts{}, fmt.Errorf("failed fetching metrics, got status code %d, is the Prometheus plugin enabled?", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return proxiedRequestCounts{}, fmt.Errorf("failed parsing metrics: %w", err)
+	}
+	var counts proxiedRequestCounts
+	// Requests are counted only once the first one is proxied, so there may be no counters yet.
+	family, ok := families["kong_http_requests_total"]
+	if !ok {
+		return counts, nil
+	}
+	for _, m := range family.GetMetric() {
+		value := m.GetCounter().GetValue()
+		counts.total += value
+		for _, label := range m.GetLabel() {
+			if label.GetName() == "code" && strings.HasPrefix(label.GetValue(), "5") {
+				counts.failed += value
+			}
+		}
+	}
+	return counts, nil
+}
+
+// canaryRollout holds the staged rollout configuration and state of KongClient.
+type canaryRollout struct {
+	config         CanaryRolloutConfig
+	healthCheckers []CanaryHealthChecker
+
+	// rejectedConfigHash is the hash of the last configuration that canaries didn't accept.
+	rejectedConfigHash []byte
+}
+
+// SetCanaryRollout enables staged rollout of configuration to gateway clients. When no health checkers are
+// provided, canaries' /status endpoints are checked.
+func (c *KongClient) SetCanaryRollout(config CanaryRolloutConfig, healthCheckers ...CanaryHealthChecker) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(healthCheckers) == 0 {
+		healthCheckers = []CanaryHealthChecker{NewStatusCanaryHealthChecker(c.requestTimeout)}
+	}
+	c.canaryRollout = canaryRollout{
+		config:         config,
+		healthCheckers: healthCheckers,
+	}
+}
+
+// canaryRolloutEnabled tells whether the configuration should be rolled out to gatewayClients in stages.
+// It requires at least two gateway clients, so that there's a gateway client that's not a canary.
+func (c *KongClient) canaryRolloutEnabled(gatewayClients []*adminapi.Client) bool {
+	return c.canaryRollout.config.Enabled() && len(gatewayClients) > 1
+}
+
+// rolloutToGatewayClients sends the configuration generated from the provided kong state to canary gateway
+// clients first and, when they accept it, to the rest of gateway clients. Canaries are baked only when
+// the configuration has changed for any of them. When canaries don't accept the configuration, their results
+// are failed, so that they get the last valid configuration pushed, and the rest of gateway clients are
+// skipped. The generated content is returned along with the results.
+func (c *KongClient) rolloutToGatewayClients(
+	ctx context.Context, s *kongstate.KongState, config sendconfig.Config, gatewayClients []*adminapi.Client,
+) (generatedContent, []gatewaySyncResult, error) {
+	generated, err := c.generateGatewayContent(ctx, s, config, gatewayClients)
+	if err != nil {
+		return generatedContent{}, nil, err
+	}
+	hash := generated.content.Hash
+	if rejected := c.canaryRollout.rejectedConfigHash; rejected != nil && bytes.Equal(rejected, hash) {
+		return generatedContent{}, nil, fmt.Errorf("configuration %x was rejected by canary gateways, waiting for a configuration change", hash)
+	}
+
+	canaries, rest := c.splitCanaries(gatewayClients)
+	logger := c.logger.WithValues("canaries", len(canaries), "rest", len(rest))
+
+	bakeNeeded := false
+	for _, canary := range canaries {
+		if !bytes.Equal(canary.LastConfigSHA(), hash) {
+			bakeNeeded = true
+			break
+		}
+	}
+
+	canaryResults := c.sendGeneratedToGatewayClients(ctx, config, generated, canaries)
+	for _, r := range canaryResults {
+		if r.err != nil {
+			logger.Error(r.err, "canary gateway failed to apply configuration, halting rollout", "url", r.client.BaseRootURL())
+			return generated, append(canaryResults, haltedGatewaySyncResults(rest)...), nil
+		}
+	}
+
+	if bakeNeeded {
+		logger.V(util.InfoLevel).Info("configuration applied to canary gateways, baking", "bake_period", c.canaryRollout.config.BakePeriod)
+		// Baking takes up to the bake period, so the lock is released meanwhile not to block other methods of
+		// the client (e.g. DBMode or listing listeners). Updates are still serialized with updateLock.
+		rollout := c.canaryRollout
+		c.lock.Unlock()
+		err := rollout.bake(ctx, c.logger, canaries)
+		c.lock.Lock()
+		if err != nil {
+			logger.Error(err, "canary gateways didn't accept configuration, reverting them to the last valid configuration")
+			c.canaryRollout.rejectedConfigHash = hash
+			for i := range canaryResults {
+				canaryResults[i].err = fmt.Errorf("canary %s rejected configuration: %w", canaryResults[i].client.BaseRootURL(), err)
+			}
+			return generated, append(canaryResults, haltedGatewaySyncResults(rest)...), nil
+		}
+		logger.V(util.InfoLevel).Info("canary gateways accepted configuration, rolling it out to the rest of gateways")
+	}
+	c.canaryRollout.rejectedConfigHash = nil
+
+	return generated, append(canaryResults, c.sendGeneratedToGatewayClients(ctx, config, generated, rest)...), nil
+}
+
+// splitCanaries splits gatewayClients into canaries and the rest. Gateway clients are ordered by their URLs,
+// so that the same ones are canaries as long as the set of gateway clients doesn't change.
+func (c *KongClient) splitCanaries(gatewayClients []*adminapi.Client) (canaries, rest []*adminapi.Client) {
+	sorted := make([]*adminapi.Client, len(gatewayClients))
+	copy(sorted, gatewayClients)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].BaseRootURL() < sorted[j].BaseRootURL()
+	})
+
+	count := (len(sorted)*c.canaryRollout.config.CanaryPercent + 99) / 100
+	if count < 1 {
+		count = 1
+	}
+	if count > len(sorted)-1 {
+		count = len(sorted) - 1
+	}
+	return sorted[:count], sorted[count:]
+}
+
+// bake health-checks canaries every check interval for the bake period. It returns an error when the ratio
+// of failed checks exceeds the maximum error rate.
+func (r canaryRollout) bake(ctx context.Context, logger logr.Logger, canaries []*adminapi.Client) error {
+	cfg := r.config
+	bakeTimer := time.NewTimer(cfg.BakePeriod)
+	defer bakeTimer.Stop()
+	checkTicker := time.NewTicker(cfg.CheckInterval)
+	defer checkTicker.Stop()
+
+	var (
+		checks, failed int
+		lastErr        error
+	)
+	checkCanaries := func() {
+		for _, canary := range canaries {
+			for _, checker := range r.healthCheckers {
+				checks++
+				if err := checker.CheckCanaryHealth(ctx, canary); err != nil {
+					failed++
+					lastErr = fmt.Errorf("canary %s: %w", canary.BaseRootURL(), err)
+					logger.V(util.DebugLevel).Info("canary health check failed", "url", canary.BaseRootURL(), "error", err.Error())
+				}
+			}
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-checkTicker.C:
+			checkCanaries()
+		case <-bakeTimer.C:
+			// Make sure canaries are checked at least once, even if the bake period is shorter than the check interval.
+			if checks == 0 {
+				checkCanaries()
+			}
+			if errorRate := float64(failed) / float64(checks); errorRate > cfg.MaxErrorRate {
+				return fmt.Errorf("%d of %d health checks failed (error rate %.2f exceeds %.2f), last failure: %w",
+					failed, checks, errorRate, cfg.MaxErrorRate, lastErr)
+			}
+			return nil
+		}
+	}
+}
+
+// haltedGatewaySyncResults returns skipped results for gateway clients the configuration was not rolled out to.
+func haltedGatewaySyncResults(gatewayClients []*adminapi.Client) []gatewaySyncResult {
+	results := make([]gatewaySyncResult, 0, len(gatewayClients))
+	for _, client := range gatewayClients {
+		results = append(results, gatewaySyncResult{client: client, err: errRolloutHalted, skipped: true})
+	}
+	return results
+}
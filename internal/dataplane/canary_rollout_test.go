@@ -0,0 +1,231 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// mockCanaryHealthChecker is a mock implementation of CanaryHealthChecker.
+type mockCanaryHealthChecker struct {
+	lock    sync.Mutex
+	err     error
+	checked []string
+	// onCheck is called on every check, e.g. to assert the rollout's state during the bake period.
+	onCheck func()
+}
+
+func (m *mockCanaryHealthChecker) CheckCanaryHealth(_ context.Context, client *adminapi.Client) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.checked = append(m.checked, client.BaseRootURL())
+	if m.onCheck != nil {
+		m.onCheck()
+	}
+	return m.err
+}
+
+func (m *mockCanaryHealthChecker) checks() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return len(m.checked)
+}
+
+func TestKongClientUpdate_CanaryRollout(t *testing.T) {
+	var (
+		ctx                = context.Background()
+		testGatewayClients = []*adminapi.Client{
+			mustSampleGatewayClient(t),
+			mustSampleGatewayClient(t),
+			mustSampleGatewayClient(t),
+		}
+		clientsProvider = mockGatewayClientsProvider{
+			gatewayClients: testGatewayClients,
+		}
+
+		updateStrategyResolver = newMockUpdateStrategyResolver(t)
+		configChangeDetector   = mockConfigurationChangeDetector{hasConfigurationChanged: true}
+		configBuilder          = newMockKongConfigBuilder()
+		kongRawStateGetter     = &mockKongLastValidConfigFetcher{}
+		kongClient             = setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector, configBuilder, nil, kongRawStateGetter)
+		healthChecker          = &mockCanaryHealthChecker{}
+	)
+	kongClient.SetCanaryRollout(CanaryRolloutConfig{
+		CanaryPercent: 30,
+		BakePeriod:    50 * time.Millisecond,
+		CheckInterval: 10 * time.Millisecond,
+	}, healthChecker)
+
+	urls := mapClientsToUrls(clientsProvider)
+	sort.Strings(urls)
+	canaryURL, restURLs := urls[0], urls[1:]
+
+	serviceName := func(url string) string {
+		content, ok := updateStrategyResolver.lastUpdatedContentForURL(url)
+		require.True(t, ok, "configuration should be sent to %s", url)
+		require.Len(t, content.Content.Services, 1)
+		return *content.Content.Services[0].Name
+	}
+	stateWithService := func(name string) *kongstate.KongState {
+		return &kongstate.KongState{
+			Services: []kongstate.Service{
+				{Service: kong.Service{Name: kong.String(name), Host: kong.String("example.com")}},
+			},
+		}
+	}
+
+	t.Log("healthy canaries get the configuration before the rest of gateways")
+	healthChecker.onCheck = func() {
+		for _, url := range restURLs {
+			_, ok := updateStrategyResolver.lastUpdatedContentForURL(url)
+			require.False(t, ok, "configuration shouldn't be sent to %s before canaries are baked", url)
+		}
+		require.True(t, kongClient.lock.TryRLock(), "client's lock shouldn't be held while canaries are baked")
+		kongClient.lock.RUnlock()
+	}
+	configBuilder.kongState = stateWithService("first")
+	require.NoError(t, kongClient.Update(ctx))
+	require.NotZero(t, healthChecker.checks())
+	for _, url := range urls {
+		require.Equal(t, "first", serviceName(url))
+	}
+	healthChecker.onCheck = nil
+
+	t.Log("unchanged configuration is not baked again")
+	checksBefore := healthChecker.checks()
+	require.NoError(t, kongClient.Update(ctx))
+	require.Equal(t, checksBefore, healthChecker.checks())
+
+	t.Log("unhealthy canaries are reverted to the last valid configuration and the rest of gateways are left untouched")
+	gatewaySyncStatuses := make(chan []util.GatewaySyncStatus, 1)
+	kongClient.diagnostic = util.ConfigDumpDiagnostic{GatewaySyncStatuses: gatewaySyncStatuses}
+	healthChecker.err = errors.New("unhealthy")
+	configBuilder.kongState = stateWithService("second")
+	require.Error(t, kongClient.Update(ctx))
+	for _, url := range urls {
+		require.Equal(t, "first", serviceName(url))
+	}
+	require.Len(t, gatewaySyncStatuses, 1)
+	for _, status := range <-gatewaySyncStatuses {
+		require.False(t, status.InSync)
+		if status.URL == canaryURL {
+			require.False(t, status.Skipped, "canary should be reported as failed")
+		} else {
+			require.True(t, status.Skipped, "gateway %s the rollout was halted for should be reported as skipped", status.URL)
+		}
+	}
+	kongClient.diagnostic = util.ConfigDumpDiagnostic{}
+
+	t.Log("rejected configuration is not rolled out again")
+	checksBefore = healthChecker.checks()
+	require.ErrorContains(t, kongClient.Update(ctx), "rejected by canary gateways")
+	require.Equal(t, checksBefore, healthChecker.checks())
+	require.Equal(t, "first", serviceName(canaryURL))
+
+	t.Log("changed configuration is rolled out once canaries are healthy again")
+	healthChecker.err = nil
+	configBuilder.kongState = stateWithService("third")
+	require.NoError(t, kongClient.Update(ctx))
+	for _, url := range urls {
+		require.Equal(t, "third", serviceName(url))
+	}
+}
+
+func TestKongClient_SplitCanaries(t *testing.T) {
+	testCases := []struct {
+		name             string
+		clientsCount     int
+		canaryPercent    int
+		expectedCanaries int
+	}{
+		{name: "at least one canary", clientsCount: 10, canaryPercent: 1, expectedCanaries: 1},
+		{name: "rounded up", clientsCount: 10, canaryPercent: 25, expectedCanaries: 3},
+		{name: "at least one gateway is not a canary", clientsCount: 2, canaryPercent: 100, expectedCanaries: 1},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			gatewayClients := make([]*adminapi.Client, 0, tc.clientsCount)
+			for i := 0; i < tc.clientsCount; i++ {
+				gatewayClients = append(gatewayClients, mustSampleGatewayClient(t))
+			}
+			kongClient := &KongClient{canaryRollout: canaryRollout{config: CanaryRolloutConfig{CanaryPercent: tc.canaryPercent}}}
+
+			canaries, rest := kongClient.splitCanaries(gatewayClients)
+			require.Len(t, canaries, tc.expectedCanaries)
+			require.Len(t, rest, tc.clientsCount-tc.expectedCanaries)
+
+			secondCanaries, _ := kongClient.splitCanaries(gatewayClients)
+			require.Equal(t, canaries, secondCanaries, "canaries should be chosen deterministically")
+		})
+	}
+}
+
+func TestProxyErrorRateCanaryHealthChecker(t *testing.T) {
+	var (
+		lock sync.Mutex
+		body string
+	)
+	setRequestCounts := func(ok, failed int) {
+		lock.Lock()
+		defer lock.Unlock()
+		body = fmt.Sprintf(`# TYPE kong_http_requests_total counter
+kong_http_requests_total{service="s",route="r",code="200",source="service",consumer=""} %d
+kong_http_requests_total{service="s",route="r",code="503",source="service",consumer=""} %d
+`, ok, failed)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	client, err := adminapi.NewTestClient(server.URL)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	checker := NewProxyErrorRateCanaryHealthChecker(time.Second, 0.1)
+
+	t.Log("the first check only takes a baseline")
+	setRequestCounts(50, 50)
+	require.NoError(t, checker.CheckCanaryHealth(ctx, client))
+
+	t.Log("error rate below the maximum passes")
+	setRequestCounts(145, 55)
+	require.NoError(t, checker.CheckCanaryHealth(ctx, client))
+
+	t.Log("no requests proxied since the previous check passes")
+	require.NoError(t, checker.CheckCanaryHealth(ctx, client))
+
+	t.Log("error rate above the maximum fails")
+	setRequestCounts(155, 65)
+	require.ErrorContains(t, checker.CheckCanaryHealth(ctx, client), "10 of 20 proxied requests got a 5xx response")
+
+	t.Log("counters reset by a restart are counted from zero")
+	setRequestCounts(10, 0)
+	require.NoError(t, checker.CheckCanaryHealth(ctx, client))
+
+	t.Log("missing metrics endpoint fails")
+	notFoundClient, err := adminapi.NewTestClient(server.URL + "/missing")
+	require.NoError(t, err)
+	require.ErrorContains(t, checker.CheckCanaryHealth(ctx, notFoundClient), "is the Prometheus plugin enabled?")
+}
@@ -0,0 +1,222 @@
+package capabilities
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/blang/semver/v4"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Capability is a feature of Kong Gateway that the generated configuration may depend on.
+type Capability string
+
+const (
+	// ExpressionRoutes is the support of expression based Kong Routes.
+	ExpressionRoutes Capability = "expression routes"
+	// ConsumerGroups is the support of Kong Consumer Groups.
+	ConsumerGroups Capability = "consumer groups"
+	// ConsumerGroupScopedPlugins is the support of Kong Plugins scoped to Kong Consumer Groups.
+	ConsumerGroupScopedPlugins Capability = "consumer group scoped plugins"
+	// TLSPassthroughProtocol is the support of the tls_passthrough protocol of Kong Routes.
+	TLSPassthroughProtocol Capability = "tls_passthrough protocol"
+	// WebSocketProtocols is the support of the ws and wss protocols of Kong Services and Routes.
+	WebSocketProtocols Capability = "ws and wss protocols"
+)
+
+// Edition is an edition of Kong Gateway.
+type Edition string
+
+const (
+	// EditionUnknown is used when the edition of Kong Gateway is not known, e.g. when the configuration is generated
+	// without connecting to it.
+	EditionUnknown Edition = ""
+	// EditionOSS is the open source edition of Kong Gateway.
+	EditionOSS Edition = "oss"
+	// EditionEnterprise is Kong Gateway Enterprise.
+	EditionEnterprise Edition = "enterprise"
+)
+
+// RouterFlavorExpressions is the router flavor of Kong Gateway enabling expression based Kong Routes.
+const RouterFlavorExpressions = "expressions"
+
+// Gateway describes Kong Gateway(s) the configuration is generated for. Unknown (zero) properties don't restrict
+// capabilities depending on them.
+type Gateway struct {
+	// Version is the version of Kong Gateway.
+	Version semver.Version
+	// Edition is the edition of Kong Gateway.
+	Edition Edition
+	// RouterFlavor is the router flavor Kong Gateway is running with (e.g. "traditional_compatible").
+	RouterFlavor string
+	// LoadedPlugins are names of plugins loaded by Kong Gateway. When nil, all plugins are considered available.
+	LoadedPlugins []string
+}
+
+// Equal tells whether both Gateways are described by the same properties.
+func (g Gateway) Equal(other Gateway) bool {
+	return g.Version.Equals(other.Version) &&
+		g.Edition == other.Edition &&
+		g.RouterFlavor == other.RouterFlavor &&
+		(g.LoadedPlugins == nil) == (other.LoadedPlugins == nil) &&
+		slices.Equal(g.LoadedPlugins, other.LoadedPlugins)
+}
+
+// CommonGateway describes what all the provided Kong Gateways have in common, so that the configuration generated
+// for it is accepted by each of them (e.g. during a rolling upgrade, when they run different versions):
+//   - the lowest of their known versions,
+//   - the OSS edition if any of them is OSS, the Enterprise edition if all of them are Enterprise,
+//   - a known router flavor other than "expressions" if their router flavors differ,
+//   - plugins loaded by all of them that report their loaded plugins.
+func CommonGateway(gateways ...Gateway) Gateway {
+	var (
+		common             Gateway
+		allEnterprise      = len(gateways) > 0
+		loadedPluginsKnown bool
+	)
+	for _, g := range gateways {
+		if !g.Version.Equals(semver.Version{}) && (common.Version.Equals(semver.Version{}) || g.Version.LT(common.Version)) {
+			common.Version = g.Version
+		}
+
+		if g.Edition == EditionOSS {
+			common.Edition = EditionOSS
+		}
+		if g.Edition != EditionEnterprise {
+			allEnterprise = false
+		}
+
+		if g.RouterFlavor != "" && (common.RouterFlavor == "" || common.RouterFlavor == RouterFlavorExpressions) {
+			common.RouterFlavor = g.RouterFlavor
+		}
+
+		if g.LoadedPlugins != nil {
+			if !loadedPluginsKnown {
+				common.LoadedPlugins = slices.Clone(g.LoadedPlugins)
+				loadedPluginsKnown = true
+			} else {
+				common.LoadedPlugins = lo.Intersect(common.LoadedPlugins, g.LoadedPlugins)
+			}
+		}
+	}
+	if allEnterprise {
+		common.Edition = EditionEnterprise
+	}
+	if common.LoadedPlugins != nil {
+		sort.Strings(common.LoadedPlugins)
+	}
+	return common
+}
+
+// requirement describes what Kong Gateway has to satisfy to provide a capability.
+type requirement struct {
+	minVersion     semver.Version
+	enterpriseOnly bool
+	routerFlavor   string
+}
+
+var requirements = map[Capability]requirement{
+	ExpressionRoutes: {
+		minVersion:   semver.Version{Major: 3, Minor: 0},
+		routerFlavor: RouterFlavorExpressions,
+	},
+	ConsumerGroups: {
+		minVersion:     semver.Version{Major: 2, Minor: 7},
+		enterpriseOnly: true,
+	},
+	ConsumerGroupScopedPlugins: {
+		minVersion:     semver.Version{Major: 3, Minor: 4},
+		enterpriseOnly: true,
+	},
+	TLSPassthroughProtocol: {
+		minVersion: semver.Version{Major: 2, Minor: 7},
+	},
+	WebSocketProtocols: {
+		minVersion:     semver.Version{Major: 3, Minor: 0},
+		enterpriseOnly: true,
+	},
+}
+
+// protocolCapabilities maps protocols of Kong Services and Routes to capabilities they require. Protocols that are
+// not listed are supported by all Kong Gateway versions.
+var protocolCapabilities = map[string]Capability{
+	"tls_passthrough": TLSPassthroughProtocol,
+	"ws":              WebSocketProtocols,
+	"wss":             WebSocketProtocols,
+}
+
+// Registry tells what capabilities Kong Gateway(s) the configuration is generated for have. A zero Registry
+// describes an unknown Kong Gateway and supports all capabilities.
+type Registry struct {
+	gateway       Gateway
+	loadedPlugins sets.Set[string]
+}
+
+// NewRegistry creates a Registry of capabilities of the provided Kong Gateway.
+func NewRegistry(gateway Gateway) Registry {
+	r := Registry{gateway: gateway}
+	if gateway.LoadedPlugins != nil {
+		r.loadedPlugins = sets.New(gateway.LoadedPlugins...)
+	}
+	return r
+}
+
+// Gateway returns the Kong Gateway the Registry describes.
+func (r Registry) Gateway() Gateway {
+	return r.gateway
+}
+
+// Supports tells whether Kong Gateway has the capability.
+func (r Registry) Supports(c Capability) bool {
+	return r.Check(c) == nil
+}
+
+// Check returns an error explaining why Kong Gateway doesn't have the capability or nil if it has it.
+func (r Registry) Check(c Capability) error {
+	req, ok := requirements[c]
+	if !ok {
+		return fmt.Errorf("unknown capability %q", c)
+	}
+	if v := r.gateway.Version; !v.Equals(semver.Version{}) && v.LT(req.minVersion) {
+		return fmt.Errorf("%s require Kong Gateway %s or newer, while it's %s", c, req.minVersion, v)
+	}
+	if req.enterpriseOnly && r.gateway.Edition == EditionOSS {
+		return fmt.Errorf("%s require Kong Gateway Enterprise", c)
+	}
+	if f := r.gateway.RouterFlavor; req.routerFlavor != "" && f != "" && f != req.routerFlavor {
+		return fmt.Errorf("%s require Kong Gateway running with %q router flavor, while it's %q", c, req.routerFlavor, f)
+	}
+	return nil
+}
+
+// CheckProtocol returns an error explaining why Kong Gateway doesn't support the protocol of Kong Services
+// and Routes or nil if it supports it.
+func (r Registry) CheckProtocol(protocol string) error {
+	c, ok := protocolCapabilities[protocol]
+	if !ok {
+		return nil
+	}
+	return r.Check(c)
+}
+
+// CheckPlugin returns an error explaining why the plugin is not available in Kong Gateway or nil if it is.
+func (r Registry) CheckPlugin(name string) error {
+	if r.loadedPlugins == nil || r.loadedPlugins.Has(name) {
+		return nil
+	}
+	return fmt.Errorf("plugin %q is not loaded by Kong Gateway", name)
+}
+
+// Supported returns all capabilities Kong Gateway has, sorted by their names.
+func (r Registry) Supported() []Capability {
+	var supported []Capability
+	for c := range requirements {
+		if r.Supports(c) {
+			supported = append(supported, c)
+		}
+	}
+	sort.Slice(supported, func(i, j int) bool { return supported[i] < supported[j] })
+	return supported
+}
@@ -0,0 +1,199 @@
+package capabilities_test
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/capabilities"
+)
+
+func TestRegistry_Check(t *testing.T) {
+	testCases := []struct {
+		name          string
+		gateway       capabilities.Gateway
+		capability    capabilities.Capability
+		expectedError string
+	}{
+		{
+			name:       "unknown gateway supports everything",
+			gateway:    capabilities.Gateway{},
+			capability: capabilities.ConsumerGroupScopedPlugins,
+		},
+		{
+			name: "expression routes with expressions router flavor",
+			gateway: capabilities.Gateway{
+				Version:      semver.MustParse("3.4.1"),
+				RouterFlavor: capabilities.RouterFlavorExpressions,
+			},
+			capability: capabilities.ExpressionRoutes,
+		},
+		{
+			name: "expression routes with traditional router flavor",
+			gateway: capabilities.Gateway{
+				Version:      semver.MustParse("3.4.1"),
+				RouterFlavor: "traditional_compatible",
+			},
+			capability:    capabilities.ExpressionRoutes,
+			expectedError: `expression routes require Kong Gateway running with "expressions" router flavor, while it's "traditional_compatible"`,
+		},
+		{
+			name: "consumer group scoped plugins in enterprise",
+			gateway: capabilities.Gateway{
+				Version: semver.MustParse("3.4.1"),
+				Edition: capabilities.EditionEnterprise,
+			},
+			capability: capabilities.ConsumerGroupScopedPlugins,
+		},
+		{
+			name: "consumer group scoped plugins in older enterprise",
+			gateway: capabilities.Gateway{
+				Version: semver.MustParse("3.3.0"),
+				Edition: capabilities.EditionEnterprise,
+			},
+			capability:    capabilities.ConsumerGroupScopedPlugins,
+			expectedError: "consumer group scoped plugins require Kong Gateway 3.4.0 or newer, while it's 3.3.0",
+		},
+		{
+			name: "consumer groups in oss",
+			gateway: capabilities.Gateway{
+				Version: semver.MustParse("3.4.1"),
+				Edition: capabilities.EditionOSS,
+			},
+			capability:    capabilities.ConsumerGroups,
+			expectedError: "consumer groups require Kong Gateway Enterprise",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r := capabilities.NewRegistry(tc.gateway)
+			err := r.Check(tc.capability)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				require.False(t, r.Supports(tc.capability))
+			} else {
+				require.NoError(t, err)
+				require.True(t, r.Supports(tc.capability))
+			}
+		})
+	}
+}
+
+func TestRegistry_CheckProtocol(t *testing.T) {
+	oss := capabilities.NewRegistry(capabilities.Gateway{Version: semver.MustParse("3.4.1"), Edition: capabilities.EditionOSS})
+	require.NoError(t, oss.CheckProtocol("https"))
+	require.NoError(t, oss.CheckProtocol("tls_passthrough"))
+	require.EqualError(t, oss.CheckProtocol("wss"), "ws and wss protocols require Kong Gateway Enterprise")
+
+	enterprise := capabilities.NewRegistry(capabilities.Gateway{Version: semver.MustParse("3.4.1"), Edition: capabilities.EditionEnterprise})
+	require.NoError(t, enterprise.CheckProtocol("wss"))
+}
+
+func TestRegistry_CheckPlugin(t *testing.T) {
+	t.Run("unknown loaded plugins", func(t *testing.T) {
+		r := capabilities.NewRegistry(capabilities.Gateway{})
+		require.NoError(t, r.CheckPlugin("key-auth"))
+	})
+
+	t.Run("known loaded plugins", func(t *testing.T) {
+		r := capabilities.NewRegistry(capabilities.Gateway{LoadedPlugins: []string{"key-auth", "cors"}})
+		require.NoError(t, r.CheckPlugin("key-auth"))
+		require.EqualError(t, r.CheckPlugin("openid-connect"), `plugin "openid-connect" is not loaded by Kong Gateway`)
+	})
+}
+
+func TestRegistry_Supported(t *testing.T) {
+	r := capabilities.NewRegistry(capabilities.Gateway{
+		Version:      semver.MustParse("3.4.1"),
+		Edition:      capabilities.EditionOSS,
+		RouterFlavor: "traditional_compatible",
+	})
+	require.Equal(t, []capabilities.Capability{capabilities.TLSPassthroughProtocol}, r.Supported())
+}
+
+func TestCommonGateway(t *testing.T) {
+	testCases := []struct {
+		name     string
+		gateways []capabilities.Gateway
+		expected capabilities.Gateway
+	}{
+		{
+			name:     "no gateways",
+			expected: capabilities.Gateway{},
+		},
+		{
+			name: "single gateway",
+			gateways: []capabilities.Gateway{
+				{
+					Version:       semver.MustParse("3.4.1"),
+					Edition:       capabilities.EditionEnterprise,
+					RouterFlavor:  capabilities.RouterFlavorExpressions,
+					LoadedPlugins: []string{"key-auth", "cors"},
+				},
+			},
+			expected: capabilities.Gateway{
+				Version:       semver.MustParse("3.4.1"),
+				Edition:       capabilities.EditionEnterprise,
+				RouterFlavor:  capabilities.RouterFlavorExpressions,
+				LoadedPlugins: []string{"cors", "key-auth"},
+			},
+		},
+		{
+			name: "gateways during a rolling upgrade",
+			gateways: []capabilities.Gateway{
+				{
+					Version:       semver.MustParse("3.5.0"),
+					Edition:       capabilities.EditionEnterprise,
+					RouterFlavor:  capabilities.RouterFlavorExpressions,
+					LoadedPlugins: []string{"key-auth", "cors", "ai-proxy"},
+				},
+				{
+					Version:       semver.MustParse("3.4.1"),
+					Edition:       capabilities.EditionEnterprise,
+					RouterFlavor:  "traditional_compatible",
+					LoadedPlugins: []string{"key-auth", "cors"},
+				},
+			},
+			expected: capabilities.Gateway{
+				Version:       semver.MustParse("3.4.1"),
+				Edition:       capabilities.EditionEnterprise,
+				RouterFlavor:  "traditional_compatible",
+				LoadedPlugins: []string{"cors", "key-auth"},
+			},
+		},
+		{
+			name: "oss and enterprise gateways",
+			gateways: []capabilities.Gateway{
+				{Version: semver.MustParse("3.4.1"), Edition: capabilities.EditionEnterprise},
+				{Version: semver.MustParse("3.4.1"), Edition: capabilities.EditionOSS},
+			},
+			expected: capabilities.Gateway{
+				Version: semver.MustParse("3.4.1"),
+				Edition: capabilities.EditionOSS,
+			},
+		},
+		{
+			name: "gateways with unknown properties",
+			gateways: []capabilities.Gateway{
+				{Edition: capabilities.EditionEnterprise},
+				{Version: semver.MustParse("3.4.1"), LoadedPlugins: []string{"key-auth"}},
+			},
+			expected: capabilities.Gateway{
+				Version:       semver.MustParse("3.4.1"),
+				LoadedPlugins: []string{"key-auth"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			common := capabilities.CommonGateway(tc.gateways...)
+			require.Equal(t, tc.expected, common)
+			require.True(t, tc.expected.Equal(common))
+		})
+	}
+}
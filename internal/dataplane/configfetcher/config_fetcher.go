@@ -24,6 +24,9 @@ type LastValidConfigFetcher interface {
 
 	// StoreLastValidConfig stores a given configuration as the last valid config. Should be used when the configuration was successfully accepted by a gateway.
 	StoreLastValidConfig(s *kongstate.KongState)
+
+	// FetchCurrentConfig fetches the configuration currently loaded by a gateway without persisting it.
+	FetchCurrentConfig(ctx context.Context, logger logr.Logger, gatewayClient *adminapi.Client) (*kongstate.KongState, error)
 }
 
 type DefaultKongLastGoodConfigFetcher struct {
@@ -97,6 +100,23 @@ func (cf *DefaultKongLastGoodConfigFetcher) TryFetchingValidConfigFromGateways(
 	return errs
 }
 
+func (cf *DefaultKongLastGoodConfigFetcher) FetchCurrentConfig(
+	ctx context.Context,
+	logger logr.Logger,
+	gatewayClient *adminapi.Client,
+) (*kongstate.KongState, error) {
+	logger.V(util.DebugLevel).Info("fetching current configuration", "url", gatewayClient.BaseRootURL())
+	rs, err := cf.getKongRawState(ctx, gatewayClient.AdminAPIClient())
+	if err != nil {
+		return nil, err
+	}
+	ks := KongRawStateToKongState(rs)
+	if cf.fillIDs {
+		ks.FillIDs(logger)
+	}
+	return ks, nil
+}
+
 func (cf *DefaultKongLastGoodConfigFetcher) getKongRawState(ctx context.Context, client *kong.Client) (*utils.KongRawState, error) {
 	return dump.Get(ctx, client, cf.config)
 }
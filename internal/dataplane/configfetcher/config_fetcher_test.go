@@ -103,3 +103,19 @@ func TestTryFetchingValidConfigFromGateways(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchCurrentConfig(t *testing.T) {
+	ctx := context.Background()
+	adminAPIServer := httptest.NewServer(mocks.NewAdminAPIHandler(t, mocks.WithReady(true)))
+	t.Cleanup(func() { adminAPIServer.Close() })
+	client, err := adminapi.NewKongClientForWorkspace(ctx, adminAPIServer.URL, "", adminAPIServer.Client())
+	require.NoError(t, err)
+
+	fetcher := NewDefaultKongLastGoodConfigFetcher(false)
+	state, err := fetcher.FetchCurrentConfig(ctx, zapr.NewLogger(zap.NewNop()), client)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+
+	_, ok := fetcher.LastValidConfig()
+	require.False(t, ok, "fetched configuration should not be persisted as the last valid one")
+}
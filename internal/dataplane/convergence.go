@@ -0,0 +1,197 @@
+package dataplane
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// DefaultConvergencePollInterval is the default interval of polling gateways' /status endpoint while waiting
+// for them to converge to the pushed configuration.
+const DefaultConvergencePollInterval = 200 * time.Millisecond
+
+// ConvergenceCheckConfig configures verification that DB-less gateways run the configuration pushed to them.
+// After every push, the gateway's /status endpoint is polled until its configuration_hash reports the pushed
+// configuration. As Kong calculates the hash on its own, the hash a configuration is reported with is learned
+// from the first gateway that converges to it, and all other gateways are expected to report the same hash.
+// Gateways that report a different hash while no push was needed (e.g. after an in-place restart) are
+// considered drifted and the configuration is pushed to them again.
+type ConvergenceCheckConfig struct {
+	// Timeout is the maximum time to wait for a gateway to converge after a push. Gateways that don't
+	// converge in time are considered lagging and get the configuration pushed again with the next sync.
+	Timeout time.Duration
+
+	// PollInterval is the interval of polling gateways' /status endpoint.
+	PollInterval time.Duration
+}
+
+// convergenceCheck holds the convergence check configuration and state of KongClient. It's safe for
+// concurrent use as gateways are verified concurrently.
+type convergenceCheck struct {
+	config ConvergenceCheckConfig
+
+	lock sync.Mutex
+	// expectedSHA is the hash of the configuration expectedKongHash was learned for.
+	expectedSHA []byte
+	// expectedKongHash is the configuration hash gateways report for the configuration with expectedSHA.
+	expectedKongHash string
+	// observedKongHashes are configuration hashes last reported by gateways (keyed by their URLs) once
+	// they converged.
+	observedKongHashes map[string]string
+}
+
+// expectedKongHashFor returns the configuration hash gateways are expected to report for the configuration
+// with the given hash, if it's known.
+func (cc *convergenceCheck) expectedKongHashFor(sha []byte, empty bool) (string, bool) {
+	if empty {
+		return sendconfig.WellKnownInitialHash, true
+	}
+
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	if bytes.Equal(cc.expectedSHA, sha) {
+		return cc.expectedKongHash, true
+	}
+	return "", false
+}
+
+func (cc *convergenceCheck) observedKongHash(url string) string {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	return cc.observedKongHashes[url]
+}
+
+// storeConverged stores the configuration hash the gateway reported once it converged to the configuration
+// with the given hash. The first gateway that converges to a configuration determines its expected hash.
+func (cc *convergenceCheck) storeConverged(url string, sha []byte, kongHash string) {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	if !bytes.Equal(cc.expectedSHA, sha) {
+		cc.expectedSHA = sha
+		cc.expectedKongHash = kongHash
+	}
+	cc.observedKongHashes[url] = kongHash
+}
+
+// SetConvergenceCheck enables verification that DB-less gateways run the configuration pushed to them.
+func (c *KongClient) SetConvergenceCheck(config ConvergenceCheckConfig) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.convergence = &convergenceCheck{
+		config:             config,
+		observedKongHashes: map[string]string{},
+	}
+}
+
+// convergenceCheckEnabled tells whether gateways should be verified to converge to the pushed configuration.
+// Only DB-less gateways report the hash of their configuration.
+func (c *KongClient) convergenceCheckEnabled(config sendconfig.Config) bool {
+	return c.convergence != nil && config.InMemory
+}
+
+// verifyConvergence verifies that the gateway runs the generated configuration. When the configuration
+// was pushed, it waits for the gateway to converge to it. Otherwise, it checks whether the gateway drifted
+// from the configuration and, if so, pushes it again.
+func (c *KongClient) verifyConvergence(
+	ctx context.Context, client *adminapi.Client, config sendconfig.Config, generated generatedContent, pushed bool,
+) error {
+	url := client.BaseRootURL()
+	sha := generated.content.Hash
+	empty := deckgen.IsContentEmpty(generated.content.Content)
+
+	if !pushed {
+		expected, known := c.convergence.expectedKongHashFor(sha, empty)
+		if !known {
+			return nil
+		}
+		kongHash, err := c.gatewayConfigurationHash(ctx, client)
+		if err != nil {
+			// Unavailability of the gateway is not a drift, the next sync is going to verify it again.
+			c.logger.V(util.DebugLevel).Info("failed to verify gateway's configuration hash", "url", url, "error", err.Error())
+			return nil
+		}
+		if kongHash == expected {
+			return nil
+		}
+
+		c.logger.Info("gateway drifted from the pushed configuration, pushing it again",
+			"url", url, "expected_hash", expected, "actual_hash", kongHash)
+		c.prometheusMetrics.RecordConfigDrift(url)
+		client.SetLastConfigSHA(nil)
+		if _, err := c.sendToClient(ctx, client, config, generated); err != nil {
+			return err
+		}
+	}
+
+	return c.awaitConvergence(ctx, client, sha, empty)
+}
+
+// awaitConvergence polls the gateway's /status endpoint until it reports the configuration with the given hash.
+// When the hash Kong reports for the configuration is not known yet, the first hash that differs from the one
+// the gateway reported previously is accepted, as Kong loads DB-less configuration before responding to the push.
+func (c *KongClient) awaitConvergence(ctx context.Context, client *adminapi.Client, sha []byte, empty bool) error {
+	var (
+		url      = client.BaseRootURL()
+		previous = c.convergence.observedKongHash(url)
+		start    = time.Now()
+	)
+	converged := func(kongHash string) bool {
+		if expected, known := c.convergence.expectedKongHashFor(sha, empty); known {
+			return kongHash == expected
+		}
+		return kongHash != "" && kongHash != sendconfig.WellKnownInitialHash && kongHash != previous
+	}
+
+	timeout := time.NewTimer(c.convergence.config.Timeout)
+	defer timeout.Stop()
+	poll := time.NewTicker(c.convergence.config.PollInterval)
+	defer poll.Stop()
+
+	var lastErr error
+	for {
+		kongHash, err := c.gatewayConfigurationHash(ctx, client)
+		if err == nil && converged(kongHash) {
+			c.prometheusMetrics.RecordConfigConvergence(url, time.Since(start), true)
+			c.convergence.storeConverged(url, sha, kongHash)
+			c.logger.V(util.DebugLevel).Info("gateway converged to the pushed configuration", "url", url, "hash", kongHash,
+				"duration", time.Since(start))
+			return nil
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			c.prometheusMetrics.RecordConfigConvergence(url, time.Since(start), false)
+			// Make sure the configuration is pushed again with the next sync.
+			client.SetLastConfigSHA(nil)
+			if lastErr != nil {
+				return fmt.Errorf("gateway %s didn't converge to the pushed configuration within %s: %w",
+					url, c.convergence.config.Timeout, lastErr)
+			}
+			return fmt.Errorf("gateway %s didn't converge to the pushed configuration within %s, it reports hash %s",
+				url, c.convergence.config.Timeout, kongHash)
+		case <-poll.C:
+		}
+	}
+}
+
+// gatewayConfigurationHash returns the configuration hash the gateway reports in its /status endpoint.
+func (c *KongClient) gatewayConfigurationHash(ctx context.Context, client *adminapi.Client) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	status, err := client.AdminAPIClient().Status(ctx)
+	if err != nil {
+		return "", err
+	}
+	return status.ConfigurationHash, nil
+}
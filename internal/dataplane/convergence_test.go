@@ -0,0 +1,155 @@
+package dataplane
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/zapr"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	"github.com/kong/kubernetes-ingress-controller/v2/test/mocks"
+)
+
+// fakeDBLessGateway is a fake DB-less Kong Gateway's Admin API that reports the MD5 of the last configuration
+// posted to /config as its configuration hash.
+type fakeDBLessGateway struct {
+	lock              sync.Mutex
+	configurationHash string
+	configPosts       int
+	// ignoreConfigPosts makes the gateway accept configuration without loading it.
+	ignoreConfigPosts bool
+}
+
+func newFakeDBLessGateway(t *testing.T) (*fakeDBLessGateway, *adminapi.Client) {
+	g := &fakeDBLessGateway{configurationHash: sendconfig.WellKnownInitialHash}
+	server := httptest.NewServer(g)
+	t.Cleanup(server.Close)
+
+	client, err := adminapi.NewTestClient(server.URL)
+	require.NoError(t, err)
+	return g, client
+}
+
+func (g *fakeDBLessGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/status":
+		_, _ = fmt.Fprintf(w, `{"configuration_hash": %q}`, g.configurationHash)
+	case r.Method == http.MethodPost && r.URL.Path == "/config":
+		body, _ := io.ReadAll(r.Body)
+		g.configPosts++
+		if !g.ignoreConfigPosts {
+			sum := md5.Sum(body) //nolint:gosec
+			g.configurationHash = hex.EncodeToString(sum[:])
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (g *fakeDBLessGateway) setConfigurationHash(hash string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.configurationHash = hash
+}
+
+func (g *fakeDBLessGateway) setIgnoreConfigPosts(ignore bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.ignoreConfigPosts = ignore
+}
+
+func (g *fakeDBLessGateway) posts() int {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.configPosts
+}
+
+func TestKongClientUpdate_ConvergenceCheck(t *testing.T) {
+	var (
+		ctx               = context.Background()
+		logger            = zapr.NewLogger(zap.NewNop())
+		gateway1, client1 = newFakeDBLessGateway(t)
+		gateway2, client2 = newFakeDBLessGateway(t)
+		clientsProvider   = mockGatewayClientsProvider{
+			gatewayClients: []*adminapi.Client{client1, client2},
+		}
+		config        = sendconfig.Config{InMemory: true}
+		configBuilder = newMockKongConfigBuilder()
+	)
+	kongClient, err := NewKongClient(
+		logger,
+		time.Second,
+		"kong",
+		util.ConfigDumpDiagnostic{},
+		config,
+		mocks.NewEventRecorder(),
+		"off",
+		clientsProvider,
+		sendconfig.NewDefaultUpdateStrategyResolver(config, logger),
+		sendconfig.NewDefaultConfigurationChangeDetector(logger),
+		&mockKongLastValidConfigFetcher{},
+		configBuilder,
+		store.NewCacheStores(),
+	)
+	require.NoError(t, err)
+	kongClient.SetConvergenceCheck(ConvergenceCheckConfig{
+		Timeout:      100 * time.Millisecond,
+		PollInterval: 10 * time.Millisecond,
+	})
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{
+			{Service: kong.Service{Name: kong.String("service"), Host: kong.String("example.com")}},
+		},
+	}
+
+	t.Log("gateways converge to the pushed configuration")
+	require.NoError(t, kongClient.Update(ctx))
+	require.Equal(t, 1, gateway1.posts())
+	require.Equal(t, 1, gateway2.posts())
+	expectedHash := kongClient.convergence.expectedKongHash
+	require.NotEmpty(t, expectedHash)
+
+	t.Log("gateways running the pushed configuration don't get it pushed again")
+	require.NoError(t, kongClient.Update(ctx))
+	require.Equal(t, 1, gateway1.posts())
+	require.Equal(t, 1, gateway2.posts())
+
+	t.Log("gateway that silently drifted gets the configuration pushed again")
+	gateway2.setConfigurationHash("0123456789abcdef0123456789abcdef")
+	require.NoError(t, kongClient.Update(ctx))
+	require.Equal(t, 1, gateway1.posts())
+	require.Equal(t, 2, gateway2.posts())
+	require.Equal(t, expectedHash, kongClient.convergence.observedKongHash(client2.BaseRootURL()))
+
+	t.Log("gateway that doesn't converge in time fails the sync and gets the configuration pushed with the next sync")
+	gateway1.setIgnoreConfigPosts(true)
+	gateway1.setConfigurationHash("0123456789abcdef0123456789abcdef")
+	require.ErrorContains(t, kongClient.Update(ctx), "didn't converge to the pushed configuration")
+	require.Nil(t, client1.LastConfigSHA())
+
+	gateway1.setIgnoreConfigPosts(false)
+	postsBefore := gateway1.posts()
+	require.NoError(t, kongClient.Update(ctx))
+	require.Equal(t, postsBefore+1, gateway1.posts())
+	require.Equal(t, expectedHash, kongClient.convergence.observedKongHash(client1.BaseRootURL()))
+}
@@ -0,0 +1,72 @@
+package deckerrors
+
+import (
+	"errors"
+	"strings"
+)
+
+// EntityError is an error of a decK sync operation on a single Kong entity.
+type EntityError struct {
+	// Op is the operation that failed, e.g. "Create".
+	Op string
+
+	// Kind is decK's kind of the entity, e.g. "service".
+	Kind string
+
+	// Entity is decK's console representation of the entity. It's its name or ID, in case of some kinds followed
+	// by entities it's associated with (e.g. "key-auth for plugin rate-limiting for service foo").
+	Entity string
+
+	// Err is the cause of the failure, e.g. a *kong.APIError.
+	Err error
+}
+
+// ExtractEntityErrors extracts errors of operations on individual Kong entities from errors returned by decK's
+// syncer. Errors that are not associated with a single entity are skipped.
+func ExtractEntityErrors(errs []error) []EntityError {
+	var entityErrs []EntityError
+	for _, err := range errs {
+		if entityErr, ok := parseEntityError(err); ok {
+			entityErrs = append(entityErrs, entityErr)
+		}
+	}
+	return entityErrs
+}
+
+// parseEntityError parses an error of decK's syncer failing to process an event. Such errors have the form of
+// "while processing event: {<Op>} <kind> <entity> failed: <cause>", with every part but the entity and the cause
+// wrapping the next one.
+func parseEntityError(err error) (EntityError, bool) {
+	const eventErrPrefix = "while processing event: "
+	if !strings.HasPrefix(err.Error(), eventErrPrefix) {
+		return EntityError{}, false
+	}
+	opErr := errors.Unwrap(err)
+	if opErr == nil {
+		return EntityError{}, false
+	}
+	cause := errors.Unwrap(opErr)
+	if cause == nil {
+		return EntityError{}, false
+	}
+
+	op, ok := strings.CutSuffix(opErr.Error(), " failed: "+cause.Error())
+	if !ok || !strings.HasPrefix(op, "{") {
+		return EntityError{}, false
+	}
+	opName, kindAndEntity, ok := strings.Cut(strings.TrimPrefix(op, "{"), "} ")
+	if !ok {
+		return EntityError{}, false
+	}
+	kind, entity, ok := strings.Cut(kindAndEntity, " ")
+	if !ok {
+		return EntityError{}, false
+	}
+
+	return EntityError{
+		Op:     opName,
+		Kind:   kind,
+		Entity: entity,
+		Err:    cause,
+	}, true
+}
@@ -0,0 +1,62 @@
+package deckerrors_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckerrors"
+)
+
+func TestExtractEntityErrors(t *testing.T) {
+	// deckEventErr returns an error the way decK's syncer wraps errors of processing events.
+	deckEventErr := func(op, kind, entity string, cause error) error {
+		opErr := fmt.Errorf("{%s} %s %s failed: %w", op, kind, entity, cause)
+		return fmt.Errorf("while processing event: %w", opErr)
+	}
+	apiErr := kong.NewAPIError(http.StatusBadRequest, "schema violation (paths.1: should start with: /)")
+
+	testCases := []struct {
+		name     string
+		input    []error
+		expected []deckerrors.EntityError
+	}{
+		{
+			name:     "no errors",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name: "entity errors",
+			input: []error{
+				deckEventErr("Create", "route", "default.httpbin.httpbin..80", apiErr),
+				deckEventErr("Update", "plugin", "rate-limiting for service default.httpbin.80", apiErr),
+			},
+			expected: []deckerrors.EntityError{
+				{Op: "Create", Kind: "route", Entity: "default.httpbin.httpbin..80", Err: apiErr},
+				{Op: "Update", Kind: "plugin", Entity: "rate-limiting for service default.httpbin.80", Err: apiErr},
+			},
+		},
+		{
+			name: "errors not associated with entities are skipped",
+			input: []error{
+				errors.New("failed to sync all entities: context canceled"),
+				fmt.Errorf("while processing event: %w", errors.New("result of event is nil")),
+				deckEventErr("Delete", "consumer", "alice", apiErr),
+			},
+			expected: []deckerrors.EntityError{
+				{Op: "Delete", Kind: "consumer", Entity: "alice", Err: apiErr},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, deckerrors.ExtractEntityErrors(tc.input))
+		})
+	}
+}
@@ -13,13 +13,20 @@ import (
 // GenerateSHA generates a SHA256 checksum of targetContent, with the purpose
 // of change detection.
 func GenerateSHA(targetContent *file.Content) ([]byte, error) {
+	sha, _, err := GenerateSHAAndSize(targetContent)
+	return sha, err
+}
+
+// GenerateSHAAndSize generates a SHA256 checksum of targetContent like GenerateSHA
+// does, along with the size of targetContent serialized to JSON in bytes.
+func GenerateSHAAndSize(targetContent *file.Content) ([]byte, int, error) {
 	jsonConfig, err := gojson.Marshal(targetContent)
 	if err != nil {
-		return nil, fmt.Errorf("marshaling Kong declarative configuration to JSON: %w", err)
+		return nil, 0, fmt.Errorf("marshaling Kong declarative configuration to JSON: %w", err)
 	}
 
 	shaSum := sha256.Sum256(jsonConfig)
-	return shaSum[:], nil
+	return shaSum[:], len(jsonConfig), nil
 }
 
 // GetFCertificateFromKongCert converts a kong.Certificate to a file.FCertificate.
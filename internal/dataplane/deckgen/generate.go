@@ -12,6 +12,7 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/versions"
 )
 
@@ -22,6 +23,15 @@ type PluginSchemaStore interface {
 	Schema(ctx context.Context, pluginName string) (map[string]interface{}, error)
 }
 
+// EmptyPluginSchemaStore is a PluginSchemaStore that returns empty schemas for all plugins, so plugins'
+// configurations are not filled with defaults. It's meant to be used when there's no Kong Gateway
+// to retrieve the schemas from.
+type EmptyPluginSchemaStore struct{}
+
+func (EmptyPluginSchemaStore) Schema(context.Context, string) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+
 // GenerateDeckContentParams is the parameters used to generate deck contents.
 type GenerateDeckContentParams struct {
 	SelectorTags     []string
@@ -41,6 +51,9 @@ func ToDeckContent(
 	k8sState *kongstate.KongState,
 	params GenerateDeckContentParams,
 ) *file.Content {
+	ctx, span := tracing.StartSpan(ctx, "deckgen.ToDeckContent")
+	defer span.End()
+
 	var content file.Content
 	content.FormatVersion = versions.DeckFileFormatVersion
 
@@ -0,0 +1,205 @@
+package dataplane
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/sourcegraph/conc/iter"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// DriftDetectionConfig configures periodic detection of changes made out of band (e.g. through the Admin API)
+// to entities managed by the controller in Kong Gateways running in DB mode. The state of each gateway is diffed
+// against the configuration most recently pushed to it, taking into account only entities tagged with the
+// controller's filter tags.
+type DriftDetectionConfig struct {
+	// AutoCorrect enables pushing the configuration again to gateways that drifted. Otherwise, the drift is
+	// only reported.
+	AutoCorrect bool
+}
+
+// driftDetection holds the drift detection configuration and state of KongClient. It's guarded by KongClient's lock.
+type driftDetection struct {
+	config DriftDetectionConfig
+
+	// pushedContents are contents generated for gateway clients indexed by their hashes, so that the content
+	// each of the gateways was last synced with can be looked up.
+	pushedContents map[string]sendconfig.ContentWithHash
+}
+
+// SetDriftDetection enables drift detection for Kong Gateways running in DB mode. It has to be run periodically
+// with DetectDrift, e.g. by DriftDetector.
+func (c *KongClient) SetDriftDetection(config DriftDetectionConfig) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.drift = &driftDetection{
+		config:         config,
+		pushedContents: map[string]sendconfig.ContentWithHash{},
+	}
+}
+
+// driftDetectionEnabled tells whether gateways should be checked for drift. Only gateways in DB mode store
+// managed entities that can be changed out of band.
+func (c *KongClient) driftDetectionEnabled(config sendconfig.Config) bool {
+	return c.drift != nil && !config.InMemory
+}
+
+// storePushedContent stores content generated for gateway clients, so that they can be checked for drift from it.
+func (c *KongClient) storePushedContent(config sendconfig.Config, content sendconfig.ContentWithHash) {
+	if !c.driftDetectionEnabled(config) {
+		return
+	}
+	c.prunePushedContents()
+	c.drift.pushedContents[string(content.Hash)] = content
+}
+
+// prunePushedContents removes stored contents no gateway client is synced with anymore.
+func (c *KongClient) prunePushedContents() {
+	referenced := map[string]struct{}{}
+	for _, client := range c.clientsProvider.GatewayClients() {
+		referenced[string(client.LastConfigSHA())] = struct{}{}
+	}
+	for sha := range c.drift.pushedContents {
+		if _, ok := referenced[sha]; !ok {
+			delete(c.drift.pushedContents, sha)
+		}
+	}
+}
+
+// DetectDrift checks gateway clients for changes made to managed entities out of band and reports them in metrics,
+// events and diagnostics. When auto-correction is enabled, the configuration is pushed again to gateways that drifted.
+// Only gateways that are in sync with the most recently pushed configuration are checked, as others are going to be
+// synced anyway.
+func (c *KongClient) DetectDrift(ctx context.Context) []util.GatewayConfigDrift {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.driftDetectionEnabled(c.kongConfig) || c.kongConfig.DryRun {
+		return nil
+	}
+
+	type checkedClient struct {
+		client  *adminapi.Client
+		content sendconfig.ContentWithHash
+	}
+	c.prunePushedContents()
+	var checked []checkedClient
+	for _, client := range c.clientsProvider.GatewayClients() {
+		if content, ok := c.drift.pushedContents[string(client.LastConfigSHA())]; ok {
+			checked = append(checked, checkedClient{client: client, content: content})
+		}
+	}
+
+	// Gateways synced with the same configuration share its content. Each detection builds the target state
+	// from its own copy of it, so the shared content is never modified concurrently.
+	drifts := iter.Map(checked, func(cc *checkedClient) util.GatewayConfigDrift {
+		return c.detectClientDrift(ctx, cc.client, cc.content)
+	})
+	if c.diagnostic.GatewayConfigDrifts != nil {
+		select {
+		case c.diagnostic.GatewayConfigDrifts <- drifts:
+		default:
+			c.logger.Error(nil, "gateway config drift diagnostic buffer full, dropping diagnostic")
+		}
+	}
+	return drifts
+}
+
+// detectClientDrift checks a single gateway client for drift from the content it was last synced with.
+func (c *KongClient) detectClientDrift(
+	ctx context.Context, client *adminapi.Client, content sendconfig.ContentWithHash,
+) util.GatewayConfigDrift {
+	url := client.BaseRootURL()
+	logger := c.logger.WithValues("url", url)
+	result := util.GatewayConfigDrift{URL: url, Time: time.Now()}
+
+	timedCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	drift, err := sendconfig.DetectDrift(timedCtx, client, c.kongConfig, content, logger)
+	if err != nil {
+		logger.Error(err, "failed to detect configuration drift")
+		result.Error = err.Error()
+		return result
+	}
+	result.Added, result.Removed, result.Modified = drift.Added, drift.Removed, drift.Modified
+
+	c.prometheusMetrics.RecordConfigDriftEntities(url, len(drift.Added), len(drift.Removed), len(drift.Modified))
+	if drift.Empty() {
+		logger.V(util.DebugLevel).Info("no configuration drift detected")
+		return result
+	}
+
+	c.prometheusMetrics.RecordConfigDrift(url)
+	message := fmt.Sprintf("managed entities were changed in %s out of band: %d added, %d removed, %d modified",
+		url, len(drift.Added), len(drift.Removed), len(drift.Modified))
+	logger.Info("configuration drift detected",
+		"added", len(drift.Added), "removed", len(drift.Removed), "modified", len(drift.Modified))
+	c.recordControllerPodEvent(corev1.EventTypeWarning, KongConfigurationDriftDetectedEventReason, message)
+
+	if !c.drift.config.AutoCorrect {
+		return result
+	}
+	// Forget the hash of the configuration the gateway was synced with, so that it's pushed again.
+	previousSHA := client.LastConfigSHA()
+	client.SetLastConfigSHA(nil)
+	if _, err := c.sendToClient(ctx, client, c.kongConfig, generatedContent{
+		content:        content,
+		sendConfigDump: func(util.ConfigDump) {},
+	}); err != nil {
+		logger.Error(err, "failed to correct configuration drift")
+		client.SetLastConfigSHA(previousSHA)
+		result.Error = err.Error()
+		return result
+	}
+	result.Corrected = bytes.Equal(client.LastConfigSHA(), content.Hash)
+	return result
+}
+
+// DriftDetector is a controller-runtime Runnable that periodically detects drift of Kong Gateways running in DB mode
+// from the configuration pushed to them.
+type DriftDetector struct {
+	logger   logr.Logger
+	client   *KongClient
+	interval time.Duration
+}
+
+// NewDriftDetector creates a DriftDetector checking the client's gateways with the given interval. Drift detection
+// has to be enabled in the client with KongClient.SetDriftDetection.
+func NewDriftDetector(logger logr.Logger, client *KongClient, interval time.Duration) *DriftDetector {
+	return &DriftDetector{
+		logger:   logger,
+		client:   client,
+		interval: interval,
+	}
+}
+
+// Start implements the controller-runtime Runnable interface. It blocks until ctx is done.
+func (d *DriftDetector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	d.logger.Info("starting configuration drift detection", "interval", d.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("stopping configuration drift detection")
+			return nil
+		case <-ticker.C:
+			d.client.DetectDrift(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection implements the controller-runtime LeaderElectionRunnable interface. Only the leader pushes
+// configuration, so only the leader knows what gateways are expected to run.
+func (d *DriftDetector) NeedLeaderElection() bool {
+	return true
+}
@@ -0,0 +1,174 @@
+package dataplane
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+const (
+	// failureStageTranslation is the stage of objects failing translation.
+	failureStageTranslation = "translation"
+	// failureStagePush is the stage of objects whose configuration was rejected by Kong.
+	failureStagePush = "push"
+)
+
+type failingObjectKey struct {
+	stage string
+	ref   audit.ObjectRef
+}
+
+// failingObjectsTracker tracks Kubernetes objects whose configuration failed to be translated or pushed to Kong,
+// along with the time since when they have been failing. It's safe for concurrent use.
+type failingObjectsTracker struct {
+	lock sync.Mutex
+	// pushFailures are failures of the most recent pushes of configuration to each of the clients, indexed by
+	// the clients' URLs.
+	pushFailures map[string][]failures.ResourceFailure
+	// reportedClients are clients whose push failures are included in the next report, i.e. the ones the
+	// configuration was pushed to or whose push was skipped since the last report.
+	reportedClients map[string]struct{}
+	// firstSeen are times since when the currently failing objects have been failing.
+	firstSeen map[failingObjectKey]time.Time
+}
+
+func newFailingObjectsTracker() *failingObjectsTracker {
+	return &failingObjectsTracker{
+		pushFailures:    make(map[string][]failures.ResourceFailure),
+		reportedClients: make(map[string]struct{}),
+		firstSeen:       make(map[failingObjectKey]time.Time),
+	}
+}
+
+// addPushFailures adds failures of pushing configuration to the client to be included in the next report. They
+// replace failures of pushes to the client before the last report.
+func (t *failingObjectsTracker) addPushFailures(client string, pushFailures []failures.ResourceFailure) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if _, ok := t.reportedClients[client]; !ok {
+		t.pushFailures[client] = nil
+		t.reportedClients[client] = struct{}{}
+	}
+	t.pushFailures[client] = append(t.pushFailures[client], pushFailures...)
+}
+
+// keepPushFailures includes failures of the most recent push to the client in the next report. It's used when
+// the push was skipped (e.g. as the client's circuit breaker rejects the same configuration), so objects that
+// made the client reject it are still reported as failing.
+func (t *failingObjectsTracker) keepPushFailures(client string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.reportedClients[client] = struct{}{}
+}
+
+// report returns objects failing translation with translationFailures and objects failing push with push failures
+// of clients the configuration was pushed to (or whose push was skipped) since the last report, sorted by their
+// stage, kind, namespace and name. Objects that are not failing anymore are forgotten, so they're reported with
+// a new first-seen time when they fail again. Push failures of other clients (e.g. removed ones) are forgotten too.
+func (t *failingObjectsTracker) report(translationFailures []failures.ResourceFailure, now time.Time) []util.FailingObject {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	failing := make(map[failingObjectKey]*util.FailingObject)
+	add := func(stage string, resourceFailures []failures.ResourceFailure) {
+		for _, f := range resourceFailures {
+			failure := util.ObjectFailure{Message: f.Message(), Reason: string(f.ReasonCategory())}
+			for _, obj := range f.CausingObjects() {
+				key := failingObjectKey{stage: stage, ref: audit.NewObjectRef(obj)}
+				fo, ok := failing[key]
+				if !ok {
+					firstSeen, ok := t.firstSeen[key]
+					if !ok {
+						firstSeen = now
+					}
+					fo = &util.FailingObject{
+						Kind:      key.ref.Kind,
+						Namespace: key.ref.Namespace,
+						Name:      key.ref.Name,
+						Stage:     stage,
+						FirstSeen: firstSeen,
+					}
+					failing[key] = fo
+				}
+				// The same failure is reported by every Kong Gateway that rejected the configuration.
+				if !containsFailure(fo.Failures, failure) {
+					fo.Failures = append(fo.Failures, failure)
+				}
+			}
+		}
+	}
+	add(failureStageTranslation, translationFailures)
+	for client := range t.pushFailures {
+		if _, ok := t.reportedClients[client]; !ok {
+			delete(t.pushFailures, client)
+			continue
+		}
+		add(failureStagePush, t.pushFailures[client])
+	}
+	t.reportedClients = make(map[string]struct{})
+
+	t.firstSeen = make(map[failingObjectKey]time.Time, len(failing))
+	report := make([]util.FailingObject, 0, len(failing))
+	for key, fo := range failing {
+		t.firstSeen[key] = fo.FirstSeen
+		report = append(report, *fo)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		a, b := report[i], report[j]
+		if a.Stage != b.Stage {
+			return a.Stage > b.Stage // translation first
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
+
+func containsFailure(fs []util.ObjectFailure, f util.ObjectFailure) bool {
+	for _, existing := range fs {
+		if existing == f {
+			return true
+		}
+	}
+	return false
+}
+
+// countFailingObjects counts failing objects at the stage by their kind, namespace and reasons of their failures.
+// An object failing for reasons of multiple categories is counted in each of them.
+func countFailingObjects(failing []util.FailingObject, stage string) []metrics.FailingObjectCount {
+	type countKey struct{ kind, namespace, reason string }
+	counts := make(map[countKey]int)
+	for _, fo := range failing {
+		if fo.Stage != stage {
+			continue
+		}
+		reasons := make(map[string]struct{}, len(fo.Failures))
+		for _, f := range fo.Failures {
+			reasons[f.Reason] = struct{}{}
+		}
+		for reason := range reasons {
+			counts[countKey{kind: fo.Kind, namespace: fo.Namespace, reason: reason}]++
+		}
+	}
+
+	result := make([]metrics.FailingObjectCount, 0, len(counts))
+	for key, count := range counts {
+		result = append(result, metrics.FailingObjectCount{
+			Kind:      key.kind,
+			Namespace: key.namespace,
+			Reason:    key.reason,
+			Count:     count,
+		})
+	}
+	return result
+}
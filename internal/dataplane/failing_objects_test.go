@@ -0,0 +1,76 @@
+package dataplane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+func TestFailingObjectsTracker(t *testing.T) {
+	newIngress := func(name string) *netv1.Ingress {
+		return &netv1.Ingress{
+			TypeMeta:   metav1.TypeMeta{Kind: "Ingress", APIVersion: netv1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		}
+	}
+	newFailure := func(message string, name string) failures.ResourceFailure {
+		f, err := failures.NewResourceFailure(message, newIngress(name))
+		require.NoError(t, err)
+		return f
+	}
+	var (
+		tracker = newFailingObjectsTracker()
+		first   = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+		second  = first.Add(time.Minute)
+	)
+
+	t.Log("objects failing translation and push are reported")
+	tracker.addPushFailures("gateway-1", []failures.ResourceFailure{newFailure("invalid path: should start with: /", "rejected")})
+	// The same failure reported by another Kong Gateway is reported once.
+	tracker.addPushFailures("gateway-2", []failures.ResourceFailure{newFailure("invalid path: should start with: /", "rejected")})
+	report := tracker.report([]failures.ResourceFailure{newFailure("failed to fetch the secret (default/tls)", "broken")}, first)
+	require.Equal(t, []util.FailingObject{
+		{
+			Kind: "Ingress", Namespace: "default", Name: "broken", Stage: failureStageTranslation,
+			Failures:  []util.ObjectFailure{{Message: "failed to fetch the secret (default/tls)", Reason: "missing_reference"}},
+			FirstSeen: first,
+		},
+		{
+			Kind: "Ingress", Namespace: "default", Name: "rejected", Stage: failureStagePush,
+			Failures:  []util.ObjectFailure{{Message: "invalid path: should start with: /", Reason: "invalid"}},
+			FirstSeen: first,
+		},
+	}, report)
+	require.Equal(t, []metrics.FailingObjectCount{
+		{Kind: "Ingress", Namespace: "default", Reason: "missing_reference", Count: 1},
+	}, countFailingObjects(report, failureStageTranslation))
+
+	t.Log("objects failing push are still reported when pushes to clients that rejected them are skipped")
+	tracker.keepPushFailures("gateway-1")
+	tracker.addPushFailures("gateway-2", nil)
+	report = tracker.report([]failures.ResourceFailure{newFailure("failed to fetch the secret (default/tls)", "broken")}, second)
+	require.Len(t, report, 2)
+	require.Equal(t, "rejected", report[1].Name)
+	require.Equal(t, failureStagePush, report[1].Stage)
+	require.Equal(t, first, report[1].FirstSeen)
+
+	t.Log("objects still failing keep their first-seen time with the latest messages, fixed ones are forgotten")
+	tracker.addPushFailures("gateway-1", nil)
+	tracker.addPushFailures("gateway-2", nil)
+	report = tracker.report([]failures.ResourceFailure{newFailure("failed to construct certificate from secret", "broken")}, second)
+	require.Equal(t, []util.FailingObject{
+		{
+			Kind: "Ingress", Namespace: "default", Name: "broken", Stage: failureStageTranslation,
+			Failures:  []util.ObjectFailure{{Message: "failed to construct certificate from secret", Reason: "certificate"}},
+			FirstSeen: first,
+		},
+	}, report)
+	require.Empty(t, countFailingObjects(report, failureStagePush))
+}
@@ -3,6 +3,8 @@ package failures
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -62,8 +64,57 @@ func (p ResourceFailure) Message() string {
 	return p.message
 }
 
+// ReasonCategory is a coarse category of the reason of a ResourceFailure. Categories are a small fixed set, so that
+// they can label metrics without making their cardinality depend on messages of failures.
+type ReasonCategory string
+
+const (
+	// ReasonCategoryUnsupported is used for configuration Kong Gateways don't support.
+	ReasonCategoryUnsupported ReasonCategory = "unsupported"
+	// ReasonCategoryCertificate is used for invalid or missing certificates.
+	ReasonCategoryCertificate ReasonCategory = "certificate"
+	// ReasonCategoryMissingReference is used for references to objects that can't be found.
+	ReasonCategoryMissingReference ReasonCategory = "missing_reference"
+	// ReasonCategoryInvalid is used for invalid configuration, including configuration rejected by Kong Gateways.
+	ReasonCategoryInvalid ReasonCategory = "invalid"
+	// ReasonCategoryOther is used for failures that don't fall into any other category.
+	ReasonCategoryOther ReasonCategory = "other"
+)
+
+// reasonCategoryKeywords maps categories to keywords of messages of failures falling into them, in the order
+// they're matched in.
+var reasonCategoryKeywords = []struct {
+	category ReasonCategory
+	keywords []string
+}{
+	{ReasonCategoryUnsupported, []string{"not supported", "unsupported"}},
+	{ReasonCategoryCertificate, []string{"certificate"}},
+	{ReasonCategoryMissingReference, []string{
+		"not found", "nonexistent", "does not exist", "doesn't exist", "failed to fetch", "failed fetching",
+		"can't find", "no key",
+	}},
+	{ReasonCategoryInvalid, []string{
+		"invalid", "validation", "could not parse", "error parsing", "inconsistent", "has both", "no username",
+	}},
+}
+
+// ReasonCategory categorizes the failure by its message.
+func (p ResourceFailure) ReasonCategory() ReasonCategory {
+	message := strings.ToLower(p.message)
+	for _, c := range reasonCategoryKeywords {
+		for _, keyword := range c.keywords {
+			if strings.Contains(message, keyword) {
+				return c.category
+			}
+		}
+	}
+	return ReasonCategoryOther
+}
+
 // ResourceFailuresCollector collects resource failures across different stages of resource processing.
+// It's safe for concurrent use.
 type ResourceFailuresCollector struct {
+	lock     sync.Mutex
 	failures []ResourceFailure
 	logger   logr.Logger
 }
@@ -80,7 +131,9 @@ func (c *ResourceFailuresCollector) PushResourceFailure(reason string, causingOb
 		return
 	}
 
+	c.lock.Lock()
 	c.failures = append(c.failures, resourceFailure)
+	c.lock.Unlock()
 	c.logResourceFailure(reason, causingObjects...)
 }
 
@@ -97,6 +150,9 @@ func (c *ResourceFailuresCollector) logResourceFailure(reason string, causingObj
 // PopResourceFailures returns all resource processing failures stored in the collector and clears the collector's
 // stored failures. The collector can then be reused for the next iteration of the process it tracks.
 func (c *ResourceFailuresCollector) PopResourceFailures() []ResourceFailure {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	errs := c.failures
 	c.failures = nil
 
@@ -62,6 +62,30 @@ func TestResourceFailure(t *testing.T) {
 	})
 }
 
+func TestResourceFailure_ReasonCategory(t *testing.T) {
+	testCases := []struct {
+		message  string
+		expected ReasonCategory
+	}{
+		{message: "configuration not supported by Kong Gateway: expressions router", expected: ReasonCategoryUnsupported},
+		{message: "failed to construct certificate from secret", expected: ReasonCategoryCertificate},
+		{message: "failed to fetch the secret (default/tls)", expected: ReasonCategoryMissingReference},
+		{message: "nonexistent consumer group: \"gold\"", expected: ReasonCategoryMissingReference},
+		{message: "invalid methods: unsupported method", expected: ReasonCategoryUnsupported},
+		{message: "invalid path: should start with: /", expected: ReasonCategoryInvalid},
+		{message: "HTTPRoute can't be routed: validation failed", expected: ReasonCategoryInvalid},
+		{message: ResourceFailureReasonUnknown, expected: ReasonCategoryOther},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.message, func(t *testing.T) {
+			failure, err := NewResourceFailure(tc.message, someResourceFailureCausingObjects()...)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, failure.ReasonCategory())
+		})
+	}
+}
+
 func TestResourceFailuresCollector(t *testing.T) {
 	t.Run("is created when logger valid", func(t *testing.T) {
 		logger := zapr.NewLogger(zap.NewNop())
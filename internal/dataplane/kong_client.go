@@ -2,6 +2,7 @@ package dataplane
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"reflect"
@@ -24,6 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/clients"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/configfetcher"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
@@ -31,8 +33,10 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/diagnostics"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	dataplaneutil "github.com/kong/kubernetes-ingress-controller/v2/internal/util/dataplane"
 	k8sobj "github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object"
@@ -46,6 +50,9 @@ const (
 	KongConfigurationTranslationFailedEventReason = "KongConfigurationTranslationFailed"
 	// KongConfigurationApplyFailedEventReason defines an event reason used for creating all config apply resource failure events.
 	KongConfigurationApplyFailedEventReason = "KongConfigurationApplyFailed"
+	// KongConfigurationDriftDetectedEventReason defines an event reason used for events of detected changes made
+	// to managed entities in Kong out of band.
+	KongConfigurationDriftDetectedEventReason = "KongConfigurationDriftDetected"
 )
 
 // -----------------------------------------------------------------------------
@@ -54,7 +61,12 @@ const (
 
 // KongConfigBuilder builds a Kong configuration from a Kubernetes object cache.
 type KongConfigBuilder interface {
-	BuildKongConfig() parser.KongConfigBuildingResult
+	BuildKongConfig(ctx context.Context) parser.KongConfigBuildingResult
+}
+
+// ChangeNotifier is notified about changes of the configuration cache that should be pushed to the data-plane.
+type ChangeNotifier interface {
+	NotifyChange()
 }
 
 // KongClient is a threadsafe high level API client for the Kong data-plane(s)
@@ -85,6 +97,9 @@ type KongClient struct {
 	// lock is used to ensure threadsafety of the KongClient object
 	lock sync.RWMutex
 
+	// updateLock serializes updates, as lock is released during the bake period of a canary rollout.
+	updateLock sync.Mutex
+
 	// diagnostic is the client and configuration for reporting diagnostic
 	// information during data-plane update runtime.
 	diagnostic util.ConfigDumpDiagnostic
@@ -127,6 +142,41 @@ type KongClient struct {
 	// configStatusNotifier notifies status of configuring kong gateway.
 	configStatusNotifier clients.ConfigStatusNotifier
 
+	// changeNotifier is notified whenever an object is updated in or deleted from the cache.
+	changeNotifier ChangeNotifier
+
+	// pendingChangeSince is the time of the oldest change of the cache that hasn't been pushed yet.
+	pendingChangeSince time.Time
+
+	// changedObjects, when set, collects objects changed since the last update, so that configuration pushes
+	// can be attributed to them in the audit log.
+	changedObjects *audit.ChangedObjects
+
+	// pendingChangeLock guards changeNotifier, pendingChangeSince and changedObjects, as they're accessed by
+	// reconcilers concurrently with updates.
+	pendingChangeLock sync.Mutex
+
+	// declarativeConfigOutputs are outputs (e.g. files) DB-less configuration is written to in addition to
+	// sending it to gateway clients.
+	declarativeConfigOutputs []sendconfig.DeclarativeConfigOutput
+
+	// declarativeConfigOutputsFailed tells whether writing configuration to any of declarativeConfigOutputs
+	// failed with the most recent update, so that the update is retried.
+	declarativeConfigOutputsFailed bool
+
+	// canaryRollout configures staged rollout of configuration to gateway clients. It's disabled by default.
+	canaryRollout canaryRollout
+
+	// convergence configures verification that gateways run the configuration pushed to them. It's disabled
+	// when nil.
+	convergence *convergenceCheck
+
+	// drift configures detection of changes made to managed entities in gateways out of band. It's disabled when nil.
+	drift *driftDetection
+
+	// versionSkew configures detection of gateways running different versions. It's disabled when nil.
+	versionSkew *versionSkewDetection
+
 	// updateStrategyResolver resolves the update strategy for a given Kong Gateway.
 	updateStrategyResolver sendconfig.UpdateStrategyResolver
 
@@ -136,6 +186,9 @@ type KongClient struct {
 	// kongConfigBuilder is used to translate Kubernetes objects into Kong configuration.
 	kongConfigBuilder KongConfigBuilder
 
+	// failingObjects tracks Kubernetes objects failing translation or push to report them in metrics and diagnostics.
+	failingObjects *failingObjectsTracker
+
 	// kongConfigFetcher fetches the loaded configuration and status from a Kong node.
 	kongConfigFetcher configfetcher.LastValidConfigFetcher
 
@@ -180,6 +233,7 @@ func NewKongClient(
 		configChangeDetector:   configChangeDetector,
 		kongConfigBuilder:      parser,
 		kongConfigFetcher:      kongConfigFetcher,
+		failingObjects:         newFailingObjectsTracker(),
 	}
 	c.initializeControllerPodReference()
 
@@ -205,7 +259,11 @@ func (c *KongClient) initializeControllerPodReference() {
 func (c *KongClient) UpdateObject(obj client.Object) error {
 	// we do a deep copy of the object here so that the caller can continue to use
 	// the original object in a threadsafe manner.
-	return c.cache.Add(obj.DeepCopyObject())
+	if err := c.cache.Add(obj.DeepCopyObject()); err != nil {
+		return err
+	}
+	c.notifyChange(obj)
+	return nil
 }
 
 // DeleteObject accepts a Kubernetes controller-runtime client.Object and removes it from the configuration cache.
@@ -215,7 +273,40 @@ func (c *KongClient) UpdateObject(obj client.Object) error {
 // under the hood the cache implementation will ignore deletions on objects
 // that are not present in the cache, so in those cases this is a no-op.
 func (c *KongClient) DeleteObject(obj client.Object) error {
-	return c.cache.Delete(obj)
+	if err := c.cache.Delete(obj); err != nil {
+		return err
+	}
+	c.notifyChange(obj)
+	return nil
+}
+
+// SetChangeNotifier sets a notifier that is notified whenever an object is updated in or deleted from
+// the configuration cache, e.g. an event-driven Synchronizer.
+func (c *KongClient) SetChangeNotifier(notifier ChangeNotifier) {
+	c.pendingChangeLock.Lock()
+	defer c.pendingChangeLock.Unlock()
+	c.changeNotifier = notifier
+}
+
+// EnableChangedObjectsTracking makes the client track objects changed between updates and attach them to
+// the context of configuration pushes with audit.WithTriggers.
+func (c *KongClient) EnableChangedObjectsTracking() {
+	c.pendingChangeLock.Lock()
+	defer c.pendingChangeLock.Unlock()
+	c.changedObjects = &audit.ChangedObjects{}
+}
+
+// UpdateRetryNeeded tells whether the update should be retried despite succeeding, because some of
+// the gateways didn't apply the configuration or it couldn't be written to declarative configuration outputs.
+func (c *KongClient) UpdateRetryNeeded() bool {
+	c.lock.RLock()
+	outputsFailed := c.declarativeConfigOutputsFailed
+	c.lock.RUnlock()
+	if outputsFailed {
+		return true
+	}
+	tracker, ok := c.clientsProvider.(clients.LaggingGatewayClientsTracker)
+	return ok && len(tracker.LaggingGatewayClients()) > 0
 }
 
 // ObjectExists indicates whether or not any version of the provided object is already present in the proxy.
@@ -385,6 +476,8 @@ func (c *KongClient) DBMode() string {
 // Kubernetes state into Kong objects and state, and then ships the
 // resulting configuration to the data-plane (Kong Admin API).
 func (c *KongClient) Update(ctx context.Context) error {
+	c.updateLock.Lock()
+	defer c.updateLock.Unlock()
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -402,8 +495,22 @@ func (c *KongClient) Update(ctx context.Context) error {
 		}
 	}
 
+	// Changes of the cache made from now on are going to be pushed with the next update.
+	changedSince := c.takePendingChange()
+	triggers, trackingChanges := c.takeChangedObjects()
+	if trackingChanges {
+		ctx = audit.WithTriggers(ctx, triggers)
+	}
+
+	// Gateways discovered since the last update may run a different version, so the configuration is built
+	// for capabilities all of them have.
+	c.detectVersionSkew(ctx)
+
 	c.logger.V(util.DebugLevel).Info("parsing kubernetes objects into data-plane configuration")
-	parsingResult := c.kongConfigBuilder.BuildKongConfig()
+	parsingResult := c.kongConfigBuilder.BuildKongConfig(ctx)
+	c.recordConfigComposition(parsingResult)
+	// Failing objects are reported once all pushes of this update (including fallback ones) are done.
+	defer c.reportFailingObjects(parsingResult.TranslationFailures)
 	if failuresCount := len(parsingResult.TranslationFailures); failuresCount > 0 {
 		c.prometheusMetrics.RecordTranslationFailure()
 		c.prometheusMetrics.RecordTranslationBrokenResources(failuresCount)
@@ -415,7 +522,14 @@ func (c *KongClient) Update(ctx context.Context) error {
 		c.logger.V(util.DebugLevel).Info("successfully built data-plane configuration")
 	}
 
-	shas, gatewaysSyncErr := c.sendOutToGatewayClients(ctx, parsingResult.KongState, c.kongConfig)
+	// In dry-run mode the configuration is never sent, so there's no config status to update nor objects
+	// configured in the data-plane to report.
+	if c.kongConfig.DryRun {
+		c.dryRunUpdate(ctx, parsingResult.KongState, c.kongConfig)
+		return nil
+	}
+
+	shas, laggingGatewayClients, gatewaysSyncErr := c.sendOutToGatewayClients(ctx, parsingResult.KongState, c.kongConfig)
 	konnectSyncErr := c.maybeSendOutToKonnectClient(ctx, parsingResult.KongState, c.kongConfig)
 
 	// Taking into account the results of syncing configuration with Gateways and Konnect, and potential translation
@@ -430,8 +544,12 @@ func (c *KongClient) Update(ctx context.Context) error {
 
 	// In case of a failure in syncing configuration with Gateways, propagate the error.
 	if gatewaysSyncErr != nil {
+		c.restorePendingChange(changedSince)
+		c.restoreChangedObjects(triggers)
 		if state, found := c.kongConfigFetcher.LastValidConfig(); found {
-			_, fallbackSyncErr := c.sendOutToGatewayClients(ctx, state, c.kongConfig)
+			// Gateways that applied the configuration are left untouched, the last valid configuration is pushed
+			// only to the lagging ones.
+			fallbackSyncErr := c.sendOutFallbackToGatewayClients(ctx, state, c.kongConfig, laggingGatewayClients)
 			if fallbackSyncErr != nil {
 				return errors.Join(gatewaysSyncErr, fallbackSyncErr)
 			}
@@ -440,6 +558,11 @@ func (c *KongClient) Update(ctx context.Context) error {
 		return gatewaysSyncErr
 	}
 
+	if !changedSince.IsZero() {
+		c.prometheusMetrics.RecordChangeToPush(time.Since(changedSince))
+	}
+	c.recordObjectsApplied(parsingResult)
+
 	// report on configured Kubernetes objects if enabled
 	if c.AreKubernetesObjectReportsEnabled() {
 		// if the configuration SHAs that have just been pushed are different than
@@ -455,18 +578,142 @@ func (c *KongClient) Update(ctx context.Context) error {
 	return nil
 }
 
+// recordConfigComposition records metrics describing the translated configuration and the store it was
+// translated from.
+func (c *KongClient) recordConfigComposition(result parser.KongConfigBuildingResult) {
+	for phase, d := range result.PhaseDurations {
+		c.prometheusMetrics.RecordTranslationPhase(string(phase), d)
+	}
+	c.prometheusMetrics.RecordStoreObjects(c.cache.ObjectCounts())
+
+	entityCounts := result.KongState.CountEntities()
+	counts := make([]metrics.EntityCount, 0, len(entityCounts))
+	for key, count := range entityCounts {
+		counts = append(counts, metrics.EntityCount{
+			EntityType: key.EntityType,
+			Namespace:  key.Namespace,
+			SourceKind: key.SourceKind,
+			Count:      count,
+		})
+	}
+	c.prometheusMetrics.RecordConfigEntities(counts)
+}
+
+// recordObjectsApplied records that the configuration generated from the configured Kubernetes objects was
+// successfully pushed. Objects failing translation keep the time they were last applied at.
+func (c *KongClient) recordObjectsApplied(result parser.KongConfigBuildingResult) {
+	applied := make([]metrics.ObjectRef, 0, len(result.ConfiguredKubernetesObjects))
+	for _, obj := range result.ConfiguredKubernetesObjects {
+		applied = append(applied, metrics.ObjectRef(audit.NewObjectRef(obj)))
+	}
+	var retained []metrics.ObjectRef
+	for _, f := range result.TranslationFailures {
+		for _, obj := range f.CausingObjects() {
+			retained = append(retained, metrics.ObjectRef(audit.NewObjectRef(obj)))
+		}
+	}
+	c.prometheusMetrics.RecordObjectsApplied(applied, retained)
+}
+
+// reportFailingObjects reports Kubernetes objects failing translation with translationFailures or failing push since
+// the last report in metrics and diagnostics.
+func (c *KongClient) reportFailingObjects(translationFailures []failures.ResourceFailure) {
+	failing := c.failingObjects.report(translationFailures, time.Now())
+	c.prometheusMetrics.RecordTranslationFailingObjects(countFailingObjects(failing, failureStageTranslation))
+	c.prometheusMetrics.RecordPushFailingObjects(countFailingObjects(failing, failureStagePush))
+
+	if c.diagnostic.FailingObjects != nil {
+		select {
+		case c.diagnostic.FailingObjects <- failing:
+		default:
+			c.logger.Error(nil, "failing objects diagnostic buffer full, dropping diagnostic")
+		}
+	}
+}
+
+// gatewaySyncResult is the result of sending configuration to a single gateway client.
+type gatewaySyncResult struct {
+	client *adminapi.Client
+	sha    string
+	err    error
+	// skipped tells that the configuration was not sent to the gateway client (e.g. a staged rollout was halted),
+	// so it still runs the previous one. err explains why.
+	skipped bool
+}
+
 // sendOutToGatewayClients will generate deck content (config) from the provided kong state
-// and send it out to each of the configured gateway clients.
+// and send it out to each of the configured gateway clients. The content is generated once
+// and shared by all the gateway clients. The sync is considered successful when the quorum of
+// gateway clients (see sendconfig.Config.GatewaySyncQuorum) applied the configuration. Gateway
+// clients that failed to apply it are marked as lagging and returned, along with the SHAs
+// of the previous sync. The configuration is also written to declarative configuration outputs,
+// failing which doesn't fail the sync, but causes the update to be retried.
 func (c *KongClient) sendOutToGatewayClients(
 	ctx context.Context, s *kongstate.KongState, config sendconfig.Config,
-) ([]string, error) {
+) (_ []string, _ []*adminapi.Client, err error) {
+	ctx, span := tracing.StartSpan(ctx, "KongClient.SendOutToGatewayClients")
+	defer func() { tracing.EndSpan(span, err) }()
+
 	gatewayClients := c.clientsProvider.GatewayClients()
 	c.logger.V(util.DebugLevel).Info("sending configuration to gateway clients", "count", len(gatewayClients))
-	shas, err := iter.MapErr(gatewayClients, func(client **adminapi.Client) (string, error) {
-		return c.sendToClient(ctx, *client, s, config)
-	})
+
+	var (
+		generated generatedContent
+		results   []gatewaySyncResult
+	)
+	if c.canaryRolloutEnabled(gatewayClients) {
+		generated, results, err = c.rolloutToGatewayClients(ctx, s, config, gatewayClients)
+	} else {
+		generated, results, err = c.sendToGatewayClients(ctx, s, config, gatewayClients)
+	}
 	if err != nil {
-		return nil, err
+		return nil, gatewayClients, err
+	}
+	c.reportGatewaySyncResults(results)
+
+	var (
+		shas          []string
+		failedClients []*adminapi.Client
+		skipped       int
+		errs          error
+	)
+	for _, r := range results {
+		switch {
+		case r.skipped:
+			skipped++
+		case r.err != nil:
+			failedClients = append(failedClients, r.client)
+			errs = errors.Join(errs, r.err)
+		default:
+			shas = append(shas, r.sha)
+		}
+	}
+	// Skipped gateway clients don't count against the quorum, but they didn't get the configuration, so the sync
+	// fails anyway. Only the failed ones get the last valid configuration pushed.
+	quorum := config.GatewaySyncQuorum(len(gatewayClients) - skipped)
+	// The configuration is recorded in diagnostics once for all the gateways, as applied when a quorum of them
+	// applied it.
+	generated.sendDiagnostic(skipped > 0 || len(shas) < quorum)
+	if skipped > 0 {
+		return nil, failedClients, fmt.Errorf("configuration not sent to %d of %d gateways: %w",
+			skipped, len(gatewayClients), errors.Join(errRolloutHalted, errs))
+	}
+	if len(shas) < quorum {
+		return nil, failedClients, fmt.Errorf("configuration applied to %d of %d gateways while %d are required: %w",
+			len(shas), len(gatewayClients), quorum, errs)
+	} else if errs != nil {
+		c.logger.Error(errs, "configuration applied to a quorum of gateways, lagging ones will be retried with the next sync",
+			"applied", len(shas), "lagging", len(failedClients), "quorum", quorum)
+	}
+
+	// Declarative configuration outputs don't take part in the quorum, so failing to write to them doesn't
+	// discard the configuration applied to gateways. They're retried with the next update.
+	outputsSHAs, outputsErr := c.writeToDeclarativeConfigOutputs(ctx, s, config)
+	c.declarativeConfigOutputsFailed = outputsErr != nil
+	if outputsErr != nil {
+		c.logger.Error(outputsErr, "failed to write configuration to declarative configuration outputs, will retry")
+	} else {
+		shas = append(shas, outputsSHAs...)
 	}
 	previousSHAs := c.SHAs
 
@@ -475,19 +722,210 @@ func (c *KongClient) sendOutToGatewayClients(
 
 	c.kongConfigFetcher.StoreLastValidConfig(s)
 
-	return previousSHAs, nil
+	return previousSHAs, failedClients, nil
+}
+
+// sendOutFallbackToGatewayClients sends the provided fallback kong state (e.g. the last valid configuration)
+// to the provided gateway clients. It returns an error if any of them failed to apply it.
+func (c *KongClient) sendOutFallbackToGatewayClients(
+	ctx context.Context, s *kongstate.KongState, config sendconfig.Config, gatewayClients []*adminapi.Client,
+) error {
+	generated, results, err := c.sendToGatewayClients(ctx, s, config, gatewayClients)
+	if err != nil {
+		return err
+	}
+	var errs error
+	for _, r := range results {
+		errs = errors.Join(errs, r.err)
+	}
+	generated.sendDiagnostic(errs != nil)
+	return errs
+}
+
+// sendToGatewayClients generates deck content from the provided kong state once and sends it to each
+// of the provided gateway clients, returning the generated content and results for each of them. An error
+// is returned only when the content couldn't be generated.
+func (c *KongClient) sendToGatewayClients(
+	ctx context.Context, s *kongstate.KongState, config sendconfig.Config, gatewayClients []*adminapi.Client,
+) (generatedContent, []gatewaySyncResult, error) {
+	if len(gatewayClients) == 0 {
+		return noopGeneratedContent(), nil, nil
+	}
+	generated, err := c.generateGatewayContent(ctx, s, config, gatewayClients)
+	if err != nil {
+		return generatedContent{}, nil, err
+	}
+	return generated, c.sendGeneratedToGatewayClients(ctx, config, generated, gatewayClients), nil
+}
+
+// generateGatewayContent generates deck content from the provided kong state to be shared by all the provided
+// gateway clients.
+func (c *KongClient) generateGatewayContent(
+	ctx context.Context, s *kongstate.KongState, config sendconfig.Config, gatewayClients []*adminapi.Client,
+) (generatedContent, error) {
+	generated, err := c.generateSharedGatewayContent(ctx, s, config, gatewayClients)
+	if err != nil {
+		return generatedContent{}, err
+	}
+	c.storePushedContent(config, generated.content)
+	return generated, nil
+}
+
+// generateSharedGatewayContent generates deck content from the provided kong state to be shared by all the
+// provided gateway clients, without storing it as pushed.
+func (c *KongClient) generateSharedGatewayContent(
+	ctx context.Context, s *kongstate.KongState, config sendconfig.Config, gatewayClients []*adminapi.Client,
+) (generatedContent, error) {
+	// Plugins' defaults are filled in from schemas of the gateway the configuration is generated for, so that
+	// it's accepted by all the gateways even if they run different versions.
+	schemasClient := c.pluginSchemasClient(gatewayClients)
+	return c.generateContent(ctx, s, deckGenParamsForClient(schemasClient, config), config.InMemory, metrics.GenerationTargetGateways)
+}
+
+// sendGeneratedToGatewayClients sends already generated content to each of the provided gateway clients
+// concurrently, returning results for each of them.
+func (c *KongClient) sendGeneratedToGatewayClients(
+	ctx context.Context, config sendconfig.Config, generated generatedContent, gatewayClients []*adminapi.Client,
+) []gatewaySyncResult {
+	return iter.Map(gatewayClients, func(client **adminapi.Client) gatewaySyncResult {
+		previousSHA := (*client).LastConfigSHA()
+		sha, err := c.sendToClient(ctx, *client, config, generated)
+		if err == nil && c.convergenceCheckEnabled(config) {
+			err = c.verifyConvergence(ctx, *client, config, generated, sha != string(previousSHA))
+		}
+		return gatewaySyncResult{client: *client, sha: sha, err: err}
+	})
+}
+
+// reportGatewaySyncResults marks gateway clients that failed to apply the configuration as lagging, so that
+// they can be retried, and reports results of the sync in metrics and diagnostics. Skipped gateway clients are
+// not lagging, as they still run the previous configuration.
+func (c *KongClient) reportGatewaySyncResults(results []gatewaySyncResult) {
+	var (
+		laggingURLs []string
+		inSync      = make(map[string]bool, len(results))
+		services    = make(map[string]string, len(results))
+		circuitOpen = make(map[string]bool, len(results))
+		statuses    = make([]util.GatewaySyncStatus, 0, len(results))
+		now         = time.Now()
+	)
+	for _, r := range results {
+		url := r.client.BaseRootURL()
+		inSync[url] = r.err == nil
+		var service string
+		if svc, ok := r.client.ServiceReference(); ok {
+			service = svc.String()
+		}
+		services[url] = service
+		status := util.GatewaySyncStatus{
+			URL:        url,
+			Service:    service,
+			InSync:     r.err == nil,
+			Skipped:    r.skipped,
+			ConfigHash: hex.EncodeToString(r.client.LastConfigSHA()),
+			Time:       now,
+		}
+		if r.err != nil {
+			if !r.skipped {
+				laggingURLs = append(laggingURLs, url)
+			}
+			status.Error = r.err.Error()
+		}
+		backoffStatus := r.client.BackoffStatus()
+		circuitOpen[url] = backoffStatus.Open
+		status.CircuitOpen = backoffStatus.Open
+		status.CircuitOpenReason = backoffStatus.Reason
+		statuses = append(statuses, status)
+	}
+
+	if tracker, ok := c.clientsProvider.(clients.LaggingGatewayClientsTracker); ok {
+		tracker.MarkLaggingGatewayClients(laggingURLs...)
+	}
+	c.prometheusMetrics.RecordGatewaysConfigInSync(inSync)
+	c.prometheusMetrics.RecordGatewaysInfo(services)
+	c.prometheusMetrics.RecordGatewaysCircuitBreakerOpen(circuitOpen)
+	if c.diagnostic.GatewaySyncStatuses != nil {
+		select {
+		case c.diagnostic.GatewaySyncStatuses <- statuses:
+		default:
+			c.logger.Error(nil, "gateway sync status diagnostic buffer full, dropping diagnostic")
+		}
+	}
+}
+
+// writeToDeclarativeConfigOutputs generates DB-less configuration from the provided kong state and writes it
+// to each of the configured declarative configuration outputs. The configuration is generated once and shared
+// by all the outputs.
+func (c *KongClient) writeToDeclarativeConfigOutputs(
+	ctx context.Context, s *kongstate.KongState, config sendconfig.Config,
+) ([]string, error) {
+	if len(c.declarativeConfigOutputs) == 0 {
+		return nil, nil
+	}
+	c.logger.V(util.DebugLevel).Info("writing configuration to declarative configuration outputs", "count", len(c.declarativeConfigOutputs))
+
+	// There's no gateway to fetch plugin schemas from, so plugins' defaults are not filled in. Kong Gateway
+	// fills them in when loading the configuration anyway.
+	deckGenParams := deckgen.GenerateDeckContentParams{
+		SelectorTags:                    config.FilterTags,
+		ExpressionRoutes:                config.ExpressionRoutes,
+		PluginSchemas:                   deckgen.EmptyPluginSchemaStore{},
+		AppendStubEntityWhenConfigEmpty: true,
+	}
+	generated, err := c.generateContent(ctx, s, deckGenParams, true, metrics.GenerationTargetDeclarativeConfigOutputs)
+	if err != nil {
+		return nil, err
+	}
+	shas, err := iter.MapErr(c.declarativeConfigOutputs, func(output *sendconfig.DeclarativeConfigOutput) (string, error) {
+		return c.writeToDeclarativeConfigOutput(ctx, *output, config, generated)
+	})
+	generated.sendDiagnostic(err != nil)
+	return shas, err
+}
+
+func (c *KongClient) writeToDeclarativeConfigOutput(
+	ctx context.Context,
+	output sendconfig.DeclarativeConfigOutput,
+	config sendconfig.Config,
+	generated generatedContent,
+) (string, error) {
+	logger := c.logger.WithValues("output", output.Target())
+
+	timedCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	newConfigSHA, err := sendconfig.PerformDeclarativeConfigOutputUpdate(
+		timedCtx,
+		logger,
+		output,
+		config,
+		generated.content,
+		c.prometheusMetrics,
+	)
+	if err != nil {
+		return "", fmt.Errorf("writing configuration to %s failed: %w", output.Target(), err)
+	}
+
+	return string(newConfigSHA), nil
 }
 
 // maybeSendOutToKonnectClient sends out the configuration to Konnect when KonnectClient is provided.
 // It's a noop when Konnect integration is not enabled.
-func (c *KongClient) maybeSendOutToKonnectClient(ctx context.Context, s *kongstate.KongState, config sendconfig.Config) error {
+func (c *KongClient) maybeSendOutToKonnectClient(ctx context.Context, s *kongstate.KongState, config sendconfig.Config) (err error) {
 	konnectClient := c.clientsProvider.KonnectClient()
 	// There's no KonnectClient configured, that's totally fine.
 	if konnectClient == nil {
 		return nil
 	}
-
-	if _, err := c.sendToClient(ctx, konnectClient, s, config); err != nil {
+	ctx, span := tracing.StartSpan(ctx, "KongClient.SendOutToKonnect")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var generated generatedContent
+	generated, err = c.generateContent(ctx, s, deckGenParamsForClient(konnectClient, config), false, metrics.GenerationTargetKonnect)
+	if err == nil {
+		_, err = c.sendToClient(ctx, konnectClient, config, generated)
+		generated.sendDiagnostic(err != nil)
+	}
+	if err != nil {
 		// In case of an error, we only log it since we don't want the Konnect to affect the basic functionality
 		// of the controller.
 
@@ -502,23 +940,69 @@ func (c *KongClient) maybeSendOutToKonnectClient(ctx context.Context, s *kongsta
 	return nil
 }
 
+// generatedContent is the configuration generated from a kong state once per sync and shared by all clients
+// it's sent to.
+type generatedContent struct {
+	content        sendconfig.ContentWithHash
+	target         string
+	sendConfigDump sendConfigDumpFn
+}
+
+// noopGeneratedContent returns empty content that's never shipped to the diagnostic server.
+func noopGeneratedContent() generatedContent {
+	return generatedContent{sendConfigDump: func(util.ConfigDump) {}}
+}
+
+// sendDiagnostic ships the content to the diagnostic server as applied or, when failed is true, failed to be applied.
+// It's meant to be called once per sync with the aggregated result of sending the content to all its clients.
+func (g generatedContent) sendDiagnostic(failed bool) {
+	g.sendConfigDump(util.ConfigDump{Hash: g.content.Hash, Target: g.target, Failed: failed})
+}
+
+// sendDryRunDiagnostic ships the content to the diagnostic server as generated in dry-run mode, i.e. never applied.
+func (g generatedContent) sendDryRunDiagnostic() {
+	g.sendConfigDump(util.ConfigDump{Hash: g.content.Hash, Target: g.target, DryRun: true})
+}
+
+// generateContent generates deck content from the provided kong state along with its hash and, when dbless is
+// true, its serialized DB-less form. Time it takes is recorded as generation time for the target.
+func (c *KongClient) generateContent(
+	ctx context.Context,
+	s *kongstate.KongState,
+	deckGenParams deckgen.GenerateDeckContentParams,
+	dbless bool,
+	target string,
+) (_ generatedContent, err error) {
+	ctx, span := tracing.StartSpan(ctx, "KongClient.GenerateContent", tracing.AttributeKeyGenerationTarget.String(target))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	logger := c.logger.WithValues("target", target)
+
+	timeStart := time.Now()
+	targetContent := deckgen.ToDeckContent(ctx, logger, s, deckGenParams)
+	sendConfigDump := prepareSendConfigDumpFn(ctx, logger, c.diagnostic, s, targetContent, deckGenParams)
+	content, err := sendconfig.PrepareContent(targetContent, dbless)
+	if err != nil {
+		return generatedContent{}, fmt.Errorf("generating configuration for %s failed: %w", target, err)
+	}
+	c.prometheusMetrics.RecordConfigGeneration(target, time.Since(timeStart))
+	c.prometheusMetrics.RecordConfigSize(target, content.Size)
+
+	return generatedContent{
+		content:        content,
+		target:         target,
+		sendConfigDump: sendConfigDump,
+	}, nil
+}
+
 func (c *KongClient) sendToClient(
 	ctx context.Context,
 	client sendconfig.AdminAPIClient,
-	s *kongstate.KongState,
 	config sendconfig.Config,
+	generated generatedContent,
 ) (string, error) {
 	logger := c.logger.WithValues("url", client.AdminAPIClient().BaseRootURL())
 
-	deckGenParams := deckgen.GenerateDeckContentParams{
-		SelectorTags:                    config.FilterTags,
-		ExpressionRoutes:                config.ExpressionRoutes,
-		PluginSchemas:                   client.PluginSchemaStore(),
-		AppendStubEntityWhenConfigEmpty: !client.IsKonnect() && config.InMemory,
-	}
-	targetContent := deckgen.ToDeckContent(ctx, logger, s, deckGenParams)
-	sendDiagnostic := prepareSendDiagnosticFn(ctx, logger, c.diagnostic, s, targetContent, deckGenParams)
-
 	// apply the configuration update in Kong
 	timedCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
 	defer cancel()
@@ -527,18 +1011,26 @@ func (c *KongClient) sendToClient(
 		logger,
 		client,
 		config,
-		targetContent,
+		generated.content,
 		c.prometheusMetrics,
 		c.updateStrategyResolver,
 		c.configChangeDetector,
 	)
 
 	c.recordResourceFailureEvents(entityErrors, KongConfigurationApplyFailedEventReason)
-	// Only record events on applying configuration to Kong gateway here.
-	if !client.IsKonnect() {
+	// Updates skipped due to an open circuit breaker are not attempted, so objects that failed the most recent
+	// attempt are still failing.
+	skipped := errors.As(err, &sendconfig.UpdateSkippedDueToBackoffStrategyError{})
+	if skipped {
+		c.failingObjects.keepPushFailures(client.BaseRootURL())
+	} else {
+		c.failingObjects.addPushFailures(client.BaseRootURL(), entityErrors)
+	}
+	// Only record events on applying configuration to Kong gateway here. Updates skipped due to an open circuit
+	// breaker are not attempted, so there's nothing to record.
+	if !client.IsKonnect() && !skipped {
 		c.recordApplyConfigurationEvents(err, client.BaseRootURL())
 	}
-	sendDiagnostic(err != nil)
 
 	if err != nil {
 		if expired, ok := timedCtx.Deadline(); ok && time.Now().After(expired) {
@@ -553,6 +1045,79 @@ func (c *KongClient) sendToClient(
 	return string(newConfigSHA), nil
 }
 
+// dryRunUpdate generates the configuration for the gateway clients once, the same way it's done when sending it,
+// but instead of sending it, ships it to the diagnostic server marked as a dry-run one and reports its difference
+// with the configuration currently loaded by each of the gateways. Konnect is skipped entirely.
+func (c *KongClient) dryRunUpdate(ctx context.Context, s *kongstate.KongState, config sendconfig.Config) {
+	gatewayClients := c.clientsProvider.GatewayClients()
+	if len(gatewayClients) == 0 {
+		return
+	}
+	c.logger.V(util.DebugLevel).Info("dry run: generating configuration without sending it to gateway clients", "count", len(gatewayClients))
+
+	generated, err := c.generateSharedGatewayContent(ctx, s, config, gatewayClients)
+	if err != nil {
+		c.logger.Error(err, "dry run: failed to generate configuration")
+		return
+	}
+	generated.sendDryRunDiagnostic()
+
+	iter.ForEach(gatewayClients, func(client **adminapi.Client) {
+		c.dryRunUpdateClient(ctx, *client, config, generated.content)
+	})
+}
+
+func (c *KongClient) dryRunUpdateClient(
+	ctx context.Context,
+	client *adminapi.Client,
+	config sendconfig.Config,
+	target sendconfig.ContentWithHash,
+) {
+	logger := c.logger.WithValues("url", client.BaseRootURL())
+
+	timedCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	currentState, err := c.kongConfigFetcher.FetchCurrentConfig(timedCtx, logger, client)
+	if err != nil {
+		logger.Error(err, "dry run: failed to fetch current configuration from gateway")
+		return
+	}
+	currentContent := deckgen.ToDeckContent(ctx, logger, currentState, deckGenParamsForClient(client, config))
+	added, removed, modified, err := diagnostics.DiffConfigs(*currentContent, *target.Content)
+	if err != nil {
+		logger.Error(err, "dry run: failed to compare configuration with the one loaded by gateway")
+		return
+	}
+
+	c.prometheusMetrics.RecordDryRunConfigDiff(client.BaseRootURL(), len(added), len(removed), len(modified))
+	logger.Info("dry run: configuration generated but not sent to gateway",
+		"hash", fmt.Sprintf("%x", target.Hash),
+		"added", len(added),
+		"removed", len(removed),
+		"modified", len(modified),
+	)
+	for change, entities := range map[string][]diagnostics.EntityDiff{
+		metrics.ChangeAdded:    added,
+		metrics.ChangeRemoved:  removed,
+		metrics.ChangeModified: modified,
+	} {
+		for _, e := range entities {
+			logger.V(util.DebugLevel).Info("dry run: entity differs from the one loaded by gateway",
+				"change", change, "kind", e.Kind, "key", e.Key, "changedFields", e.ChangedFields)
+		}
+	}
+}
+
+// deckGenParamsForClient returns parameters to generate deck content with for the client.
+func deckGenParamsForClient(client sendconfig.AdminAPIClient, config sendconfig.Config) deckgen.GenerateDeckContentParams {
+	return deckgen.GenerateDeckContentParams{
+		SelectorTags:                    config.FilterTags,
+		ExpressionRoutes:                config.ExpressionRoutes,
+		PluginSchemas:                   client.PluginSchemaStore(),
+		AppendStubEntityWhenConfigEmpty: !client.IsKonnect() && config.InMemory,
+	}
+}
+
 // SetConfigStatusNotifier sets a notifier which notifies subscribers about configuration sending results.
 // Currently it is used for uploading the node status to konnect control plane.
 func (c *KongClient) SetConfigStatusNotifier(n clients.ConfigStatusNotifier) {
@@ -562,29 +1127,105 @@ func (c *KongClient) SetConfigStatusNotifier(n clients.ConfigStatusNotifier) {
 	c.configStatusNotifier = n
 }
 
+// SetDeclarativeConfigOutputs sets outputs (e.g. files) DB-less configuration is written to in addition to
+// sending it to gateway clients.
+func (c *KongClient) SetDeclarativeConfigOutputs(outputs ...sendconfig.DeclarativeConfigOutput) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.declarativeConfigOutputs = outputs
+}
+
 // -----------------------------------------------------------------------------
 // Dataplane Client - Kong - Private
 // -----------------------------------------------------------------------------
 
-type sendDiagnosticFn func(failed bool)
+// sendConfigDumpFn ships generated configuration to the diagnostic server. The dump's Config is filled in by the
+// function, the rest of the dump describes what happened to the configuration.
+type sendConfigDumpFn func(dump util.ConfigDump)
+
+// notifyChange records the time of the change of obj in the cache, unless an older change is pending, and
+// notifies the change notifier if set.
+func (c *KongClient) notifyChange(obj client.Object) {
+	c.pendingChangeLock.Lock()
+	defer c.pendingChangeLock.Unlock()
 
-// prepareSendDiagnosticFn generates sendDiagnosticFn.
+	if c.pendingChangeSince.IsZero() {
+		c.pendingChangeSince = time.Now()
+	}
+	if c.changedObjects != nil {
+		c.changedObjects.Add(obj)
+	}
+	if c.changeNotifier != nil {
+		c.changeNotifier.NotifyChange()
+	}
+}
+
+// takePendingChange returns the time of the oldest pending change of the cache (zero if there's none) and
+// marks the changes as no longer pending.
+func (c *KongClient) takePendingChange() time.Time {
+	c.pendingChangeLock.Lock()
+	defer c.pendingChangeLock.Unlock()
+
+	since := c.pendingChangeSince
+	c.pendingChangeSince = time.Time{}
+	return since
+}
+
+// restorePendingChange marks changes taken with takePendingChange as pending again, e.g. because they failed
+// to be pushed.
+func (c *KongClient) restorePendingChange(since time.Time) {
+	if since.IsZero() {
+		return
+	}
+
+	c.pendingChangeLock.Lock()
+	defer c.pendingChangeLock.Unlock()
+	if c.pendingChangeSince.IsZero() || since.Before(c.pendingChangeSince) {
+		c.pendingChangeSince = since
+	}
+}
+
+// takeChangedObjects returns objects changed since they were last taken and forgets them. It returns false when
+// tracking of changed objects is not enabled.
+func (c *KongClient) takeChangedObjects() (audit.Triggers, bool) {
+	c.pendingChangeLock.Lock()
+	defer c.pendingChangeLock.Unlock()
+
+	if c.changedObjects == nil {
+		return audit.Triggers{}, false
+	}
+	return c.changedObjects.Take(), true
+}
+
+// restoreChangedObjects records again objects taken with takeChangedObjects, e.g. because their changes failed
+// to be pushed.
+func (c *KongClient) restoreChangedObjects(triggers audit.Triggers) {
+	c.pendingChangeLock.Lock()
+	defer c.pendingChangeLock.Unlock()
+
+	if c.changedObjects != nil {
+		c.changedObjects.Restore(triggers)
+	}
+}
+
+// prepareSendConfigDumpFn generates sendConfigDumpFn.
 // Diagnostics are sent only when provided diagnostic config (--dump-config) is set.
-func prepareSendDiagnosticFn(
+func prepareSendConfigDumpFn(
 	ctx context.Context,
 	logger logr.Logger,
 	diagnosticConfig util.ConfigDumpDiagnostic,
 	targetState *kongstate.KongState,
 	targetContent *file.Content,
 	deckGenParams deckgen.GenerateDeckContentParams,
-) sendDiagnosticFn {
+) sendConfigDumpFn {
 	if diagnosticConfig == (util.ConfigDumpDiagnostic{}) {
 		// noop, diagnostics won't be sent
-		return func(bool) {}
+		return func(util.ConfigDump) {}
 	}
 
 	var config *file.Content
-	if diagnosticConfig.DumpsIncludeSensitive {
+	if !diagnosticConfig.DumpsIncludeSensitive {
 		redactedConfig := deckgen.ToDeckContent(ctx,
 			logger,
 			targetState.SanitizedCopy(),
@@ -595,7 +1236,8 @@ func prepareSendDiagnosticFn(
 		config = targetContent
 	}
 
-	return func(failed bool) {
+	return func(dump util.ConfigDump) {
+		dump.Config = *config
 		// Given that we can send multiple configs to this channel and
 		// the fact that the API that exposes that can only expose 1 config
 		// at a time it means that users utilizing the diagnostics API
@@ -603,7 +1245,7 @@ func prepareSendDiagnosticFn(
 		// or successfully send configs might be covered by those send
 		// later on but we're OK with this limitation of said API.
 		select {
-		case diagnosticConfig.Configs <- util.ConfigDump{Failed: failed, Config: *config}:
+		case diagnosticConfig.Configs <- dump:
 			logger.V(util.DebugLevel).Info("shipping config to diagnostic server")
 		default:
 			logger.Error(nil, "config diagnostic buffer full, dropping diagnostic config")
@@ -674,12 +1316,6 @@ func (c *KongClient) recordResourceFailureEvents(resourceFailures []failures.Res
 
 // recordApplyConfigurationEvents records event attached to KIC pod after KIC applied Kong configuration.
 func (c *KongClient) recordApplyConfigurationEvents(err error, rootURL string) {
-	podNN, ok := c.controllerPodReference.Get()
-	if !ok {
-		// Can't record an event without a controller pod reference to attach to.
-		return
-	}
-
 	eventType := corev1.EventTypeNormal
 	reason := KongConfigurationApplySucceededEventReason
 	message := fmt.Sprintf("successfully applied Kong configuration to %s", rootURL)
@@ -689,6 +1325,16 @@ func (c *KongClient) recordApplyConfigurationEvents(err error, rootURL string) {
 		reason = KongConfigurationApplyFailedEventReason
 		message = fmt.Sprintf("failed to apply Kong configuration to %s: %v", rootURL, err)
 	}
+	c.recordControllerPodEvent(eventType, reason, message)
+}
+
+// recordControllerPodEvent records event attached to KIC pod.
+func (c *KongClient) recordControllerPodEvent(eventType, reason, message string) {
+	podNN, ok := c.controllerPodReference.Get()
+	if !ok {
+		// Can't record an event without a controller pod reference to attach to.
+		return
+	}
 
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -2,9 +2,12 @@ package dataplane
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -16,6 +19,7 @@ import (
 	"github.com/kong/deck/file"
 	"github.com/kong/deck/utils"
 	"github.com/kong/go-kong/kong"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,10 +28,12 @@ import (
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/clients"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/configfetcher"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
@@ -38,6 +44,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/clock"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/versions"
 	"github.com/kong/kubernetes-ingress-controller/v2/test/mocks"
 )
@@ -422,7 +429,7 @@ func newMockKongConfigBuilder() *mockKongConfigBuilder {
 	}
 }
 
-func (p *mockKongConfigBuilder) BuildKongConfig() parser.KongConfigBuildingResult {
+func (p *mockKongConfigBuilder) BuildKongConfig(context.Context) parser.KongConfigBuildingResult {
 	return parser.KongConfigBuildingResult{
 		KongState:           p.kongState,
 		TranslationFailures: p.translationFailuresToReturn,
@@ -746,6 +753,13 @@ func (cf *mockKongLastValidConfigFetcher) TryFetchingValidConfigFromGateways(con
 	return nil
 }
 
+func (cf *mockKongLastValidConfigFetcher) FetchCurrentConfig(context.Context, logr.Logger, *adminapi.Client) (*kongstate.KongState, error) {
+	if cf.kongRawState != nil {
+		return configfetcher.KongRawStateToKongState(cf.kongRawState), nil
+	}
+	return &kongstate.KongState{}, nil
+}
+
 func TestKongClientUpdate_FetchStoreAndPushLastValidConfig(t *testing.T) {
 	var (
 		ctx = context.Background()
@@ -875,3 +889,437 @@ func TestKongClientUpdate_FetchStoreAndPushLastValidConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestKongClientUpdate_DryRunDoesNotSendConfiguration(t *testing.T) {
+	var (
+		ctx               = context.Background()
+		testKonnectClient = mustSampleKonnectClient(t)
+
+		clientsProvider = mockGatewayClientsProvider{
+			gatewayClients: []*adminapi.Client{mustSampleGatewayClient(t), mustSampleGatewayClient(t)},
+			konnectClient:  testKonnectClient,
+		}
+
+		updateStrategyResolver = newMockUpdateStrategyResolver(t)
+		configChangeDetector   = mockConfigurationChangeDetector{hasConfigurationChanged: true}
+		configBuilder          = newMockKongConfigBuilder()
+		kongRawStateGetter     = &mockKongLastValidConfigFetcher{}
+		kongClient             = setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector, configBuilder, nil, kongRawStateGetter)
+		statusQueue            = newMockConfigStatusQueue()
+		diagnosticConfigs      = make(chan util.ConfigDump, 2)
+	)
+	kongClient.kongConfig.DryRun = true
+	kongClient.diagnostic = util.ConfigDumpDiagnostic{Configs: diagnosticConfigs}
+	kongClient.SetConfigStatusNotifier(statusQueue)
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{
+			{Service: kong.Service{Name: kong.String("service"), Host: kong.String("example.com")}},
+		},
+	}
+
+	require.NoError(t, kongClient.Update(ctx))
+
+	updateStrategyResolver.assertNoUpdateCalled()
+	require.Empty(t, statusQueue.Notifications(), "config status should not be notified in dry-run mode")
+	_, found := kongRawStateGetter.LastValidConfig()
+	require.False(t, found, "dry-run configuration should not be stored as the last valid one")
+
+	// The configuration is generated once for all the gateways and shipped marked as a dry-run one.
+	require.Len(t, diagnosticConfigs, 1)
+	dump := <-diagnosticConfigs
+	require.True(t, dump.DryRun)
+	require.False(t, dump.Failed)
+	require.Len(t, dump.Config.Services, 1)
+	require.Equal(t, "service", *dump.Config.Services[0].Name)
+}
+
+func TestKongClientUpdate_SendsSingleDiagnosticPerTarget(t *testing.T) {
+	var (
+		ctx                = context.Background()
+		testKonnectClient  = mustSampleKonnectClient(t)
+		testGatewayClients = []*adminapi.Client{
+			mustSampleGatewayClient(t),
+			mustSampleGatewayClient(t),
+			mustSampleGatewayClient(t),
+		}
+		clientsProvider = mockGatewayClientsProvider{
+			gatewayClients: testGatewayClients,
+			konnectClient:  testKonnectClient,
+		}
+
+		updateStrategyResolver = newMockUpdateStrategyResolver(t)
+		configChangeDetector   = mockConfigurationChangeDetector{hasConfigurationChanged: true}
+		configBuilder          = newMockKongConfigBuilder()
+		kongRawStateGetter     = &mockKongLastValidConfigFetcher{}
+		kongClient             = setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector, configBuilder, nil, kongRawStateGetter)
+		diagnosticConfigs      = make(chan util.ConfigDump, 10)
+	)
+	kongClient.kongConfig.GatewaySyncQuorumPercent = 50
+	kongClient.diagnostic = util.ConfigDumpDiagnostic{Configs: diagnosticConfigs}
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{
+			{Service: kong.Service{Name: kong.String("service"), Host: kong.String("example.com")}},
+		},
+	}
+	updateStrategyResolver.returnErrorOnUpdate(testGatewayClients[0].BaseRootURL(), true)
+
+	require.NoError(t, kongClient.Update(ctx))
+
+	require.Len(t, diagnosticConfigs, 2, "configuration should be dumped once per target")
+	gatewaysDump := <-diagnosticConfigs
+	require.Equal(t, metrics.GenerationTargetGateways, gatewaysDump.Target)
+	require.False(t, gatewaysDump.Failed, "configuration applied by a quorum of gateways should be dumped as applied")
+	gatewaysContent, ok := updateStrategyResolver.lastUpdatedContentForURL(testGatewayClients[1].BaseRootURL())
+	require.True(t, ok)
+	require.Equal(t, gatewaysContent.Hash, gatewaysDump.Hash, "hash of the sent content should be dumped")
+
+	konnectDump := <-diagnosticConfigs
+	require.Equal(t, metrics.GenerationTargetKonnect, konnectDump.Target)
+	require.False(t, konnectDump.Failed)
+	require.NotEmpty(t, konnectDump.Hash)
+}
+
+func TestKongClientUpdate_WritesToDeclarativeConfigOutputs(t *testing.T) {
+	var (
+		ctx             = context.Background()
+		outputPath      = filepath.Join(t.TempDir(), "kong.json")
+		clientsProvider = mockGatewayClientsProvider{}
+
+		updateStrategyResolver = newMockUpdateStrategyResolver(t)
+		configChangeDetector   = mockConfigurationChangeDetector{hasConfigurationChanged: true}
+		configBuilder          = newMockKongConfigBuilder()
+		kongRawStateGetter     = &mockKongLastValidConfigFetcher{}
+		kongClient             = setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector, configBuilder, nil, kongRawStateGetter)
+	)
+	kongClient.SetDeclarativeConfigOutputs(
+		sendconfig.NewUpdateStrategyFile(outputPath, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard()),
+	)
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{
+			{Service: kong.Service{Name: kong.String("service"), Host: kong.String("example.com")}},
+		},
+	}
+
+	require.NoError(t, kongClient.Update(ctx))
+	updateStrategyResolver.assertNoUpdateCalled()
+	require.Len(t, kongClient.SHAs, 1, "hash of the configuration written to the output should be recorded")
+	_, found := kongRawStateGetter.LastValidConfig()
+	require.True(t, found, "configuration written to the output should be stored as the last valid one")
+
+	b, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	var written sendconfig.DBLessConfig
+	require.NoError(t, json.Unmarshal(b, &written))
+	require.Len(t, written.Services, 1)
+	require.Equal(t, "service", *written.Services[0].Name)
+
+	t.Log("removing the output file and updating with the same configuration shouldn't write it again")
+	require.NoError(t, os.Remove(outputPath))
+	require.NoError(t, kongClient.Update(ctx))
+	require.NoFileExists(t, outputPath)
+}
+
+func TestKongClientUpdate_DeclarativeConfigOutputFailureDoesNotDiscardGatewaysSync(t *testing.T) {
+	var (
+		ctx               = context.Background()
+		testGatewayClient = mustSampleGatewayClient(t)
+		clientsProvider   = mockGatewayClientsProvider{
+			gatewayClients: []*adminapi.Client{testGatewayClient},
+		}
+
+		updateStrategyResolver = newMockUpdateStrategyResolver(t)
+		configChangeDetector   = mockConfigurationChangeDetector{hasConfigurationChanged: true}
+		configBuilder          = newMockKongConfigBuilder()
+		kongRawStateGetter     = &mockKongLastValidConfigFetcher{}
+		kongClient             = setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector, configBuilder, nil, kongRawStateGetter)
+	)
+	// The output's directory doesn't exist, so writing to it fails.
+	outputPath := filepath.Join(t.TempDir(), "missing", "kong.json")
+	kongClient.SetDeclarativeConfigOutputs(
+		sendconfig.NewUpdateStrategyFile(outputPath, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard()),
+	)
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{
+			{Service: kong.Service{Name: kong.String("service"), Host: kong.String("example.com")}},
+		},
+	}
+
+	require.NoError(t, kongClient.Update(ctx), "failing output shouldn't fail the update applied to gateways")
+	updateStrategyResolver.assertUpdateCalledForURLs([]string{testGatewayClient.BaseRootURL()})
+	require.Equal(t, []string{string(testGatewayClient.LastConfigSHA())}, kongClient.SHAs,
+		"hash of the configuration applied to the gateway should be recorded")
+	_, found := kongRawStateGetter.LastValidConfig()
+	require.True(t, found, "configuration applied to the gateway should be stored as the last valid one")
+	require.True(t, kongClient.UpdateRetryNeeded(), "update should be retried for the failing output")
+
+	t.Log("creating the output's directory should make the next update write to it")
+	require.NoError(t, os.Mkdir(filepath.Dir(outputPath), 0o700))
+	require.NoError(t, kongClient.Update(ctx))
+	require.FileExists(t, outputPath)
+	require.Len(t, kongClient.SHAs, 2)
+	require.False(t, kongClient.UpdateRetryNeeded())
+}
+
+func TestKongClientUpdate_ContentIsGeneratedOnceForAllGatewayClients(t *testing.T) {
+	var (
+		ctx                = context.Background()
+		testGatewayClients = []*adminapi.Client{
+			mustSampleGatewayClient(t),
+			mustSampleGatewayClient(t),
+			mustSampleGatewayClient(t),
+		}
+		testKonnectClient = mustSampleKonnectClient(t)
+		clientsProvider   = mockGatewayClientsProvider{
+			gatewayClients: testGatewayClients,
+			konnectClient:  testKonnectClient,
+		}
+
+		updateStrategyResolver = newMockUpdateStrategyResolver(t)
+		configChangeDetector   = mockConfigurationChangeDetector{hasConfigurationChanged: true}
+		configBuilder          = newMockKongConfigBuilder()
+		kongRawStateGetter     = &mockKongLastValidConfigFetcher{}
+		kongClient             = setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector, configBuilder, nil, kongRawStateGetter)
+	)
+	kongClient.kongConfig.InMemory = true
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{
+			{Service: kong.Service{Name: kong.String("service"), Host: kong.String("example.com")}},
+		},
+	}
+
+	require.NoError(t, kongClient.Update(ctx))
+
+	firstContent, ok := updateStrategyResolver.lastUpdatedContentForURL(testGatewayClients[0].BaseRootURL())
+	require.True(t, ok)
+	require.NotEmpty(t, firstContent.Hash)
+	require.NotEmpty(t, firstContent.DBLessConfig, "serialized DB-less configuration should be prepared for DB-less gateways")
+	for _, gatewayClient := range testGatewayClients[1:] {
+		content, ok := updateStrategyResolver.lastUpdatedContentForURL(gatewayClient.BaseRootURL())
+		require.True(t, ok)
+		require.Same(t, firstContent.Content, content.Content, "all gateway clients should share the same generated content")
+		require.Equal(t, firstContent.Hash, content.Hash)
+	}
+
+	konnectContent, ok := updateStrategyResolver.lastUpdatedContentForURL(testKonnectClient.BaseRootURL())
+	require.True(t, ok)
+	require.NotSame(t, firstContent.Content, konnectContent.Content, "konnect should get its own generated content")
+	require.Empty(t, konnectContent.DBLessConfig, "serialized DB-less configuration should not be prepared for konnect")
+}
+
+func TestKongClientUpdate_GatewaySyncQuorum(t *testing.T) {
+	testCases := []struct {
+		name          string
+		quorumPercent int
+		expectError   bool
+	}{
+		{
+			name:          "quorum of all gateways is not met when one of them fails",
+			quorumPercent: 100,
+			expectError:   true,
+		},
+		{
+			name:          "quorum of half of gateways is met when one of them fails",
+			quorumPercent: 50,
+			expectError:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				ctx                = context.Background()
+				testGatewayClients = []*adminapi.Client{
+					mustSampleGatewayClient(t),
+					mustSampleGatewayClient(t),
+					mustSampleGatewayClient(t),
+				}
+				failingClient   = testGatewayClients[2]
+				clientsProvider = mockGatewayClientsProvider{
+					gatewayClients: testGatewayClients,
+				}
+
+				updateStrategyResolver = newMockUpdateStrategyResolver(t)
+				configChangeDetector   = mockConfigurationChangeDetector{hasConfigurationChanged: true}
+				configBuilder          = newMockKongConfigBuilder()
+				kongRawStateGetter     = &mockKongLastValidConfigFetcher{}
+				kongClient             = setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector, configBuilder, nil, kongRawStateGetter)
+				gatewaySyncStatuses    = make(chan []util.GatewaySyncStatus, 1)
+			)
+			kongClient.kongConfig.GatewaySyncQuorumPercent = tc.quorumPercent
+			kongClient.diagnostic = util.ConfigDumpDiagnostic{GatewaySyncStatuses: gatewaySyncStatuses}
+			updateStrategyResolver.returnErrorOnUpdate(failingClient.BaseRootURL(), true)
+			failingClient.AttachServiceReference(k8stypes.NamespacedName{Namespace: "kong", Name: "admin"})
+			// The mock update strategy doesn't register failures, open the circuit up front.
+			failingClientBackoff := adminapi.NewGatewayBackoffStrategy(adminapi.GatewayBackoffConfig{
+				InitialInterval: time.Hour,
+				MaxInterval:     time.Hour,
+			}, clock.System{})
+			failingClientBackoff.RegisterUpdateFailure(errors.New("connection refused"), nil)
+			failingClient.AttachBackoffStrategy(failingClientBackoff)
+
+			err := kongClient.Update(ctx)
+			if tc.expectError {
+				require.Error(t, err)
+				require.Empty(t, kongClient.SHAs)
+				_, found := kongRawStateGetter.LastValidConfig()
+				require.False(t, found, "configuration should not be stored as the last valid one when quorum is not met")
+			} else {
+				require.NoError(t, err)
+				require.Len(t, kongClient.SHAs, 2, "hashes of gateways that applied the configuration should be recorded")
+				_, found := kongRawStateGetter.LastValidConfig()
+				require.True(t, found, "configuration should be stored as the last valid one when quorum is met")
+			}
+
+			select {
+			case statuses := <-gatewaySyncStatuses:
+				require.Len(t, statuses, len(testGatewayClients))
+				for _, status := range statuses {
+					if status.URL == failingClient.BaseRootURL() {
+						require.False(t, status.InSync)
+						require.NotEmpty(t, status.Error)
+						require.Equal(t, "kong/admin", status.Service, "gateway should be labeled with its source service")
+						require.True(t, status.CircuitOpen, "gateway's open circuit breaker should be reported")
+						require.NotEmpty(t, status.CircuitOpenReason)
+					} else {
+						require.True(t, status.InSync)
+						require.Empty(t, status.Error)
+						require.Empty(t, status.Service)
+						require.False(t, status.CircuitOpen)
+					}
+				}
+			default:
+				require.Fail(t, "expected gateway sync statuses to be shipped to the diagnostic server")
+			}
+		})
+	}
+}
+
+// mockChangeNotifier is a mock implementation of ChangeNotifier counting notifications.
+type mockChangeNotifier struct {
+	notifications int
+}
+
+func (m *mockChangeNotifier) NotifyChange() {
+	m.notifications++
+}
+
+func TestKongClient_NotifiesAboutChanges(t *testing.T) {
+	var (
+		ctx                = context.Background()
+		testGatewayClients = []*adminapi.Client{
+			mustSampleGatewayClient(t),
+		}
+		clientsProvider = mockGatewayClientsProvider{
+			gatewayClients: testGatewayClients,
+		}
+
+		updateStrategyResolver = newMockUpdateStrategyResolver(t)
+		configChangeDetector   = mockConfigurationChangeDetector{hasConfigurationChanged: true}
+		configBuilder          = newMockKongConfigBuilder()
+		kongRawStateGetter     = &mockKongLastValidConfigFetcher{}
+		kongClient             = setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector, configBuilder, nil, kongRawStateGetter)
+		notifier               = &mockChangeNotifier{}
+		service                = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "default"},
+		}
+	)
+	kongClient.SetChangeNotifier(notifier)
+
+	t.Log("updating and deleting objects notifies about changes")
+	require.NoError(t, kongClient.UpdateObject(service))
+	require.NoError(t, kongClient.DeleteObject(service))
+	require.Equal(t, 2, notifier.notifications)
+
+	t.Log("failed update keeps changes pending")
+	updateStrategyResolver.returnErrorOnUpdate(testGatewayClients[0].BaseRootURL(), true)
+	require.Error(t, kongClient.Update(ctx))
+	require.False(t, kongClient.takePendingChange().IsZero(), "changes should stay pending after a failed update")
+
+	t.Log("successful update clears pending changes")
+	require.NoError(t, kongClient.UpdateObject(service))
+	updateStrategyResolver.returnErrorOnUpdate(testGatewayClients[0].BaseRootURL(), false)
+	require.NoError(t, kongClient.Update(ctx))
+	require.True(t, kongClient.takePendingChange().IsZero(), "changes should not be pending after a successful update")
+}
+
+func TestKongClient_TracksChangedObjects(t *testing.T) {
+	var (
+		ctx                = context.Background()
+		testGatewayClients = []*adminapi.Client{
+			mustSampleGatewayClient(t),
+		}
+		clientsProvider = mockGatewayClientsProvider{
+			gatewayClients: testGatewayClients,
+		}
+
+		updateStrategyResolver = newMockUpdateStrategyResolver(t)
+		configChangeDetector   = mockConfigurationChangeDetector{hasConfigurationChanged: true}
+		configBuilder          = newMockKongConfigBuilder()
+		kongRawStateGetter     = &mockKongLastValidConfigFetcher{}
+		kongClient             = setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector, configBuilder, nil, kongRawStateGetter)
+		service                = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "default"},
+		}
+	)
+
+	t.Log("changed objects are not tracked unless enabled")
+	require.NoError(t, kongClient.UpdateObject(service))
+	_, ok := kongClient.takeChangedObjects()
+	require.False(t, ok)
+
+	kongClient.EnableChangedObjectsTracking()
+	expected := audit.Triggers{Objects: []audit.ObjectRef{{Kind: "Service", Namespace: "default", Name: "service"}}}
+
+	t.Log("failed update keeps changed objects for the next one")
+	require.NoError(t, kongClient.UpdateObject(service))
+	updateStrategyResolver.returnErrorOnUpdate(testGatewayClients[0].BaseRootURL(), true)
+	require.Error(t, kongClient.Update(ctx))
+	triggers, ok := kongClient.takeChangedObjects()
+	require.True(t, ok)
+	require.Equal(t, expected, triggers)
+
+	t.Log("successful update forgets changed objects")
+	require.NoError(t, kongClient.DeleteObject(service))
+	updateStrategyResolver.returnErrorOnUpdate(testGatewayClients[0].BaseRootURL(), false)
+	require.NoError(t, kongClient.Update(ctx))
+	triggers, ok = kongClient.takeChangedObjects()
+	require.True(t, ok)
+	require.Empty(t, triggers.Objects)
+}
+
+func TestKongClient_RecordsConfigCompositionMetrics(t *testing.T) {
+	var (
+		ctx                = context.Background()
+		testGatewayClients = []*adminapi.Client{
+			mustSampleGatewayClient(t),
+		}
+		clientsProvider = mockGatewayClientsProvider{
+			gatewayClients: testGatewayClients,
+		}
+
+		updateStrategyResolver = newMockUpdateStrategyResolver(t)
+		configChangeDetector   = mockConfigurationChangeDetector{hasConfigurationChanged: true}
+		configBuilder          = newMockKongConfigBuilder()
+		kongRawStateGetter     = &mockKongLastValidConfigFetcher{}
+		kongClient             = setupTestKongClient(t, updateStrategyResolver, clientsProvider, configChangeDetector, configBuilder, nil, kongRawStateGetter)
+		service                = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "default"},
+		}
+	)
+	configBuilder.kongState = &kongstate.KongState{
+		Services: []kongstate.Service{
+			{
+				Service: kong.Service{Name: kong.String("service")},
+				Parent:  service,
+			},
+		},
+	}
+	require.NoError(t, kongClient.UpdateObject(service))
+	require.NoError(t, kongClient.Update(ctx))
+
+	m := kongClient.prometheusMetrics
+	require.Equal(t, 1.0, testutil.ToFloat64(m.StoreObjects.WithLabelValues("Service")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.ConfigEntities.WithLabelValues("services", "default", "Service")))
+	require.Positive(t, testutil.ToFloat64(m.ConfigSize.WithLabelValues(metrics.GenerationTargetGateways)))
+}
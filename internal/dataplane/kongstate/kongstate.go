@@ -3,12 +3,14 @@ package kongstate
 import (
 	"crypto/sha256"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/go-kong/kong"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/admission/validation/consumers/credentials"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
@@ -465,3 +467,78 @@ func (ks *KongState) FillIDs(logger logr.Logger) {
 		}
 	}
 }
+
+// EntityCountKey groups Kong entities of the same type generated from Kubernetes objects of the same kind
+// in the same namespace.
+type EntityCountKey struct {
+	// EntityType is the type of the entities, named after their collection in declarative configuration
+	// (e.g. "services").
+	EntityType string
+	// Namespace is the namespace of the Kubernetes objects the entities were generated from.
+	Namespace string
+	// SourceKind is the kind of the Kubernetes objects the entities were generated from.
+	SourceKind string
+}
+
+// CountEntities counts Kong entities in the KongState, including entities nested in other ones, by their type and
+// the namespace and kind of Kubernetes objects they were generated from. Entities that aren't generated from
+// a single object (e.g. certificates shared by multiple objects) are counted with an empty namespace and kind.
+func (ks *KongState) CountEntities() map[EntityCountKey]int {
+	counts := make(map[EntityCountKey]int)
+	count := func(entityType, namespace, sourceKind string, n int) {
+		if n > 0 {
+			counts[EntityCountKey{EntityType: entityType, Namespace: namespace, SourceKind: sourceKind}] += n
+		}
+	}
+
+	for _, s := range ks.Services {
+		namespace, kind := s.source()
+		count("services", namespace, kind, 1)
+		count("plugins", namespace, kind, len(s.Plugins))
+		for _, r := range s.Routes {
+			// Kind of the object the route was generated from is known only when its type metadata was set,
+			// otherwise it's assumed to be the one the service was generated from.
+			routeNamespace, routeKind := r.Ingress.Namespace, r.Ingress.GroupVersionKind.Kind
+			if routeKind == "" {
+				routeKind = kind
+			}
+			if routeNamespace == "" {
+				routeNamespace = namespace
+			}
+			count("routes", routeNamespace, routeKind, 1)
+			count("plugins", routeNamespace, routeKind, len(r.Plugins))
+		}
+	}
+	for _, u := range ks.Upstreams {
+		namespace, kind := u.Service.source()
+		count("upstreams", namespace, kind, 1)
+		count("targets", namespace, kind, len(u.Targets))
+	}
+	for _, p := range ks.Plugins {
+		if p.K8sParent == nil {
+			count("plugins", "", "", 1)
+			continue
+		}
+		count("plugins", p.K8sParent.GetNamespace(), objectKind(p.K8sParent), 1)
+	}
+	for _, c := range ks.Consumers {
+		count("consumers", c.K8sKongConsumer.Namespace, "KongConsumer", 1)
+		count("plugins", c.K8sKongConsumer.Namespace, "KongConsumer", len(c.Plugins))
+	}
+	for _, cg := range ks.ConsumerGroups {
+		count("consumer_groups", cg.K8sKongConsumerGroup.Namespace, "KongConsumerGroup", 1)
+	}
+	count("certificates", "", "", len(ks.Certificates))
+	count("ca_certificates", "", "", len(ks.CACertificates))
+
+	return counts
+}
+
+// objectKind returns the kind of obj. When obj doesn't have its kind set (as typed objects read from a cache usually
+// don't), the name of its Go type is used instead.
+func objectKind(obj client.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	return reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -783,6 +784,52 @@ func TestKongState_FillIDs(t *testing.T) {
 	}
 }
 
+func TestKongState_CountEntities(t *testing.T) {
+	ingress := &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ingress"}}
+	state := KongState{
+		Services: []Service{
+			{
+				Service: kong.Service{Name: kong.String("service")},
+				Parent:  ingress,
+				Routes: []Route{
+					{Route: kong.Route{Name: kong.String("route-1")}, Ingress: util.FromK8sObject(ingress)},
+					{Route: kong.Route{Name: kong.String("route-2")}, Ingress: util.FromK8sObject(ingress)},
+				},
+			},
+		},
+		Upstreams: []Upstream{
+			{
+				Upstream: kong.Upstream{Name: kong.String("upstream")},
+				Targets:  []Target{{}, {}},
+				Service:  Service{Parent: ingress},
+			},
+		},
+		Plugins: []Plugin{
+			{
+				Plugin:    kong.Plugin{Name: kong.String("key-auth")},
+				K8sParent: &kongv1.KongPlugin{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "key-auth"}},
+			},
+		},
+		Consumers: []Consumer{
+			{
+				Consumer:        kong.Consumer{Username: kong.String("consumer")},
+				K8sKongConsumer: kongv1.KongConsumer{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "consumer"}},
+			},
+		},
+		Certificates: []Certificate{{}, {}},
+	}
+
+	require.Equal(t, map[EntityCountKey]int{
+		{EntityType: "services", Namespace: "default", SourceKind: "Ingress"}:     1,
+		{EntityType: "routes", Namespace: "default", SourceKind: "Ingress"}:       2,
+		{EntityType: "upstreams", Namespace: "default", SourceKind: "Ingress"}:    1,
+		{EntityType: "targets", Namespace: "default", SourceKind: "Ingress"}:      2,
+		{EntityType: "plugins", Namespace: "other", SourceKind: "KongPlugin"}:     1,
+		{EntityType: "consumers", Namespace: "other", SourceKind: "KongConsumer"}: 1,
+		{EntityType: "certificates", Namespace: "", SourceKind: ""}:               2,
+	}, state.CountEntities())
+}
+
 func TestKongState_BuildPluginsCollisions(t *testing.T) {
 	for _, tt := range []struct {
 		name       string
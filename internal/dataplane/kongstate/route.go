@@ -22,6 +22,15 @@ type Route struct {
 	ExpressionRoutes bool
 }
 
+// DeepCopy returns a copy of the Route that doesn't share Kong entities with it. Kubernetes object metadata
+// is shared, as it's not meant to be modified.
+func (r Route) DeepCopy() Route {
+	copied := r
+	copied.Route = *r.Route.DeepCopy()
+	copied.Plugins = deepCopyKongPlugins(r.Plugins)
+	return copied
+}
+
 var (
 	validMethods      = regexp.MustCompile(`\A[A-Z]+$`)
 	validPathHandling = regexp.MustCompile(`v\d`)
@@ -59,6 +59,50 @@ type Service struct {
 	Parent client.Object
 }
 
+// source returns the namespace and kind of the Kubernetes object the Service was generated from.
+func (s Service) source() (namespace, kind string) {
+	if s.Parent == nil {
+		return s.Namespace, ""
+	}
+	return s.Parent.GetNamespace(), objectKind(s.Parent)
+}
+
+// DeepCopy returns a copy of the Service that doesn't share Kong entities with it. Kubernetes objects
+// it refers to are shared, as they're not meant to be modified.
+func (s Service) DeepCopy() Service {
+	copied := s
+	copied.Service = *s.Service.DeepCopy()
+	copied.Plugins = deepCopyKongPlugins(s.Plugins)
+	if s.Routes != nil {
+		copied.Routes = make([]Route, 0, len(s.Routes))
+		for _, r := range s.Routes {
+			copied.Routes = append(copied.Routes, r.DeepCopy())
+		}
+	}
+	if s.Backends != nil {
+		copied.Backends = make([]ServiceBackend, len(s.Backends))
+		copy(copied.Backends, s.Backends)
+	}
+	if s.K8sServices != nil {
+		copied.K8sServices = make(map[string]*corev1.Service, len(s.K8sServices))
+		for k, v := range s.K8sServices {
+			copied.K8sServices[k] = v
+		}
+	}
+	return copied
+}
+
+func deepCopyKongPlugins(plugins []kong.Plugin) []kong.Plugin {
+	if plugins == nil {
+		return nil
+	}
+	copied := make([]kong.Plugin, 0, len(plugins))
+	for _, p := range plugins {
+		copied = append(copied, *p.DeepCopy())
+	}
+	return copied
+}
+
 func (s *Service) overridePath(anns map[string]string) {
 	if s == nil {
 		return
@@ -0,0 +1,334 @@
+package atc
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// This file implements validation of expressions against the grammar of Kong's expression router without a running
+// Kong Gateway. It's meant for contexts where Kong's `/schemas/routes/validate` endpoint is not available (e.g. offline
+// validation of manifests). Kong remains the source of truth - it may reject expressions that pass this validation.
+// https://docs.konghq.com/gateway/latest/reference/router-expressions-language/ describes the grammar.
+
+// fieldTypeIP is used internally for validation of fields holding IP addresses.
+const fieldTypeIP FieldType = FieldTypeIPCIDR
+
+var (
+	// knownFields are the fields with constant names and their types.
+	knownFields = map[string]FieldType{
+		FieldNetProtocol.String(): FieldTypeString,
+		FieldTLSSNI.String():      FieldTypeString,
+		FieldHTTPMethod.String():  FieldTypeString,
+		FieldHTTPHost.String():    FieldTypeString,
+		FieldHTTPPath.String():    FieldTypeString,
+		FieldNetPort.String():     FieldTypeInt,
+		FieldNetDstPort.String():  FieldTypeInt,
+		"net.src.port":            FieldTypeInt,
+		"net.src.ip":              fieldTypeIP,
+		"net.dst.ip":              fieldTypeIP,
+		"http.path.segments.len":  FieldTypeInt,
+	}
+
+	// knownFieldPrefixes are the prefixes of fields with dynamic names (e.g. http.headers.x_foo) and their types.
+	knownFieldPrefixes = map[string]FieldType{
+		"http.headers.":       FieldTypeString,
+		"http.queries.":       FieldTypeString,
+		"http.path.segments.": FieldTypeString,
+	}
+
+	// operatorsForFieldType are the operators allowed for each field type.
+	operatorsForFieldType = map[FieldType][]BinaryOperator{
+		FieldTypeString: {OpEqual, OpNotEqual, OpRegexMatch, OpPrefixMatch, OpSuffixMatch, OpContains},
+		FieldTypeInt:    {OpEqual, OpNotEqual, OpLessThan, OpLessEqual, OpGreaterThan, OpGreaterEqual},
+		fieldTypeIP:     {OpEqual, OpNotEqual, OpIn, OpNotIn},
+	}
+
+	// symbolOperators are the operators made of symbols, longest first so that prefixes don't shadow them.
+	symbolOperators = []BinaryOperator{
+		OpEqual, OpNotEqual, OpPrefixMatch, OpSuffixMatch, OpLessEqual, OpGreaterEqual,
+		OpRegexMatch, OpLessThan, OpGreaterThan,
+	}
+)
+
+// ValidateExpression checks whether the expression is syntactically valid, uses known fields with operators and
+// literals matching their types, and contains only valid regexes.
+func ValidateExpression(expression string) error {
+	p := &expressionParser{input: expression}
+	if err := p.parseExpression(); err != nil {
+		return err
+	}
+	p.skipSpaces()
+	if !p.done() {
+		return p.errorf("unexpected %q", p.rest())
+	}
+	return nil
+}
+
+type expressionParser struct {
+	input string
+	pos   int
+}
+
+func (p *expressionParser) parseExpression() error {
+	if err := p.parseTerm(); err != nil {
+		return err
+	}
+	for p.consume("||") {
+		if err := p.parseTerm(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *expressionParser) parseTerm() error {
+	if err := p.parseFactor(); err != nil {
+		return err
+	}
+	for p.consume("&&") {
+		if err := p.parseFactor(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *expressionParser) parseFactor() error {
+	p.skipSpaces()
+	// A negation can only be applied to a parenthesized expression, "!=" is an operator.
+	if strings.HasPrefix(p.rest(), "!") && !strings.HasPrefix(p.rest(), "!=") {
+		p.pos++
+		p.skipSpaces()
+		if !strings.HasPrefix(p.rest(), "(") {
+			return p.errorf("expected \"(\" after \"!\"")
+		}
+	}
+	if p.consume("(") {
+		if err := p.parseExpression(); err != nil {
+			return err
+		}
+		if !p.consume(")") {
+			return p.errorf("expected \")\"")
+		}
+		return nil
+	}
+	return p.parsePredicate()
+}
+
+func (p *expressionParser) parsePredicate() error {
+	fieldType, err := p.parseLHS()
+	if err != nil {
+		return err
+	}
+	op, err := p.parseOperator()
+	if err != nil {
+		return err
+	}
+	if !operatorAllowed(fieldType, op) {
+		return p.errorf("operator %q can't be used with the field", op)
+	}
+	return p.parseLiteral(fieldType, op)
+}
+
+func (p *expressionParser) parseLHS() (FieldType, error) {
+	p.skipSpaces()
+	if p.consume("lower(") {
+		fieldType, err := p.parseLHS()
+		if err != nil {
+			return 0, err
+		}
+		if fieldType != FieldTypeString {
+			return 0, p.errorf("lower() can be applied only to string fields")
+		}
+		if !p.consume(")") {
+			return 0, p.errorf("expected \")\"")
+		}
+		return fieldType, nil
+	}
+
+	start := p.pos
+	for !p.done() {
+		r := rune(p.input[p.pos])
+		if !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.') {
+			break
+		}
+		p.pos++
+	}
+	field := p.input[start:p.pos]
+	if field == "" {
+		return 0, p.errorf("expected a field")
+	}
+	if t, ok := knownFields[field]; ok {
+		return t, nil
+	}
+	for prefix, t := range knownFieldPrefixes {
+		if strings.HasPrefix(field, prefix) && len(field) > len(prefix) {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown field %q at position %d", field, start)
+}
+
+func (p *expressionParser) parseOperator() (BinaryOperator, error) {
+	p.skipSpaces()
+	for _, op := range symbolOperators {
+		if strings.HasPrefix(p.rest(), string(op)) {
+			p.pos += len(op)
+			return op, nil
+		}
+	}
+	for _, op := range []BinaryOperator{OpNotIn, OpIn, OpContains} {
+		if p.consumeWord(string(op)) {
+			return op, nil
+		}
+	}
+	return "", p.errorf("expected an operator")
+}
+
+func (p *expressionParser) parseLiteral(fieldType FieldType, op BinaryOperator) error {
+	p.skipSpaces()
+	switch fieldType {
+	case FieldTypeString:
+		s, err := p.parseStringLiteral()
+		if err != nil {
+			return err
+		}
+		if op == OpRegexMatch {
+			if _, err := regexp.Compile(s); err != nil {
+				return fmt.Errorf("invalid regex %q: %w", s, err)
+			}
+		}
+		return nil
+	case FieldTypeInt:
+		start := p.pos
+		if strings.HasPrefix(p.rest(), "-") {
+			p.pos++
+		}
+		for !p.done() && unicode.IsDigit(rune(p.input[p.pos])) {
+			p.pos++
+		}
+		if _, err := strconv.Atoi(p.input[start:p.pos]); err != nil {
+			p.pos = start
+			return p.errorf("expected an integer")
+		}
+		return nil
+	default:
+		start := p.pos
+		for !p.done() && !unicode.IsSpace(rune(p.input[p.pos])) && p.input[p.pos] != ')' {
+			p.pos++
+		}
+		literal := p.input[start:p.pos]
+		if op == OpIn || op == OpNotIn {
+			if _, err := netip.ParsePrefix(literal); err != nil {
+				p.pos = start
+				return p.errorf("expected an IP CIDR")
+			}
+			return nil
+		}
+		if _, err := netip.ParseAddr(literal); err != nil {
+			p.pos = start
+			return p.errorf("expected an IP address")
+		}
+		return nil
+	}
+}
+
+// parseStringLiteral parses a quoted string literal (with escape sequences) or a raw string literal (r#"..."#)
+// and returns its value.
+func (p *expressionParser) parseStringLiteral() (string, error) {
+	if p.consume(`r#"`) {
+		end := strings.Index(p.rest(), `"#`)
+		if end < 0 {
+			return "", p.errorf("unterminated raw string")
+		}
+		s := p.rest()[:end]
+		p.pos += end + len(`"#`)
+		return s, nil
+	}
+
+	if !p.consume(`"`) {
+		return "", p.errorf("expected a string")
+	}
+	var sb strings.Builder
+	for !p.done() {
+		c := p.input[p.pos]
+		p.pos++
+		switch c {
+		case '"':
+			return sb.String(), nil
+		case '\\':
+			if p.done() {
+				return "", p.errorf("unterminated string")
+			}
+			escaped := p.input[p.pos]
+			p.pos++
+			switch escaped {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '\\', '"':
+				sb.WriteByte(escaped)
+			default:
+				return "", p.errorf("invalid escape sequence \"\\%c\"", escaped)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func operatorAllowed(fieldType FieldType, op BinaryOperator) bool {
+	for _, allowed := range operatorsForFieldType[fieldType] {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *expressionParser) skipSpaces() {
+	for !p.done() && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips spaces and advances past token if the input continues with it.
+func (p *expressionParser) consume(token string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.rest(), token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+// consumeWord is like consume, but requires the token to be followed by a space.
+func (p *expressionParser) consumeWord(word string) bool {
+	p.skipSpaces()
+	rest := p.rest()
+	if strings.HasPrefix(rest, word) && len(rest) > len(word) && unicode.IsSpace(rune(rest[len(word)])) {
+		p.pos += len(word)
+		return true
+	}
+	return false
+}
+
+func (p *expressionParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *expressionParser) rest() string {
+	return p.input[p.pos:]
+}
+
+func (p *expressionParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%s at position %d", fmt.Sprintf(format, args...), p.pos)
+}
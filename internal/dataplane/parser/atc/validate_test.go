@@ -0,0 +1,95 @@
+package atc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExpression(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		valid      bool
+	}{
+		{
+			name:       "single predicate",
+			expression: `http.path == "/foo"`,
+			valid:      true,
+		},
+		{
+			name:       "nested and/or with headers, sni and methods",
+			expression: `((http.path == "/prefix/0") || (http.path ^= "/prefix/0/")) && (http.host =^ ".foo.com") && (tls.sni == "a.foo.com") && (http.method == "GET")`,
+			valid:      true,
+		},
+		{
+			name:       "regex match with escaped characters",
+			expression: `(http.host == "example.com") && (http.path ~ "^/foo/\\d{3}") && (http.headers.x_foo ~ "[a-z]+\"")`,
+			valid:      true,
+		},
+		{
+			name:       "raw string literal",
+			expression: `http.path ~ r#"^/foo/\d+$"#`,
+			valid:      true,
+		},
+		{
+			name:       "integer fields",
+			expression: `(net.dst.port == 80) || (net.dst.port >= 8000)`,
+			valid:      true,
+		},
+		{
+			name:       "ip fields",
+			expression: `(net.src.ip in 10.0.0.0/8) && (net.dst.ip == ::1)`,
+			valid:      true,
+		},
+		{
+			name:       "lower transform and negation",
+			expression: `!(lower(http.headers.foo) contains "bar")`,
+			valid:      true,
+		},
+		{
+			name:       "unknown field",
+			expression: `http.foo == "bar"`,
+		},
+		{
+			name:       "operator not matching field type",
+			expression: `net.dst.port ~ "80"`,
+		},
+		{
+			name:       "literal not matching field type",
+			expression: `net.dst.port == "80"`,
+		},
+		{
+			name:       "invalid regex",
+			expression: `http.path ~ "^/foo/(bar"`,
+		},
+		{
+			name:       "unbalanced parentheses",
+			expression: `(http.path == "/foo") && (http.host == "example.com"`,
+		},
+		{
+			name:       "unterminated string",
+			expression: `http.path == "/foo`,
+		},
+		{
+			name:       "dangling logical operator",
+			expression: `http.path == "/foo" &&`,
+		},
+		{
+			name:       "lower applied to integer field",
+			expression: `lower(net.dst.port) == 80`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateExpression(tc.expression)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/capabilities"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+)
+
+// enforceCapabilities removes parts of the configuration requiring capabilities the Kong Gateways lack and reports
+// translation failures for Kubernetes objects they were translated from. Otherwise, the gateways would reject
+// the whole configuration. Plugins attached to removed entities are removed as well.
+func (p *Parser) enforceCapabilities(ks *kongstate.KongState) {
+	droppedServices, droppedRoutes := p.enforceProtocolCapabilities(ks)
+	droppedConsumerGroups := p.enforceConsumerGroupCapabilities(ks)
+
+	ks.Plugins = lo.Filter(ks.Plugins, func(plugin kongstate.Plugin, _ int) bool {
+		switch {
+		case plugin.Service != nil && droppedServices.Has(*plugin.Service.ID):
+			return false
+		case plugin.Route != nil && droppedRoutes.Has(*plugin.Route.ID):
+			return false
+		case plugin.ConsumerGroup != nil && droppedConsumerGroups.Has(*plugin.ConsumerGroup.ID):
+			return false
+		}
+
+		if err := p.capabilities.CheckPlugin(*plugin.Name); err != nil {
+			p.registerCapabilityFailure(err, plugin.K8sParent)
+			return false
+		}
+		if plugin.ConsumerGroup != nil {
+			if err := p.capabilities.Check(capabilities.ConsumerGroupScopedPlugins); err != nil {
+				p.registerCapabilityFailure(err, plugin.K8sParent)
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// enforceProtocolCapabilities removes Kong Services and Routes with protocols the Kong Gateways don't support. Names
+// of the removed Services and Routes are returned.
+func (p *Parser) enforceProtocolCapabilities(ks *kongstate.KongState) (droppedServices, droppedRoutes sets.Set[string]) {
+	droppedServices, droppedRoutes = sets.New[string](), sets.New[string]()
+
+	// New slices are built, as translated Services may be shared with the translation cache.
+	services := make([]kongstate.Service, 0, len(ks.Services))
+	for _, service := range ks.Services {
+		if service.Protocol != nil {
+			if err := p.capabilities.CheckProtocol(*service.Protocol); err != nil {
+				p.registerCapabilityFailure(fmt.Errorf("service protocol: %w", err), service.Parent)
+				droppedServices.Insert(*service.Name)
+				for _, route := range service.Routes {
+					droppedRoutes.Insert(*route.Name)
+				}
+				continue
+			}
+		}
+
+		service.Routes = lo.Filter(service.Routes, func(route kongstate.Route, _ int) bool {
+			if err := p.checkRouteProtocols(route); err != nil {
+				p.registerCapabilityFailure(fmt.Errorf("route %s protocols: %w", *route.Name, err), service.Parent)
+				droppedRoutes.Insert(*route.Name)
+				return false
+			}
+			return true
+		})
+		services = append(services, service)
+	}
+	ks.Services = services
+
+	return droppedServices, droppedRoutes
+}
+
+func (p *Parser) checkRouteProtocols(route kongstate.Route) error {
+	for _, protocol := range route.Protocols {
+		if protocol == nil {
+			continue
+		}
+		if err := p.capabilities.CheckProtocol(*protocol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enforceConsumerGroupCapabilities removes Kong Consumer Groups and Consumers' memberships in them when the Kong
+// Gateways don't support them. Names of the removed Consumer Groups are returned.
+func (p *Parser) enforceConsumerGroupCapabilities(ks *kongstate.KongState) sets.Set[string] {
+	dropped := sets.New[string]()
+	err := p.capabilities.Check(capabilities.ConsumerGroups)
+	if err == nil {
+		return dropped
+	}
+
+	for i := range ks.ConsumerGroups {
+		cg := &ks.ConsumerGroups[i]
+		p.registerCapabilityFailure(err, &cg.K8sKongConsumerGroup)
+		dropped.Insert(*cg.Name)
+	}
+	ks.ConsumerGroups = nil
+
+	consumers := make([]kongstate.Consumer, 0, len(ks.Consumers))
+	for _, consumer := range ks.Consumers {
+		if len(consumer.ConsumerGroups) > 0 {
+			consumer.ConsumerGroups = lo.Filter(consumer.ConsumerGroups, func(cg kong.ConsumerGroup, _ int) bool {
+				return cg.Name == nil || !dropped.Has(*cg.Name)
+			})
+		}
+		consumers = append(consumers, consumer)
+	}
+	ks.Consumers = consumers
+
+	return dropped
+}
+
+// registerCapabilityFailure registers a translation failure of the object whose configuration requires a capability
+// the Kong Gateways lack.
+func (p *Parser) registerCapabilityFailure(err error, causingObject client.Object) {
+	reason := fmt.Sprintf("configuration not supported by Kong Gateway: %s", err)
+	if causingObject == nil {
+		p.logger.Error(err, "skipping configuration not supported by Kong Gateway")
+		return
+	}
+	p.registerTranslationFailure(reason, causingObject)
+}
@@ -0,0 +1,247 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/zapr"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/capabilities"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+func TestParser_EnforceCapabilities(t *testing.T) {
+	ingressTypeMeta := metav1.TypeMeta{Kind: "Ingress", APIVersion: netv1.SchemeGroupVersion.String()}
+	pluginTypeMeta := metav1.TypeMeta{Kind: "KongPlugin", APIVersion: kongv1.SchemeGroupVersion.String()}
+	ingress := &netv1.Ingress{TypeMeta: ingressTypeMeta, ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "default"}}
+	wsIngress := &netv1.Ingress{TypeMeta: ingressTypeMeta, ObjectMeta: metav1.ObjectMeta{Name: "ws-ingress", Namespace: "default"}}
+	keyAuthPlugin := &kongv1.KongPlugin{TypeMeta: pluginTypeMeta, ObjectMeta: metav1.ObjectMeta{Name: "key-auth", Namespace: "default"}}
+	oidcPlugin := &kongv1.KongPlugin{TypeMeta: pluginTypeMeta, ObjectMeta: metav1.ObjectMeta{Name: "oidc", Namespace: "default"}}
+	rateLimitingPlugin := &kongv1.KongPlugin{TypeMeta: pluginTypeMeta, ObjectMeta: metav1.ObjectMeta{Name: "rate-limiting", Namespace: "default"}}
+	consumerGroup := kongv1beta1.KongConsumerGroup{
+		TypeMeta:   metav1.TypeMeta{Kind: "KongConsumerGroup", APIVersion: kongv1beta1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "group", Namespace: "default"},
+	}
+
+	newKongState := func() kongstate.KongState {
+		return kongstate.KongState{
+			Services: []kongstate.Service{
+				{
+					Service: kong.Service{Name: kong.String("http"), Protocol: kong.String("http")},
+					Routes: []kongstate.Route{
+						{Route: kong.Route{Name: kong.String("http"), Protocols: kong.StringSlice("http", "https")}},
+						{Route: kong.Route{Name: kong.String("ws"), Protocols: kong.StringSlice("ws")}},
+					},
+					Parent: ingress,
+				},
+				{
+					Service: kong.Service{Name: kong.String("wss"), Protocol: kong.String("wss")},
+					Routes: []kongstate.Route{
+						{Route: kong.Route{Name: kong.String("wss"), Protocols: kong.StringSlice("wss")}},
+					},
+					Parent: wsIngress,
+				},
+			},
+			ConsumerGroups: []kongstate.ConsumerGroup{
+				{
+					ConsumerGroup:        kong.ConsumerGroup{Name: kong.String("group")},
+					K8sKongConsumerGroup: consumerGroup,
+				},
+			},
+			Consumers: []kongstate.Consumer{
+				{
+					Consumer:       kong.Consumer{Username: kong.String("consumer")},
+					ConsumerGroups: []kong.ConsumerGroup{{Name: kong.String("group")}},
+				},
+			},
+			Plugins: []kongstate.Plugin{
+				{
+					Plugin:    kong.Plugin{Name: kong.String("key-auth"), Service: &kong.Service{ID: kong.String("http")}},
+					K8sParent: keyAuthPlugin,
+				},
+				{
+					Plugin:    kong.Plugin{Name: kong.String("key-auth"), Route: &kong.Route{ID: kong.String("wss")}},
+					K8sParent: keyAuthPlugin,
+				},
+				{
+					Plugin:    kong.Plugin{Name: kong.String("openid-connect"), Service: &kong.Service{ID: kong.String("http")}},
+					K8sParent: oidcPlugin,
+				},
+				{
+					Plugin: kong.Plugin{
+						Name:          kong.String("rate-limiting"),
+						ConsumerGroup: &kong.ConsumerGroup{ID: kong.String("group")},
+					},
+					K8sParent: rateLimitingPlugin,
+				},
+			},
+		}
+	}
+
+	newParser := func(t *testing.T, gateway capabilities.Gateway) *Parser {
+		s, err := store.NewFakeStore(store.FakeObjects{})
+		require.NoError(t, err)
+		p := mustNewParser(t, s)
+		p.InjectCapabilities(capabilities.NewRegistry(gateway))
+		return p
+	}
+
+	t.Run("unknown gateway keeps the whole configuration", func(t *testing.T) {
+		p := newParser(t, capabilities.Gateway{})
+		ks := newKongState()
+
+		p.enforceCapabilities(&ks)
+
+		require.Equal(t, newKongState(), ks)
+		require.Empty(t, p.popTranslationFailures())
+	})
+
+	t.Run("enterprise gateway with all plugins loaded keeps the whole configuration", func(t *testing.T) {
+		p := newParser(t, capabilities.Gateway{
+			Version: semver.MustParse("3.4.1"),
+			Edition: capabilities.EditionEnterprise,
+		})
+		ks := newKongState()
+
+		p.enforceCapabilities(&ks)
+
+		require.Equal(t, newKongState(), ks)
+		require.Empty(t, p.popTranslationFailures())
+	})
+
+	t.Run("oss gateway drops unsupported configuration and reports it", func(t *testing.T) {
+		p := newParser(t, capabilities.Gateway{
+			Version:       semver.MustParse("3.4.1"),
+			Edition:       capabilities.EditionOSS,
+			LoadedPlugins: []string{"key-auth", "rate-limiting"},
+		})
+		original := newKongState()
+		ks := original
+
+		p.enforceCapabilities(&ks)
+
+		require.Len(t, ks.Services, 1)
+		require.Equal(t, "http", *ks.Services[0].Name)
+		require.Len(t, ks.Services[0].Routes, 1)
+		require.Equal(t, "http", *ks.Services[0].Routes[0].Name)
+		require.Empty(t, ks.ConsumerGroups)
+		require.Len(t, ks.Consumers, 1)
+		require.Empty(t, ks.Consumers[0].ConsumerGroups)
+		require.Len(t, ks.Plugins, 1, "only the key-auth plugin of the supported service should be kept")
+		require.Equal(t, "key-auth", *ks.Plugins[0].Name)
+		require.Equal(t, "http", *ks.Plugins[0].Service.ID)
+
+		failedObjects := make(map[string][]string)
+		for _, failure := range p.popTranslationFailures() {
+			for _, obj := range failure.CausingObjects() {
+				failedObjects[obj.GetName()] = append(failedObjects[obj.GetName()], failure.Message())
+			}
+		}
+		require.Equal(t, map[string][]string{
+			"ingress": {
+				"configuration not supported by Kong Gateway: route ws protocols: ws and wss protocols require Kong Gateway Enterprise",
+			},
+			"ws-ingress": {
+				"configuration not supported by Kong Gateway: service protocol: ws and wss protocols require Kong Gateway Enterprise",
+			},
+			"group": {
+				"configuration not supported by Kong Gateway: consumer groups require Kong Gateway Enterprise",
+			},
+			"oidc": {
+				`configuration not supported by Kong Gateway: plugin "openid-connect" is not loaded by Kong Gateway`,
+			},
+		}, failedObjects)
+
+		require.Equal(t, newKongState(), original,
+			"translated entities must not be modified in place, as they may be shared with the translation cache")
+	})
+}
+
+func TestParser_ExpressionRoutesFollowCapabilities(t *testing.T) {
+	logger := zapr.NewLogger(zap.NewNop())
+	pathType := netv1.PathTypePrefix
+	ingress := &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "ingress",
+			Namespace:       "default",
+			ResourceVersion: "1",
+			Annotations:     map[string]string{annotations.IngressClassKey: annotations.DefaultIngressClass},
+		},
+		Spec: netv1.IngressSpec{
+			Rules: []netv1.IngressRule{{
+				IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{
+					Paths: []netv1.HTTPIngressPath{{
+						Path:     "/foo",
+						PathType: &pathType,
+						Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+							Name: "httpbin",
+							Port: netv1.ServiceBackendPort{Number: 80},
+						}},
+					}},
+				}},
+			}},
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "httpbin", Namespace: "default", ResourceVersion: "1"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+	}
+	s, err := store.NewFakeStore(store.FakeObjects{IngressesV1: []*netv1.Ingress{ingress}, Services: []*corev1.Service{service}})
+	require.NoError(t, err)
+
+	expressionsGateway := capabilities.Gateway{
+		Version:      semver.MustParse("3.4.1"),
+		RouterFlavor: capabilities.RouterFlavorExpressions,
+	}
+	traditionalGateway := capabilities.Gateway{
+		Version:      semver.MustParse("3.4.1"),
+		RouterFlavor: "traditional_compatible",
+	}
+
+	p, err := NewParser(logger, s, FeatureFlags{ExpressionRoutes: true, IncrementalTranslation: true})
+	require.NoError(t, err)
+	p.InjectCapabilities(capabilities.NewRegistry(expressionsGateway))
+
+	requireRoute := func(t *testing.T, expression bool) {
+		result := p.BuildKongConfig(context.Background())
+		require.Empty(t, result.TranslationFailures)
+		require.Len(t, result.KongState.Services, 1)
+		require.Len(t, result.KongState.Services[0].Routes, 1)
+		route := result.KongState.Services[0].Routes[0]
+		if expression {
+			require.NotNil(t, route.Expression, "expression route expected")
+			require.Empty(t, route.Paths)
+		} else {
+			require.Nil(t, route.Expression, "traditional route expected")
+			require.NotEmpty(t, route.Paths)
+		}
+	}
+
+	t.Log("expression routes are generated while all Kong Gateways support them")
+	requireRoute(t, true)
+
+	t.Log("traditional routes are generated once a Kong Gateway not supporting expression routes is detected")
+	p.InjectCapabilities(capabilities.NewRegistry(capabilities.CommonGateway(expressionsGateway, traditionalGateway)))
+	requireRoute(t, false)
+
+	t.Log("expression routes are generated again once all Kong Gateways support them")
+	p.InjectCapabilities(capabilities.NewRegistry(expressionsGateway))
+	requireRoute(t, true)
+
+	t.Log("expression routes are never generated when they weren't enabled")
+	p, err = NewParser(logger, s, FeatureFlags{})
+	require.NoError(t, err)
+	p.InjectCapabilities(capabilities.NewRegistry(expressionsGateway))
+	requireRoute(t, false)
+}
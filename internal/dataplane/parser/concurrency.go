@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"github.com/sourcegraph/conc/iter"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// translatedObject is a result of translating a single Kubernetes object into ingress rules.
+type translatedObject struct {
+	rules ingressRules
+	err   error
+}
+
+// translateObjectsConcurrently translates objs into ingress rules concurrently, reusing their cached translation
+// when the translation cache is enabled. Translated rules are merged into result and passed to report in the order
+// of objs, so that the output doesn't depend on the order the translations complete in.
+func translateObjectsConcurrently[T client.Object](
+	p *Parser,
+	result *ingressRules,
+	objs []T,
+	dependencyVersions []string,
+	translate func(*ingressRules, T) error,
+	report func(T, error),
+) {
+	translated := mapConcurrently(p, objs, func(obj *T) translatedObject {
+		rules, err := p.translationCache.translate(*obj, dependencyVersions, func(rules *ingressRules) error {
+			return translate(rules, *obj)
+		})
+		return translatedObject{rules: rules, err: err}
+	})
+	for i, t := range translated {
+		result.mergeFrom(t.rules)
+		report(objs[i], t.err)
+	}
+}
+
+// mapConcurrently calls f for every item concurrently, using at most the parser's concurrency goroutines,
+// and returns the results in the order of items.
+func mapConcurrently[T, R any](p *Parser, items []T, f func(*T) R) []R {
+	return iter.Mapper[T, R]{MaxGoroutines: p.concurrency}.Map(items, f)
+}
+
+// runConcurrently runs fns concurrently and waits for all of them to complete.
+func runConcurrently(fns ...func()) {
+	iter.ForEach(fns, func(fn *func()) {
+		(*fn)()
+	})
+}
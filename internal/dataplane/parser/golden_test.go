@@ -226,19 +226,7 @@ func runParserGoldenTest(t *testing.T, tc parserGoldenTestCase) {
 	require.NoError(t, err, "failed creating parser")
 
 	// Build the Kong configuration.
-	result := p.BuildKongConfig()
-	targetConfig := deckgen.ToDeckContent(context.Background(),
-		logger,
-		result.KongState,
-		deckgen.GenerateDeckContentParams{
-			ExpressionRoutes: tc.featureFlags.ExpressionRoutes,
-			PluginSchemas:    pluginsSchemaStoreStub{},
-		},
-	)
-
-	// Marshal the result into YAML bytes for comparison.
-	resultB, err := yaml.Marshal(targetConfig)
-	require.NoError(t, err, "failed marshalling result")
+	resultB := buildKongConfigAsYAML(t, p, tc.featureFlags)
 
 	// If the update flag is set, update the golden file with the result...
 	if *updateGolden {
@@ -264,6 +252,85 @@ func runParserGoldenTest(t *testing.T, tc parserGoldenTestCase) {
 	}
 }
 
+// TestParser_IncrementalTranslationGoldenTests verifies that the parser with incremental translation enabled
+// produces outputs identical to the golden files, both when translating objects for the first time and when
+// reusing their cached translation.
+func TestParser_IncrementalTranslationGoldenTests(t *testing.T) {
+	testCasesDirectories, err := os.ReadDir(goldenDir)
+	require.NoError(t, err, "failed to iterate over files in testdata/golden")
+
+	for _, testCaseDir := range testCasesDirectories {
+		testCaseDirPath := filepath.Join(goldenDir, testCaseDir.Name())
+		for _, parserSettings := range resolveSetsOfParserSettingsForTestCaseDir(t, testCaseDirPath) {
+			k8sConfigFile := filepath.Join(testCaseDirPath, inFileName)
+			goldenFile := filepath.Join(testCaseDirPath, fmt.Sprintf("%s%s", parserSettings.name, goldenFileSuffix))
+			featureFlags := parserSettings.featureFlags
+			featureFlags.IncrementalTranslation = true
+
+			t.Run(fmt.Sprintf("in=%s,out=%s", k8sConfigFile, goldenFile), func(t *testing.T) {
+				logger := zapr.NewLogger(zap.NewNop())
+
+				// Objects without a resourceVersion are never cached, so let's set one for all of them.
+				objects := lo.Map(extractObjectsFromYAML(t, k8sConfigFile), func(o []byte, _ int) []byte {
+					return withResourceVersion(t, o)
+				})
+				cacheStores, err := store.NewCacheStoresFromObjYAML(objects...)
+				require.NoError(t, err, "failed creating cache stores")
+
+				p, err := parser.NewParser(logger, store.New(cacheStores, "kong", logger), featureFlags)
+				require.NoError(t, err, "failed creating parser")
+
+				goldenB, err := os.ReadFile(goldenFile)
+				require.NoError(t, err, "failed reading golden file")
+
+				require.Equal(t, string(goldenB), string(buildKongConfigAsYAML(t, p, featureFlags)),
+					"initial translation does not match golden file %s", goldenFile)
+				require.Equal(t, string(goldenB), string(buildKongConfigAsYAML(t, p, featureFlags)),
+					"cached translation does not match golden file %s", goldenFile)
+			})
+		}
+	}
+}
+
+// buildKongConfigAsYAML builds the Kong configuration with the parser and returns it marshalled into YAML
+// in Deck format.
+func buildKongConfigAsYAML(t *testing.T, p *parser.Parser, featureFlags parser.FeatureFlags) []byte {
+	logger := zapr.NewLogger(zap.NewNop())
+
+	result := p.BuildKongConfig(context.Background())
+	targetConfig := deckgen.ToDeckContent(context.Background(),
+		logger,
+		result.KongState,
+		deckgen.GenerateDeckContentParams{
+			ExpressionRoutes: featureFlags.ExpressionRoutes,
+			PluginSchemas:    pluginsSchemaStoreStub{},
+		},
+	)
+
+	// Marshal the result into YAML bytes for comparison.
+	resultB, err := yaml.Marshal(targetConfig)
+	require.NoError(t, err, "failed marshalling result")
+	return resultB
+}
+
+// withResourceVersion sets metadata.resourceVersion of the YAML object unless it's already set.
+func withResourceVersion(t *testing.T, object []byte) []byte {
+	var obj map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(object, &obj), "failed unmarshalling object")
+
+	metadata, ok := obj["metadata"].(map[string]interface{})
+	if !ok {
+		return object
+	}
+	if _, ok := metadata["resourceVersion"]; !ok {
+		metadata["resourceVersion"] = "1"
+	}
+
+	b, err := yaml.Marshal(obj)
+	require.NoError(t, err, "failed marshalling object")
+	return b
+}
+
 func extractObjectsFromYAML(t *testing.T, filePath string) [][]byte {
 	y, err := os.ReadFile(filePath)
 	require.NoErrorf(t, err, "failed reading input file: %s", filePath)
@@ -37,17 +37,35 @@ func mergeIngressRules(objs ...ingressRules) ingressRules {
 	result := newIngressRules()
 
 	for _, obj := range objs {
-		result.SecretNameToSNIs.merge(obj.SecretNameToSNIs)
-		for k, v := range obj.ServiceNameToServices {
-			result.ServiceNameToServices[k] = v
-		}
-		for k, v := range obj.ServiceNameToParent {
-			result.ServiceNameToParent[k] = v
-		}
+		result.mergeFrom(obj)
 	}
 	return result
 }
 
+// mergeFrom merges other ingress rules into ir in place.
+func (ir *ingressRules) mergeFrom(o ingressRules) {
+	ir.SecretNameToSNIs.merge(o.SecretNameToSNIs)
+	for k, v := range o.ServiceNameToServices {
+		ir.ServiceNameToServices[k] = v
+	}
+	for k, v := range o.ServiceNameToParent {
+		ir.ServiceNameToParent[k] = v
+	}
+}
+
+// deepCopy returns a copy of ir that doesn't share Kong entities with it.
+func (ir ingressRules) deepCopy() ingressRules {
+	copied := newIngressRules()
+	copied.SecretNameToSNIs.merge(ir.SecretNameToSNIs)
+	for k, v := range ir.ServiceNameToServices {
+		copied.ServiceNameToServices[k] = v.DeepCopy()
+	}
+	for k, v := range ir.ServiceNameToParent {
+		copied.ServiceNameToParent[k] = v
+	}
+	return copied
+}
+
 // populateServices populates the ServiceNameToServices map with additional information
 // and returns a map of services to be skipped.
 func (ir *ingressRules) populateServices(logger logr.Logger, s store.Storer, failuresCollector *failures.ResourceFailuresCollector) map[string]interface{} {
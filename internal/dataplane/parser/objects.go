@@ -1,10 +1,15 @@
 package parser
 
-import "sigs.k8s.io/controller-runtime/pkg/client"
+import (
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
 
 // ObjectsCollector collects objects for later use.
-// Its methods are safe to call with a nil receiver.
+// Its methods are safe to call with a nil receiver and for concurrent use.
 type ObjectsCollector struct {
+	lock    sync.Mutex
 	objects []client.Object
 }
 
@@ -18,6 +23,8 @@ func (p *ObjectsCollector) Add(obj client.Object) {
 		return
 	}
 
+	p.lock.Lock()
+	defer p.lock.Unlock()
 	p.objects = append(p.objects, obj)
 }
 
@@ -28,6 +35,8 @@ func (p *ObjectsCollector) Pop() []client.Object {
 		return nil
 	}
 
+	p.lock.Lock()
+	defer p.lock.Unlock()
 	objs := p.objects
 	p.objects = nil
 	return objs
@@ -2,11 +2,13 @@ package parser
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/go-kong/kong"
@@ -20,11 +22,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/capabilities"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/gatewayapi"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/featuregates"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	kongv1alpha1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1alpha1"
 	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
@@ -36,10 +40,6 @@ import (
 
 const (
 	KindGateway = gatewayapi.Kind("Gateway")
-
-	// kongRouterFlavorExpressions is the value used in router_flavor of kong configuration
-	// to enable expression based router of kong.
-	kongRouterFlavorExpressions = "expressions"
 )
 
 // -----------------------------------------------------------------------------
@@ -62,32 +62,37 @@ type FeatureFlags struct {
 
 	// RewriteURIs enables the parser to translate the konghq.com/rewrite annotation to the proper set of Kong plugins.
 	RewriteURIs bool
+
+	// IncrementalTranslation enables the parser to cache translation results of Kubernetes objects and translate
+	// only the objects whose translation inputs changed since the previous BuildKongConfig() call.
+	IncrementalTranslation bool
 }
 
 func NewFeatureFlags(
 	logger logr.Logger,
 	featureGates featuregates.FeatureGates,
-	routerFlavor string,
+	gatewayCapabilities capabilities.Registry,
 	updateStatusFlag bool,
 ) FeatureFlags {
 	return FeatureFlags{
 		ReportConfiguredKubernetesObjects: updateStatusFlag,
-		ExpressionRoutes:                  shouldEnableParserExpressionRoutes(logger, featureGates, routerFlavor),
+		ExpressionRoutes:                  shouldEnableParserExpressionRoutes(logger, featureGates, gatewayCapabilities),
 		FillIDs:                           featureGates.Enabled(featuregates.FillIDsFeature),
 		RewriteURIs:                       featureGates.Enabled(featuregates.RewriteURIsFeature),
+		IncrementalTranslation:            featureGates.Enabled(featuregates.IncrementalTranslationFeature),
 	}
 }
 
 func shouldEnableParserExpressionRoutes(
 	logger logr.Logger,
 	featureGates featuregates.FeatureGates,
-	routerFlavor string,
+	gatewayCapabilities capabilities.Registry,
 ) bool {
 	if !featureGates.Enabled(featuregates.ExpressionRoutesFeature) {
 		return false
 	}
-	if routerFlavor != kongRouterFlavorExpressions {
-		logger.V(util.InfoLevel).Info("ExpressionRoutes feature gate enabled but Gateway is running with incompatible router flavor, using that instead", "flavor", routerFlavor)
+	if err := gatewayCapabilities.Check(capabilities.ExpressionRoutes); err != nil {
+		logger.V(util.InfoLevel).Info("ExpressionRoutes feature gate enabled but Gateway doesn't support expression routes, using traditional routes instead", "reason", err.Error())
 		return false
 	}
 	logger.V(util.InfoLevel).Info("expression routes mode enabled")
@@ -109,8 +114,21 @@ type Parser struct {
 	licenseGetter LicenseGetter
 	featureFlags  FeatureFlags
 
+	// capabilities describe what the Kong Gateways the configuration is built for support. Parts of the configuration
+	// requiring capabilities they lack are not generated and their Kubernetes objects are reported as failed.
+	capabilities capabilities.Registry
+
+	// expressionRoutesRequested tells whether featureFlags enabled expression routes when the parser was created.
+	// featureFlags.ExpressionRoutes is re-derived from it whenever capabilities are injected, so that traditional
+	// routes are generated for Kong Gateways that don't support expression routes.
+	expressionRoutesRequested bool
+
 	failuresCollector      *failures.ResourceFailuresCollector
 	parsedObjectsCollector *ObjectsCollector
+	translationCache       *translationCache
+
+	// concurrency is the maximum number of goroutines translating objects concurrently. 0 means GOMAXPROCS.
+	concurrency int
 }
 
 // NewParser produces a new Parser object provided a logging mechanism
@@ -128,12 +146,20 @@ func NewParser(
 		parsedObjectsCollector = NewObjectsCollector()
 	}
 
+	// If the feature flag is enabled, create a cache for translation results.
+	var translationCache *translationCache
+	if featureFlags.IncrementalTranslation {
+		translationCache = newTranslationCache()
+	}
+
 	return &Parser{
-		logger:                 logger,
-		storer:                 storer,
-		featureFlags:           featureFlags,
-		failuresCollector:      failuresCollector,
-		parsedObjectsCollector: parsedObjectsCollector,
+		logger:                    logger,
+		storer:                    storer,
+		featureFlags:              featureFlags,
+		expressionRoutesRequested: featureFlags.ExpressionRoutes,
+		failuresCollector:         failuresCollector,
+		parsedObjectsCollector:    parsedObjectsCollector,
+		translationCache:          translationCache,
 	}, nil
 }
 
@@ -152,72 +178,115 @@ type KongConfigBuildingResult struct {
 
 	// ConfiguredKubernetesObjects is a list of Kubernetes objects that were successfully parsed.
 	ConfiguredKubernetesObjects []client.Object
+
+	// PhaseDurations tells how long each of the phases of building the configuration took.
+	PhaseDurations map[TranslationPhase]time.Duration
 }
 
+// TranslationPhase is a phase of building the Kong configuration, named after the span it's traced with.
+type TranslationPhase string
+
+const (
+	TranslationPhaseTranslateIngressRules TranslationPhase = "TranslateIngressRules"
+	TranslationPhasePopulateServices      TranslationPhase = "PopulateServices"
+	TranslationPhaseFillOverrides         TranslationPhase = "FillOverrides"
+	TranslationPhaseFillConsumers         TranslationPhase = "FillConsumers"
+	TranslationPhaseFillPlugins           TranslationPhase = "FillPlugins"
+	TranslationPhaseFillCertificates      TranslationPhase = "FillCertificates"
+	TranslationPhaseEnforceCapabilities   TranslationPhase = "EnforceCapabilities"
+	TranslationPhaseFillIDs               TranslationPhase = "FillIDs"
+)
+
 // BuildKongConfig creates a Kong configuration from Ingress and Custom resources
 // defined in Kubernetes.
-func (p *Parser) BuildKongConfig() KongConfigBuildingResult {
-	// parse and merge all rules together from all Kubernetes API sources
-	ingressRules := mergeIngressRules(
-		p.ingressRulesFromIngressV1(),
-		p.ingressRulesFromTCPIngressV1beta1(),
-		p.ingressRulesFromUDPIngressV1beta1(),
-		p.ingressRulesFromHTTPRoutes(),
-		p.ingressRulesFromUDPRoutes(),
-		p.ingressRulesFromTCPRoutes(),
-		p.ingressRulesFromTLSRoutes(),
-		p.ingressRulesFromGRPCRoutes(),
-	)
-
-	// populate any Kubernetes Service objects relevant objects and get the
-	// services to be skipped because of annotations inconsistency
-	servicesToBeSkipped := ingressRules.populateServices(p.logger, p.storer, p.failuresCollector)
+func (p *Parser) BuildKongConfig(ctx context.Context) KongConfigBuildingResult {
+	ctx, span := tracing.StartSpan(ctx, "Parser.BuildKongConfig")
+	defer span.End()
+
+	p.translationCache.startBuild(p.storer)
+	phaseDurations := make(map[TranslationPhase]time.Duration)
+
+	// parse all rules from all Kubernetes API sources concurrently and merge them together in a fixed order
+	translateStart := time.Now()
+	_, translateSpan := tracing.StartSpan(ctx, "Parser."+string(TranslationPhaseTranslateIngressRules))
+	ingressRulesSources := []func() ingressRules{
+		p.ingressRulesFromIngressV1,
+		p.ingressRulesFromTCPIngressV1beta1,
+		p.ingressRulesFromUDPIngressV1beta1,
+		p.ingressRulesFromHTTPRoutes,
+		p.ingressRulesFromUDPRoutes,
+		p.ingressRulesFromTCPRoutes,
+		p.ingressRulesFromTLSRoutes,
+		p.ingressRulesFromGRPCRoutes,
+	}
+	ingressRules := mergeIngressRules(mapConcurrently(p, ingressRulesSources, func(source *func() ingressRules) ingressRules {
+		return (*source)()
+	})...)
+	translateSpan.End()
+	phaseDurations[TranslationPhaseTranslateIngressRules] = time.Since(translateStart)
+
+	p.finishTranslationCacheBuild()
 
 	// add the routes and services to the state
 	var result kongstate.KongState
 
-	// generate Upstreams and Targets from service defs
-	// update ServiceNameToServices with resolved ports (translating any name references to their number, as Kong
-	// services require a number)
-	result.Upstreams, ingressRules.ServiceNameToServices = p.getUpstreams(ingressRules.ServiceNameToServices)
-
-	for key, service := range ingressRules.ServiceNameToServices {
-		// if the service doesn't need to be skipped, then add it to the
-		// list of services.
-		if _, ok := servicesToBeSkipped[key]; !ok {
-			result.Services = append(result.Services, service)
+	runPhase(ctx, TranslationPhasePopulateServices, phaseDurations, func() {
+		// populate any Kubernetes Service objects relevant objects and get the
+		// services to be skipped because of annotations inconsistency
+		servicesToBeSkipped := ingressRules.populateServices(p.logger, p.storer, p.failuresCollector)
+
+		// generate Upstreams and Targets from service defs
+		// update ServiceNameToServices with resolved ports (translating any name references to their number, as Kong
+		// services require a number)
+		result.Upstreams, ingressRules.ServiceNameToServices = p.getUpstreams(ingressRules.ServiceNameToServices)
+
+		for key, service := range ingressRules.ServiceNameToServices {
+			// if the service doesn't need to be skipped, then add it to the
+			// list of services.
+			if _, ok := servicesToBeSkipped[key]; !ok {
+				result.Services = append(result.Services, service)
+			}
 		}
-	}
+	})
 
 	// merge KongIngress with Routes, Services and Upstream
-	result.FillOverrides(p.logger, p.storer)
+	runPhase(ctx, TranslationPhaseFillOverrides, phaseDurations, func() {
+		result.FillOverrides(p.logger, p.storer)
+	})
 
 	// generate consumers and credentials
-	result.FillConsumersAndCredentials(p.storer, p.failuresCollector)
-	for i := range result.Consumers {
-		p.registerSuccessfullyParsedObject(&result.Consumers[i].K8sKongConsumer)
-	}
+	runPhase(ctx, TranslationPhaseFillConsumers, phaseDurations, func() {
+		result.FillConsumersAndCredentials(p.storer, p.failuresCollector)
+		for i := range result.Consumers {
+			p.registerSuccessfullyParsedObject(&result.Consumers[i].K8sKongConsumer)
+		}
 
-	// process consumer groups
-	result.FillConsumerGroups(p.logger, p.storer)
-	for i := range result.ConsumerGroups {
-		p.registerSuccessfullyParsedObject(&result.ConsumerGroups[i].K8sKongConsumerGroup)
-	}
+		// process consumer groups
+		result.FillConsumerGroups(p.logger, p.storer)
+		for i := range result.ConsumerGroups {
+			p.registerSuccessfullyParsedObject(&result.ConsumerGroups[i].K8sKongConsumerGroup)
+		}
+	})
 
 	// process annotation plugins
-	result.FillPlugins(p.logger, p.storer, p.failuresCollector)
-	for i := range result.Plugins {
-		p.registerSuccessfullyParsedObject(result.Plugins[i].K8sParent)
-	}
-
-	// generate Certificates and SNIs
-	ingressCerts := p.getCerts(ingressRules.SecretNameToSNIs)
-	gatewayCerts := p.getGatewayCerts()
-	// note that ingress-derived certificates will take precedence over gateway-derived certificates for SNI assignment
-	result.Certificates = mergeCerts(p.logger, ingressCerts, gatewayCerts)
-
-	// populate CA certificates in Kong
-	result.CACertificates = p.getCACerts()
+	runPhase(ctx, TranslationPhaseFillPlugins, phaseDurations, func() {
+		result.FillPlugins(p.logger, p.storer, p.failuresCollector)
+		for i := range result.Plugins {
+			p.registerSuccessfullyParsedObject(result.Plugins[i].K8sParent)
+		}
+	})
+
+	// generate Certificates and SNIs, and populate CA certificates in Kong
+	runPhase(ctx, TranslationPhaseFillCertificates, phaseDurations, func() {
+		var ingressCerts, gatewayCerts []certWrapper
+		runConcurrently(
+			func() { ingressCerts = p.getCerts(ingressRules.SecretNameToSNIs) },
+			func() { gatewayCerts = p.getGatewayCerts() },
+			func() { result.CACertificates = p.getCACerts() },
+		)
+		// note that ingress-derived certificates will take precedence over gateway-derived certificates for SNI assignment
+		result.Certificates = mergeCerts(p.logger, ingressCerts, gatewayCerts)
+	})
 
 	if p.licenseGetter != nil {
 		optionalLicense := p.licenseGetter.GetLicense()
@@ -226,15 +295,23 @@ func (p *Parser) BuildKongConfig() KongConfigBuildingResult {
 		}
 	}
 
+	// drop parts of the configuration the gateways would reject
+	runPhase(ctx, TranslationPhaseEnforceCapabilities, phaseDurations, func() {
+		p.enforceCapabilities(&result)
+	})
+
 	if p.featureFlags.FillIDs {
 		// generate IDs for Kong entities
-		result.FillIDs(p.logger)
+		runPhase(ctx, TranslationPhaseFillIDs, phaseDurations, func() {
+			result.FillIDs(p.logger)
+		})
 	}
 
 	return KongConfigBuildingResult{
 		KongState:                   &result,
 		TranslationFailures:         p.popTranslationFailures(),
 		ConfiguredKubernetesObjects: p.popConfiguredKubernetesObjects(),
+		PhaseDurations:              phaseDurations,
 	}
 }
 
@@ -247,15 +324,56 @@ func (p *Parser) InjectLicenseGetter(licenseGetter LicenseGetter) {
 	p.licenseGetter = licenseGetter
 }
 
+// InjectCapabilities sets capabilities of the Kong Gateways the configuration is built for. Without them, the parser
+// assumes all capabilities are supported. Expression routes are generated only as long as the Kong Gateways
+// support them, as otherwise they would reject the whole configuration (e.g. older gateways during a rolling
+// upgrade), so traditional routes are generated instead.
+func (p *Parser) InjectCapabilities(gatewayCapabilities capabilities.Registry) {
+	p.capabilities = gatewayCapabilities
+
+	expressionRoutes := p.expressionRoutesRequested && gatewayCapabilities.Supports(capabilities.ExpressionRoutes)
+	if expressionRoutes == p.featureFlags.ExpressionRoutes {
+		return
+	}
+	if expressionRoutes {
+		p.logger.Info("Kong Gateways support expression routes, using expression routes")
+	} else {
+		p.logger.Info("Kong Gateways don't support expression routes, using traditional routes instead",
+			"reason", gatewayCapabilities.Check(capabilities.ExpressionRoutes).Error())
+	}
+	p.featureFlags.ExpressionRoutes = expressionRoutes
+	// Cached translation results contain routes of the other kind.
+	p.translationCache.invalidate()
+}
+
 // -----------------------------------------------------------------------------
 // Parser - Private Methods
 // -----------------------------------------------------------------------------
 
+// runPhase runs fn as a phase of building the configuration, traced with a span of its own and timed in durations.
+func runPhase(ctx context.Context, phase TranslationPhase, durations map[TranslationPhase]time.Duration, fn func()) {
+	_, span := tracing.StartSpan(ctx, "Parser."+string(phase))
+	defer span.End()
+	start := time.Now()
+	fn()
+	durations[phase] = time.Since(start)
+}
+
 // registerTranslationFailure should be called when any Kubernetes object translation failure is encountered.
 func (p *Parser) registerTranslationFailure(reason string, causingObjects ...client.Object) {
 	p.failuresCollector.PushResourceFailure(reason, causingObjects...)
 }
 
+// finishTranslationCacheBuild evicts translation results of objects that are gone from the translation cache.
+func (p *Parser) finishTranslationCacheBuild() {
+	if p.translationCache == nil {
+		return
+	}
+	p.translationCache.finishBuild()
+	p.logger.V(util.DebugLevel).Info("translated Kubernetes objects",
+		"reused", p.translationCache.hits, "translated", p.translationCache.misses)
+}
+
 func (p *Parser) popTranslationFailures() []failures.ResourceFailure {
 	return p.failuresCollector.PopResourceFailures()
 }
@@ -334,107 +452,123 @@ func findPort(svc *corev1.Service, wantPort kongstate.PortDef) (*corev1.ServiceP
 }
 
 func (p *Parser) getUpstreams(serviceMap map[string]kongstate.Service) ([]kongstate.Upstream, map[string]kongstate.Service) {
-	upstreamDedup := make(map[string]struct{}, len(serviceMap))
-	var empty struct{}
-	upstreams := make([]kongstate.Upstream, 0, len(serviceMap))
-	for serviceName, service := range serviceMap {
-		// the name of the Upstream for a service must match the service.Host
-		// as the Gateway's internal DNS resolve mechanisms will fail to properly
-		// resolve the host otherwise.
-		name := *service.Host
+	serviceNames := lo.Keys(serviceMap)
+	sort.Strings(serviceNames)
 
+	// the name of the Upstream for a service must match the service.Host
+	// as the Gateway's internal DNS resolve mechanisms will fail to properly
+	// resolve the host otherwise. Services sharing a host share the Upstream
+	// of the first of them.
+	upstreamDedup := make(map[string]struct{}, len(serviceMap))
+	upstreamServiceNames := make([]string, 0, len(serviceMap))
+	for _, serviceName := range serviceNames {
+		name := *serviceMap[serviceName].Host
 		if _, exists := upstreamDedup[name]; !exists {
-			// populate all the kong targets for the upstream given all the backends
-			var targets []kongstate.Target
-			for _, backend := range service.Backends {
-				// gather the Kubernetes service for the backend
-				backendNamespace := backend.Namespace
-				if backendNamespace == "" {
-					// if the backend namespace isn't specified, it's in the same namespace as the referee route (which is,
-					// somewhat confusingly, the _service_ namespace in serviceMap services, as historically there was no option
-					// to reference services outside the route namespace, and we could always stuff the route namespace into the
-					// placeholder service.
-					backendNamespace = service.Namespace
-				}
-				k8sService, ok := service.K8sServices[fmt.Sprintf("%s/%s", backendNamespace, backend.Name)]
-				if !ok {
-					p.registerTranslationFailure(
-						fmt.Sprintf("can't add target for backend %s: no kubernetes service found", backend.Name),
-						service.Parent,
-					)
-					continue
-				}
-
-				// determine the port for the backend
-				port, err := findPort(k8sService, backend.PortDef)
-				if err != nil {
-					p.registerTranslationFailure(
-						fmt.Sprintf("can't find port for backend kubernetes service: %v", err),
-						k8sService, service.Parent,
-					)
-					continue
-				}
-				service.Port = lo.ToPtr(int(port.Port))
-				serviceMap[serviceName] = service
+			upstreamDedup[name] = struct{}{}
+			upstreamServiceNames = append(upstreamServiceNames, serviceName)
+		}
+	}
 
-				// get the new targets for this backend service
-				newTargets := getServiceEndpoints(p.logger, p.storer, k8sService, port)
+	// resolve endpoints of all the Upstreams concurrently, keeping the order of services.
+	upstreams := mapConcurrently(p, upstreamServiceNames, func(serviceName *string) kongstate.Upstream {
+		return p.getUpstream(serviceMap[*serviceName])
+	})
+	for i, upstream := range upstreams {
+		// the Upstream's service has its port resolved
+		serviceMap[upstreamServiceNames[i]] = upstream.Service
+	}
+	return upstreams, serviceMap
+}
 
-				if len(newTargets) == 0 {
-					p.logger.V(util.InfoLevel).Info("no targets could be found for kubernetes service",
-						"namespace", k8sService.Namespace, "name", k8sService.Name, "kong_service", *service.Name)
-				}
+// getUpstream returns an Upstream for the service with targets resolved from endpoints of all its backends.
+func (p *Parser) getUpstream(service kongstate.Service) kongstate.Upstream {
+	name := *service.Host
+
+	// populate all the kong targets for the upstream given all the backends
+	var targets []kongstate.Target
+	for _, backend := range service.Backends {
+		// gather the Kubernetes service for the backend
+		backendNamespace := backend.Namespace
+		if backendNamespace == "" {
+			// if the backend namespace isn't specified, it's in the same namespace as the referee route (which is,
+			// somewhat confusingly, the _service_ namespace in serviceMap services, as historically there was no option
+			// to reference services outside the route namespace, and we could always stuff the route namespace into the
+			// placeholder service.
+			backendNamespace = service.Namespace
+		}
+		k8sService, ok := service.K8sServices[fmt.Sprintf("%s/%s", backendNamespace, backend.Name)]
+		if !ok {
+			p.registerTranslationFailure(
+				fmt.Sprintf("can't add target for backend %s: no kubernetes service found", backend.Name),
+				service.Parent,
+			)
+			continue
+		}
 
-				// if weights were set for the backend then that weight needs to be
-				// distributed equally among all the targets.
-				if backend.Weight != nil && len(newTargets) != 0 {
-					// initialize the weight of the target based on the weight of the backend
-					// which governs that target (and potentially more). If the weight of the
-					// backend is 0 then this indicates an intention to drop all targets from
-					// this backend from the load-balancer and is a special situation where
-					// all derived targets will receive a weight of 0.
-					targetWeight := int(*backend.Weight)
-
-					// if the backend governing this target is not set to a weight of 0,
-					// all targets derived from the backend split the weight, therefore
-					// equally splitting the traffic load.
-					if *backend.Weight != 0 {
-						targetWeight = int(*backend.Weight) / len(newTargets)
-						// minimum weight of 1 if weight zero was not specifically set.
-						if targetWeight == 0 {
-							targetWeight = 1
-						}
-					}
+		// determine the port for the backend
+		port, err := findPort(k8sService, backend.PortDef)
+		if err != nil {
+			p.registerTranslationFailure(
+				fmt.Sprintf("can't find port for backend kubernetes service: %v", err),
+				k8sService, service.Parent,
+			)
+			continue
+		}
+		service.Port = lo.ToPtr(int(port.Port))
 
-					for i := range newTargets {
-						newTargets[i].Weight = &targetWeight
-					}
-				}
+		// get the new targets for this backend service
+		newTargets := getServiceEndpoints(p.logger, p.storer, k8sService, port)
 
-				// add the new targets to the existing pool of targets for the Upstream.
-				targets = append(targets, newTargets...)
-			}
+		if len(newTargets) == 0 {
+			p.logger.V(util.InfoLevel).Info("no targets could be found for kubernetes service",
+				"namespace", k8sService.Namespace, "name", k8sService.Name, "kong_service", *service.Name)
+		}
 
-			// warn if an upstream was created with 0 targets
-			if len(targets) == 0 {
-				p.logger.V(util.InfoLevel).Info("no targets found to create upstream", "service_name", *service.Name)
+		// if weights were set for the backend then that weight needs to be
+		// distributed equally among all the targets.
+		if backend.Weight != nil && len(newTargets) != 0 {
+			// initialize the weight of the target based on the weight of the backend
+			// which governs that target (and potentially more). If the weight of the
+			// backend is 0 then this indicates an intention to drop all targets from
+			// this backend from the load-balancer and is a special situation where
+			// all derived targets will receive a weight of 0.
+			targetWeight := int(*backend.Weight)
+
+			// if the backend governing this target is not set to a weight of 0,
+			// all targets derived from the backend split the weight, therefore
+			// equally splitting the traffic load.
+			if *backend.Weight != 0 {
+				targetWeight = int(*backend.Weight) / len(newTargets)
+				// minimum weight of 1 if weight zero was not specifically set.
+				if targetWeight == 0 {
+					targetWeight = 1
+				}
 			}
 
-			// define the upstream including all the newly populated targets
-			// to load-balance traffic to.
-			upstream := kongstate.Upstream{
-				Upstream: kong.Upstream{
-					Name: kong.String(name),
-					Tags: service.Tags, // populated by populateServices already
-				},
-				Service: service,
-				Targets: targets,
+			for i := range newTargets {
+				newTargets[i].Weight = &targetWeight
 			}
-			upstreams = append(upstreams, upstream)
-			upstreamDedup[name] = empty
 		}
+
+		// add the new targets to the existing pool of targets for the Upstream.
+		targets = append(targets, newTargets...)
+	}
+
+	// warn if an upstream was created with 0 targets
+	if len(targets) == 0 {
+		p.logger.V(util.InfoLevel).Info("no targets found to create upstream", "service_name", *service.Name)
+	}
+
+	// define the upstream including all the newly populated targets
+	// to load-balance traffic to.
+	return kongstate.Upstream{
+		Upstream: kong.Upstream{
+			Name: kong.String(name),
+			Tags: service.Tags, // populated by populateServices already
+		},
+		Service: service,
+		Targets: targets,
 	}
-	return upstreams, serviceMap
 }
 
 func getCertFromSecret(secret *corev1.Secret) (string, string, error) {
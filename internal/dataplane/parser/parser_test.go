@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -24,9 +25,12 @@ import (
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/capabilities"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/gatewayapi"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/featuregates"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
@@ -61,7 +65,7 @@ func TestGlobalPlugin(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -251,7 +255,7 @@ func TestSecretConfigurationPlugin(t *testing.T) {
 			store, err := store.NewFakeStore(objects)
 			require.NoError(t, err)
 			p := mustNewParser(t, store)
-			result := p.BuildKongConfig()
+			result := p.BuildKongConfig(context.Background())
 			require.Empty(t, result.TranslationFailures)
 			require.NoError(t, err)
 			state := result.KongState
@@ -358,7 +362,7 @@ func TestSecretConfigurationPlugin(t *testing.T) {
 			store, err := store.NewFakeStore(objects)
 			require.NoError(t, err)
 			p := mustNewParser(t, store)
-			result := p.BuildKongConfig()
+			result := p.BuildKongConfig(context.Background())
 			require.Empty(t, result.TranslationFailures)
 			require.NoError(t, err)
 			state := result.KongState
@@ -462,7 +466,7 @@ func TestSecretConfigurationPlugin(t *testing.T) {
 			store, err := store.NewFakeStore(objects)
 			require.NoError(t, err)
 			p := mustNewParser(t, store)
-			result := p.BuildKongConfig()
+			result := p.BuildKongConfig(context.Background())
 			require.Empty(t, result.TranslationFailures)
 			state := result.KongState
 			require.NotNil(t, state)
@@ -515,7 +519,7 @@ func TestSecretConfigurationPlugin(t *testing.T) {
 		store, err := store.NewFakeStore(objects)
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -615,7 +619,7 @@ func TestSecretConfigurationPlugin(t *testing.T) {
 			store, err := store.NewFakeStore(objects)
 			require.NoError(t, err)
 			p := mustNewParser(t, store)
-			result := p.BuildKongConfig()
+			result := p.BuildKongConfig(context.Background())
 			require.Empty(t, result.TranslationFailures)
 			state := result.KongState
 			require.NotNil(t, state)
@@ -653,7 +657,7 @@ func TestCACertificate(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -711,7 +715,7 @@ func TestCACertificate(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -814,7 +818,7 @@ func TestCACertificate(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		assert.Len(result.TranslationFailures, 4)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -905,7 +909,7 @@ func TestServiceClientCertificate(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -979,7 +983,7 @@ func TestServiceClientCertificate(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Len(t, result.TranslationFailures, 1)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -1044,7 +1048,7 @@ func TestKongRouteAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -1133,7 +1137,7 @@ func TestKongRouteAnnotations(t *testing.T) {
 		})
 		assert.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -1222,7 +1226,7 @@ func TestKongRouteAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -1314,7 +1318,7 @@ func TestKongRouteAnnotations(t *testing.T) {
 			})
 			require.NoError(t, err)
 			p := mustNewParser(t, store)
-			result := p.BuildKongConfig()
+			result := p.BuildKongConfig(context.Background())
 			require.Empty(t, result.TranslationFailures)
 			state := result.KongState
 			require.NotNil(t, state)
@@ -1403,7 +1407,7 @@ func TestKongRouteAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -1494,7 +1498,7 @@ func TestKongRouteAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -1584,7 +1588,7 @@ func TestKongRouteAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -1674,7 +1678,7 @@ func TestKongRouteAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -1765,7 +1769,7 @@ func TestKongRouteAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -1855,7 +1859,7 @@ func TestKongRouteAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -1945,7 +1949,7 @@ func TestKongRouteAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -2011,7 +2015,7 @@ func TestKongProcessClasslessIngress(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -2066,7 +2070,7 @@ func TestKongProcessClasslessIngress(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -2131,7 +2135,7 @@ func TestKongServiceAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -2223,7 +2227,7 @@ func TestKongServiceAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -2321,7 +2325,7 @@ func TestKongServiceAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -2403,7 +2407,7 @@ func TestDefaultBackend(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -2477,7 +2481,7 @@ func TestDefaultBackend(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Len(t, result.TranslationFailures, 1)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -2544,7 +2548,7 @@ func TestParserSecret(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -2629,7 +2633,7 @@ func TestParserSecret(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -2758,7 +2762,7 @@ func TestParserSecret(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -2843,7 +2847,7 @@ func TestParserSecret(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -2933,7 +2937,7 @@ func TestParserSNI(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -3028,7 +3032,7 @@ func TestParserSNI(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -3095,7 +3099,7 @@ func TestParserHostAliases(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -3156,7 +3160,7 @@ func TestParserHostAliases(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -3218,7 +3222,7 @@ func TestParserHostAliases(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -3315,7 +3319,7 @@ func TestPluginAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -3419,7 +3423,7 @@ func TestPluginAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -3494,7 +3498,7 @@ func TestPluginAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -3546,7 +3550,7 @@ func TestPluginAnnotations(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -4186,7 +4190,7 @@ func TestPickPort(t *testing.T) {
 			require.NoError(t, err)
 
 			p := mustNewParser(t, store)
-			result := p.BuildKongConfig()
+			result := p.BuildKongConfig(context.Background())
 			require.Empty(t, result.TranslationFailures)
 
 			require.Equal(t, tt.wantTarget, *result.KongState.Upstreams[0].Targets[0].Target.Target)
@@ -4309,7 +4313,7 @@ func TestCertificate(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -4403,7 +4407,7 @@ func TestCertificate(t *testing.T) {
 		})
 		require.NoError(t, err)
 		p := mustNewParser(t, store)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.TranslationFailures)
 		state := result.KongState
 		require.NotNil(t, state)
@@ -4483,7 +4487,7 @@ func TestParser_FillsEntitiesIDs(t *testing.T) {
 	require.NoError(t, err)
 	p := mustNewParser(t, s)
 
-	result := p.BuildKongConfig()
+	result := p.BuildKongConfig(context.Background())
 	require.Empty(t, result.TranslationFailures)
 	state := result.KongState
 	require.NotNil(t, state)
@@ -4501,6 +4505,24 @@ func TestParser_FillsEntitiesIDs(t *testing.T) {
 	assert.Equal(t, "93c4b796-7cc1-5f86-834c-3bbdf00a806c", *state.Consumers[0].ID, "expected deterministic ID")
 }
 
+func TestParser_PhaseDurations(t *testing.T) {
+	s, err := store.NewFakeStore(store.FakeObjects{})
+	require.NoError(t, err)
+	p := mustNewParser(t, s)
+
+	result := p.BuildKongConfig(context.Background())
+	require.ElementsMatch(t, []TranslationPhase{
+		TranslationPhaseTranslateIngressRules,
+		TranslationPhasePopulateServices,
+		TranslationPhaseFillOverrides,
+		TranslationPhaseFillConsumers,
+		TranslationPhaseFillPlugins,
+		TranslationPhaseFillCertificates,
+		TranslationPhaseEnforceCapabilities,
+		TranslationPhaseFillIDs,
+	}, lo.Keys(result.PhaseDurations))
+}
+
 func TestNewFeatureFlags(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -4526,7 +4548,7 @@ func TestNewFeatureFlags(t *testing.T) {
 			featureGates: map[string]bool{
 				featuregates.ExpressionRoutesFeature: true,
 			},
-			routerFlavor: kongRouterFlavorExpressions,
+			routerFlavor: capabilities.RouterFlavorExpressions,
 			expectedFeatureFlags: FeatureFlags{
 				ExpressionRoutes: true,
 			},
@@ -4539,7 +4561,7 @@ func TestNewFeatureFlags(t *testing.T) {
 			},
 			routerFlavor:         "any_other_router_mode",
 			expectedFeatureFlags: FeatureFlags{},
-			expectInfoLog:        "ExpressionRoutes feature gate enabled but Gateway is running with incompatible router flavor, using that instead",
+			expectInfoLog:        "ExpressionRoutes feature gate enabled but Gateway doesn't support expression routes, using traditional routes instead",
 		},
 	}
 
@@ -4547,7 +4569,8 @@ func TestNewFeatureFlags(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			core, logs := observer.New(zap.InfoLevel)
 			logger := zapr.NewLogger(zap.New(core))
-			actualFlags := NewFeatureFlags(logger, tc.featureGates, tc.routerFlavor, tc.updateStatusFlag)
+			gatewayCapabilities := capabilities.NewRegistry(capabilities.Gateway{RouterFlavor: tc.routerFlavor})
+			actualFlags := NewFeatureFlags(logger, tc.featureGates, gatewayCapabilities, tc.updateStatusFlag)
 
 			require.Equal(t, tc.expectedFeatureFlags, actualFlags)
 
@@ -4574,13 +4597,13 @@ func TestParser_License(t *testing.T) {
 	p := mustNewParser(t, s)
 
 	t.Run("no license is populated by default", func(t *testing.T) {
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.KongState.Licenses)
 	})
 
 	t.Run("no license is populated when license getter returns no license", func(t *testing.T) {
 		p.InjectLicenseGetter(&mockLicenseGetter{})
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Empty(t, result.KongState.Licenses)
 	})
 
@@ -4592,7 +4615,7 @@ func TestParser_License(t *testing.T) {
 			}),
 		}
 		p.InjectLicenseGetter(licenseGetterWithLicense)
-		result := p.BuildKongConfig()
+		result := p.BuildKongConfig(context.Background())
 		require.Len(t, result.KongState.Licenses, 1)
 		license := result.KongState.Licenses[0]
 		require.Equal(t, "license-id", *license.ID)
@@ -4750,7 +4773,7 @@ func TestParser_ConfiguredKubernetesObjects(t *testing.T) {
 			s, _ := store.NewFakeStore(tc.objectsInStore)
 			p := mustNewParser(t, s)
 
-			result := p.BuildKongConfig()
+			result := p.BuildKongConfig(context.Background())
 			require.Len(t, result.ConfiguredKubernetesObjects, len(tc.expectedObjectsToBeConfigured))
 
 			for _, expectedObj := range tc.expectedObjectsToBeConfigured {
@@ -4762,7 +4785,7 @@ func TestParser_ConfiguredKubernetesObjects(t *testing.T) {
 	}
 }
 
-func mustNewParser(t *testing.T, storer store.Storer) *Parser {
+func mustNewParser(t testing.TB, storer store.Storer) *Parser {
 	p, err := NewParser(zapr.NewLogger(zap.NewNop()), storer,
 		FeatureFlags{
 			// We'll assume these are true for all tests.
@@ -4815,3 +4838,84 @@ func TestTargetsForEndpoints(t *testing.T) {
 
 	require.Equal(t, wantTargets, targets)
 }
+
+func BenchmarkParser_BuildKongConfig(b *testing.B) {
+	const objectsCount = 1000
+
+	var (
+		objects  store.FakeObjects
+		pathType = netv1.PathTypePrefix
+	)
+	for i := 0; i < objectsCount; i++ {
+		name := fmt.Sprintf("object-%d", i)
+		objects.Services = append(objects.Services, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+		})
+		objects.EndpointSlices = append(objects.EndpointSlices, &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: name},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{{
+				Addresses:  []string{fmt.Sprintf("10.0.%d.%d", i/256, i%256)},
+				Conditions: discoveryv1.EndpointConditions{Ready: lo.ToPtr(true)},
+			}},
+			Ports: builder.NewEndpointPort(80).WithName("").WithProtocol(corev1.ProtocolTCP).IntoSlice(),
+		})
+		objects.HTTPRoutes = append(objects.HTTPRoutes, &gatewayapi.HTTPRoute{
+			TypeMeta:   metav1.TypeMeta{Kind: "HTTPRoute", APIVersion: gatewayv1beta1.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayapi.HTTPRouteSpec{
+				Rules: []gatewayapi.HTTPRouteRule{{
+					Matches:     builder.NewHTTPRouteMatch().WithPathPrefix("/httproute/" + name).ToSlice(),
+					BackendRefs: builder.NewHTTPBackendRef(name).WithPort(80).ToSlice(),
+				}},
+			},
+		})
+		objects.IngressesV1 = append(objects.IngressesV1, &netv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Annotations: map[string]string{annotations.IngressClassKey: annotations.DefaultIngressClass},
+			},
+			Spec: netv1.IngressSpec{
+				Rules: []netv1.IngressRule{{
+					IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{
+						Paths: []netv1.HTTPIngressPath{{
+							Path:     "/ingress/" + name,
+							PathType: &pathType,
+							Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+								Name: name,
+								Port: netv1.ServiceBackendPort{Number: 80},
+							}},
+						}},
+					}},
+				}},
+			},
+		})
+	}
+	s, err := store.NewFakeStore(objects)
+	require.NoError(b, err)
+
+	for _, tc := range []struct {
+		name        string
+		concurrency int
+	}{
+		{name: "sequential", concurrency: 1},
+		{name: "concurrent", concurrency: 0},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			p := mustNewParser(b, s)
+			p.concurrency = tc.concurrency
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				result := p.BuildKongConfig(context.Background())
+				require.Len(b, result.KongState.Upstreams, objectsCount*2)
+			}
+		})
+	}
+}
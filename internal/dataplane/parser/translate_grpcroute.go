@@ -29,16 +29,18 @@ func (p *Parser) ingressRulesFromGRPCRoutes() ingressRules {
 	}
 
 	var errs []error
-	for _, grpcroute := range grpcRouteList {
-		if err := p.ingressRulesFromGRPCRoute(&result, grpcroute); err != nil {
-			err = fmt.Errorf("GRPCRoute %s/%s can't be routed: %w", grpcroute.Namespace, grpcroute.Name, err)
-			errs = append(errs, err)
-		} else {
-			// at this point the object has been configured and can be
-			// reported as successfully parsed.
-			p.registerSuccessfullyParsedObject(grpcroute)
-		}
-	}
+	translateObjectsConcurrently(p, &result, grpcRouteList, p.referenceGrantsDependency(), p.ingressRulesFromGRPCRoute,
+		func(grpcroute *gatewayapi.GRPCRoute, err error) {
+			if err != nil {
+				err = fmt.Errorf("GRPCRoute %s/%s can't be routed: %w", grpcroute.Namespace, grpcroute.Name, err)
+				errs = append(errs, err)
+			} else {
+				// at this point the object has been configured and can be
+				// reported as successfully parsed.
+				p.registerSuccessfullyParsedObject(grpcroute)
+			}
+		},
+	)
 
 	if len(errs) > 0 {
 		for _, err := range errs {
@@ -35,15 +35,17 @@ func (p *Parser) ingressRulesFromHTTPRoutes() ingressRules {
 		return result
 	}
 
-	for _, httproute := range httpRouteList {
-		if err := p.ingressRulesFromHTTPRoute(&result, httproute); err != nil {
-			p.registerTranslationFailure(fmt.Sprintf("HTTPRoute can't be routed: %s", err), httproute)
-		} else {
-			// at this point the object has been configured and can be
-			// reported as successfully parsed.
-			p.registerSuccessfullyParsedObject(httproute)
-		}
-	}
+	translateObjectsConcurrently(p, &result, httpRouteList, p.referenceGrantsDependency(), p.ingressRulesFromHTTPRoute,
+		func(httproute *gatewayapi.HTTPRoute, err error) {
+			if err != nil {
+				p.registerTranslationFailure(fmt.Sprintf("HTTPRoute can't be routed: %s", err), httproute)
+			} else {
+				// at this point the object has been configured and can be
+				// reported as successfully parsed.
+				p.registerSuccessfullyParsedObject(httproute)
+			}
+		},
+	)
 
 	return result
 }
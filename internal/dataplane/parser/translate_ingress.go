@@ -44,12 +44,7 @@ func (p *Parser) ingressRulesFromIngressV1() ingressRules {
 	}
 
 	// Translate Ingress objects into Kong Services.
-	servicesCache := IngressesV1ToKongServices(
-		p.featureFlags,
-		ingressList,
-		icp,
-		p.parsedObjectsCollector,
-	)
+	servicesCache := p.ingressesV1ToKongServices(ingressList, icp)
 	for i := range servicesCache {
 		service := servicesCache[i]
 		if err := translators.MaybeRewriteURI(&service, p.featureFlags.RewriteURIs); err != nil {
@@ -88,6 +83,43 @@ func IngressesV1ToKongServices(
 	}, parsedObjectsCollector)
 }
 
+// ingressesV1ToKongServices translates IngressV1 objects into Kong Services. Ingresses are translated one by one
+// concurrently, reusing their previous translation if they didn't change when the translation cache is enabled.
+// Kong Services shared by multiple Ingresses get routes of all of them, in the order of ingresses.
+func (p *Parser) ingressesV1ToKongServices(
+	ingresses []*netv1.Ingress,
+	icp kongv1alpha1.IngressClassParametersSpec,
+) KongServicesCache {
+	var dependencyVersions []string
+	if p.translationCache != nil {
+		dependencyVersions = []string{fmt.Sprintf("%+v", icp)}
+	}
+	translated := mapConcurrently(p, ingresses, func(ingress **netv1.Ingress) ingressRules {
+		rules, _ := p.translationCache.translate(*ingress, dependencyVersions, func(rules *ingressRules) error {
+			// Parsed objects are registered below, as a cached translation doesn't register them.
+			rules.ServiceNameToServices = IngressesV1ToKongServices(
+				p.featureFlags, []*netv1.Ingress{*ingress}, icp, (*ObjectsCollector)(nil),
+			)
+			return nil
+		})
+		return rules
+	})
+
+	servicesCache := make(KongServicesCache)
+	for i, rules := range translated {
+		p.registerSuccessfullyParsedObject(ingresses[i])
+
+		for name, service := range rules.ServiceNameToServices {
+			if existing, ok := servicesCache[name]; ok {
+				existing.Routes = append(existing.Routes, service.Routes...)
+				service = existing
+			}
+			servicesCache[name] = service
+		}
+	}
+	return servicesCache
+}
+
 // getDefaultBackendService picks the oldest Ingress with a DefaultBackend defined and returns a Kong Service for it.
 func getDefaultBackendService(allDefaultBackends []netv1.Ingress, expressionRoutes bool) (kongstate.Service, bool) {
 	sort.SliceStable(allDefaultBackends, func(i, j int) bool {
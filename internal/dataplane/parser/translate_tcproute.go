@@ -23,16 +23,18 @@ func (p *Parser) ingressRulesFromTCPRoutes() ingressRules {
 	}
 
 	var errs []error
-	for _, tcproute := range tcpRouteList {
-		if err := p.ingressRulesFromTCPRoute(&result, tcproute); err != nil {
-			err = fmt.Errorf("TCPRoute %s/%s can't be routed: %w", tcproute.Namespace, tcproute.Name, err)
-			errs = append(errs, err)
-		} else {
-			// at this point the object has been configured and can be
-			// reported as successfully parsed.
-			p.registerSuccessfullyParsedObject(tcproute)
-		}
-	}
+	translateObjectsConcurrently(p, &result, tcpRouteList, p.referenceGrantsDependency(), p.ingressRulesFromTCPRoute,
+		func(tcproute *gatewayapi.TCPRoute, err error) {
+			if err != nil {
+				err = fmt.Errorf("TCPRoute %s/%s can't be routed: %w", tcproute.Namespace, tcproute.Name, err)
+				errs = append(errs, err)
+			} else {
+				// at this point the object has been configured and can be
+				// reported as successfully parsed.
+				p.registerSuccessfullyParsedObject(tcproute)
+			}
+		},
+	)
 
 	if p.featureFlags.ExpressionRoutes {
 		applyExpressionToIngressRules(&result)
@@ -28,16 +28,19 @@ func (p *Parser) ingressRulesFromTLSRoutes() ingressRules {
 	}
 
 	var errs []error
-	for _, tlsroute := range tlsRouteList {
-		if err := p.ingressRulesFromTLSRoute(&result, tlsroute); err != nil {
-			err = fmt.Errorf("TLSRoute %s/%s can't be routed: %w", tlsroute.Namespace, tlsroute.Name, err)
-			errs = append(errs, err)
-		} else {
-			// at this point the object has been configured and can be
-			// reported as successfully parsed.
-			p.registerSuccessfullyParsedObject(tlsroute)
-		}
-	}
+	// TLSRoutes depend on Gateways as well, as their listeners determine whether TLS is passed through.
+	translateObjectsConcurrently(p, &result, tlsRouteList, p.referenceGrantsAndGatewaysDependency(), p.ingressRulesFromTLSRoute,
+		func(tlsroute *gatewayapi.TLSRoute, err error) {
+			if err != nil {
+				err = fmt.Errorf("TLSRoute %s/%s can't be routed: %w", tlsroute.Namespace, tlsroute.Name, err)
+				errs = append(errs, err)
+			} else {
+				// at this point the object has been configured and can be
+				// reported as successfully parsed.
+				p.registerSuccessfullyParsedObject(tlsroute)
+			}
+		},
+	)
 
 	if p.featureFlags.ExpressionRoutes {
 		applyExpressionToIngressRules(&result)
@@ -22,24 +22,32 @@ func (p *Parser) ingressRulesFromUDPRoutes() ingressRules {
 		return result
 	}
 
-	var errs []error
+	var (
+		errs           []error
+		validUDPRoutes = make([]*gatewayapi.UDPRoute, 0, len(udpRouteList))
+	)
 	for _, udproute := range udpRouteList {
 		if err := validateUDPRoute(udproute); err != nil {
 			errs = append(errs, err)
 			p.registerTranslationFailure(err.Error(), udproute)
 			continue
 		}
-
-		if err := p.ingressRulesFromUDPRoute(&result, udproute); err != nil {
-			err = fmt.Errorf("UDPRoute %s/%s can't be routed: %w", udproute.Namespace, udproute.Name, err)
-			errs = append(errs, err)
-		} else {
-			// at this point the object has been configured and can be
-			// reported as successfully parsed.
-			p.registerSuccessfullyParsedObject(udproute)
-		}
+		validUDPRoutes = append(validUDPRoutes, udproute)
 	}
 
+	translateObjectsConcurrently(p, &result, validUDPRoutes, p.referenceGrantsDependency(), p.ingressRulesFromUDPRoute,
+		func(udproute *gatewayapi.UDPRoute, err error) {
+			if err != nil {
+				err = fmt.Errorf("UDPRoute %s/%s can't be routed: %w", udproute.Namespace, udproute.Name, err)
+				errs = append(errs, err)
+			} else {
+				// at this point the object has been configured and can be
+				// reported as successfully parsed.
+				p.registerSuccessfullyParsedObject(udproute)
+			}
+		},
+	)
+
 	// Translate generated Kong Route to expression based route.
 	if p.featureFlags.ExpressionRoutes {
 		applyExpressionToIngressRules(&result)
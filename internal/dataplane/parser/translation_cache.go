@@ -0,0 +1,218 @@
+package parser
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+)
+
+// translationCache caches results of translating individual Kubernetes objects into ingress rules, so that only
+// objects whose translation inputs changed since the previous BuildKongConfig call get translated again.
+// A result is reused as long as the object's resourceVersion and versions of other objects its translation
+// depends on (e.g. ReferenceGrants) don't change. Objects without a resourceVersion are never cached.
+//
+// Only the translation of individual objects is cached. The stages combining translated objects with Services,
+// EndpointSlices, Secrets, plugins and consumers run on every build, hence these are not dependencies of cached
+// results.
+//
+// Its methods are safe to call with a nil receiver, which means the cache is disabled. translate is safe for
+// concurrent use, so objects can be translated concurrently within a build.
+type translationCache struct {
+	lock    sync.Mutex
+	entries map[translationCacheKey]*translationCacheEntry
+
+	// build is incremented with every build, so that entries of objects that were not translated in the last
+	// build (e.g. deleted ones) can be evicted.
+	build uint64
+
+	// referenceGrantsVersion and gatewaysVersion are versions of all ReferenceGrants and Gateways in the store
+	// at the beginning of the current build. Empty when unknown.
+	referenceGrantsVersion string
+	gatewaysVersion        string
+
+	// hits and misses count cache lookups in the current build.
+	hits, misses int
+}
+
+type translationCacheKey struct {
+	// kind is the Go type of the object, as typed objects in the store don't necessarily have TypeMeta set.
+	kind      string
+	namespace string
+	name      string
+}
+
+type translationCacheEntry struct {
+	// version identifies the object's resourceVersion and versions of its dependencies the entry was
+	// translated with.
+	version string
+	rules   ingressRules
+	err     error
+
+	lastBuild uint64
+}
+
+func newTranslationCache() *translationCache {
+	return &translationCache{
+		entries: make(map[translationCacheKey]*translationCacheEntry),
+	}
+}
+
+// startBuild prepares the cache for a new build by capturing versions of objects that translation of many
+// objects depends on.
+func (c *translationCache) startBuild(s store.Storer) {
+	if c == nil {
+		return
+	}
+
+	c.build++
+	c.hits, c.misses = 0, 0
+
+	c.referenceGrantsVersion = ""
+	if grants, err := s.ListReferenceGrants(); err == nil {
+		c.referenceGrantsVersion = objectsVersion(grants)
+	}
+	c.gatewaysVersion = ""
+	if gateways, err := s.ListGateways(); err == nil {
+		c.gatewaysVersion = objectsVersion(gateways)
+	}
+}
+
+// finishBuild evicts entries of objects that were not translated in the current build.
+func (c *translationCache) finishBuild() {
+	if c == nil {
+		return
+	}
+
+	for key, entry := range c.entries {
+		if entry.lastBuild != c.build {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// invalidate evicts all entries, e.g. when the way objects are translated changes.
+func (c *translationCache) invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries = make(map[translationCacheKey]*translationCacheEntry)
+}
+
+// translate returns ingress rules translated from obj. When the object was translated with the same
+// resourceVersion and dependency versions before, a copy of the cached result is returned. Otherwise,
+// translate is called and its result is cached. An empty dependency version means the version is unknown,
+// in which case the object is not cached.
+//
+// The returned rules are owned by the caller. When the translation fails, the rules translated before
+// the failure are returned along with the error, as translate would leave them in the rules it was given.
+func (c *translationCache) translate(
+	obj client.Object, dependencyVersions []string, translate func(*ingressRules) error,
+) (ingressRules, error) {
+	translateNew := func() (ingressRules, error) {
+		rules := newIngressRules()
+		err := translate(&rules)
+		return rules, err
+	}
+	if c == nil {
+		return translateNew()
+	}
+
+	version, ok := translationVersion(obj, dependencyVersions)
+	if !ok {
+		c.lock.Lock()
+		c.misses++
+		c.lock.Unlock()
+		return translateNew()
+	}
+
+	key := translationCacheKey{
+		kind:      fmt.Sprintf("%T", obj),
+		namespace: obj.GetNamespace(),
+		name:      obj.GetName(),
+	}
+	c.lock.Lock()
+	if entry, ok := c.entries[key]; ok && entry.version == version {
+		c.hits++
+		entry.lastBuild = c.build
+		c.lock.Unlock()
+		// Entries are never modified once stored, so it's safe to copy the entry outside the lock.
+		return entry.rules.deepCopy(), entry.err
+	}
+	c.misses++
+	c.lock.Unlock()
+
+	rules, err := translateNew()
+	entry := &translationCacheEntry{
+		version:   version,
+		rules:     rules.deepCopy(),
+		err:       err,
+		lastBuild: c.build,
+	}
+	c.lock.Lock()
+	c.entries[key] = entry
+	c.lock.Unlock()
+	return rules, err
+}
+
+// translationVersion returns the version of the object's translation inputs. It returns false when any of
+// them is unknown.
+func translationVersion(obj client.Object, dependencyVersions []string) (string, bool) {
+	resourceVersion := obj.GetResourceVersion()
+	if resourceVersion == "" {
+		return "", false
+	}
+	for _, v := range dependencyVersions {
+		if v == "" {
+			return "", false
+		}
+	}
+	return strings.Join(append([]string{resourceVersion}, dependencyVersions...), "/"), true
+}
+
+// objectsVersion returns a version of the set of objects that changes whenever any of them is created, updated
+// or deleted. It returns an empty string when any of the objects lacks a resourceVersion.
+func objectsVersion[T client.Object](objs []T) string {
+	keys := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		resourceVersion := obj.GetResourceVersion()
+		if resourceVersion == "" {
+			return ""
+		}
+		keys = append(keys, obj.GetNamespace()+"/"+obj.GetName()+"@"+resourceVersion)
+	}
+	sort.Strings(keys)
+
+	h := fnv.New64a()
+	for _, key := range keys {
+		_, _ = h.Write([]byte(key))
+		_, _ = h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+// referenceGrantsDependency returns dependency versions of translation of Gateway API routes, which depends
+// on ReferenceGrants permitting their backendRefs.
+func (p *Parser) referenceGrantsDependency() []string {
+	if p.translationCache == nil {
+		return nil
+	}
+	return []string{p.translationCache.referenceGrantsVersion}
+}
+
+// referenceGrantsAndGatewaysDependency returns dependency versions of translation of Gateway API routes that
+// depend on their parent Gateways in addition to ReferenceGrants.
+func (p *Parser) referenceGrantsAndGatewaysDependency() []string {
+	if p.translationCache == nil {
+		return nil
+	}
+	return []string{p.translationCache.referenceGrantsVersion, p.translationCache.gatewaysVersion}
+}
@@ -0,0 +1,194 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/zapr"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/kongstate"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/builder"
+)
+
+// emptyPluginSchemas is a plugins.SchemaStore returning an empty schema for all plugins.
+type emptyPluginSchemas struct{}
+
+func (emptyPluginSchemas) Schema(context.Context, string) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+
+func TestParser_IncrementalTranslation(t *testing.T) {
+	var (
+		logger            = zapr.NewLogger(zap.NewNop())
+		httpRouteTypeMeta = metav1.TypeMeta{Kind: "HTTPRoute", APIVersion: gatewayv1beta1.GroupVersion.String()}
+		services          = []*corev1.Service{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "httpbin", Namespace: "default", ResourceVersion: "1"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "other", ResourceVersion: "1"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+			},
+		}
+		httpRoute = func(name, path string, backendRef gatewayapi.HTTPBackendRef, resourceVersion string) *gatewayapi.HTTPRoute {
+			return &gatewayapi.HTTPRoute{
+				TypeMeta:   httpRouteTypeMeta,
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", ResourceVersion: resourceVersion},
+				Spec: gatewayapi.HTTPRouteSpec{
+					Rules: []gatewayapi.HTTPRouteRule{{
+						Matches:     builder.NewHTTPRouteMatch().WithPathPrefix(path).ToSlice(),
+						BackendRefs: []gatewayapi.HTTPBackendRef{backendRef},
+					}},
+				},
+			}
+		}
+		ingress = func(name, path string) *netv1.Ingress {
+			pathType := netv1.PathTypePrefix
+			return &netv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            name,
+					Namespace:       "default",
+					ResourceVersion: "1",
+					Annotations:     map[string]string{annotations.IngressClassKey: annotations.DefaultIngressClass},
+				},
+				Spec: netv1.IngressSpec{
+					Rules: []netv1.IngressRule{{
+						IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{
+							Paths: []netv1.HTTPIngressPath{{
+								Path:     path,
+								PathType: &pathType,
+								Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+									Name: "httpbin",
+									Port: netv1.ServiceBackendPort{Number: 80},
+								}},
+							}},
+						}},
+					}},
+				},
+			}
+		}
+		referenceGrant = &gatewayapi.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "other", ResourceVersion: "1"},
+			Spec: gatewayapi.ReferenceGrantSpec{
+				From: []gatewayapi.ReferenceGrantFrom{{
+					Group:     gatewayapi.Group(gatewayv1beta1.GroupName),
+					Kind:      gatewayapi.Kind("HTTPRoute"),
+					Namespace: gatewayapi.Namespace("default"),
+				}},
+				To: []gatewayapi.ReferenceGrantTo{{
+					Group: gatewayapi.Group(""),
+					Kind:  gatewayapi.Kind("Service"),
+				}},
+			},
+		}
+		routeA = httpRoute("route-a", "/a", builder.NewHTTPBackendRef("httpbin").WithPort(80).Build(), "1")
+		routeB = httpRoute("route-b", "/b", builder.NewHTTPBackendRef("backend").WithNamespace("other").WithGroup("").WithKind("Service").WithPort(80).Build(), "1")
+		// Both Ingresses use the same Service, hence they share a Kong Service.
+		ingressA = ingress("ingress-a", "/ingress-a")
+		ingressB = ingress("ingress-b", "/ingress-b")
+	)
+
+	cacheStores := store.NewCacheStores()
+	for _, obj := range []client.Object{services[0], services[1], routeA, routeB, ingressA, ingressB, referenceGrant} {
+		require.NoError(t, cacheStores.Add(obj))
+	}
+	s := store.New(cacheStores, annotations.DefaultIngressClass, logger)
+
+	incrementalParser, err := NewParser(logger, s, FeatureFlags{IncrementalTranslation: true})
+	require.NoError(t, err)
+
+	// requireIdenticalToFullTranslation builds the Kong configuration incrementally and verifies it's identical
+	// to the configuration built by a parser translating all objects from scratch.
+	requireIdenticalToFullTranslation := func(t *testing.T) {
+		fullParser, err := NewParser(logger, s, FeatureFlags{})
+		require.NoError(t, err)
+		require.Equal(t, kongConfigAsYAML(t, fullParser), kongConfigAsYAML(t, incrementalParser))
+	}
+
+	t.Log("all objects are translated initially")
+	requireIdenticalToFullTranslation(t)
+	require.Equal(t, 4, incrementalParser.translationCache.misses)
+	require.Equal(t, 0, incrementalParser.translationCache.hits)
+
+	t.Log("unchanged objects are not translated again")
+	requireIdenticalToFullTranslation(t)
+	require.Equal(t, 0, incrementalParser.translationCache.misses)
+	require.Equal(t, 4, incrementalParser.translationCache.hits)
+
+	t.Log("only changed object is translated again")
+	require.NoError(t, cacheStores.Add(httpRoute("route-a", "/changed", builder.NewHTTPBackendRef("httpbin").WithPort(80).Build(), "2")))
+	requireIdenticalToFullTranslation(t)
+	require.Equal(t, 1, incrementalParser.translationCache.misses)
+	require.Equal(t, 3, incrementalParser.translationCache.hits)
+
+	t.Log("objects depending on a changed ReferenceGrant are translated again")
+	require.NoError(t, cacheStores.Delete(referenceGrant))
+	requireIdenticalToFullTranslation(t)
+	require.Equal(t, 2, incrementalParser.translationCache.misses)
+	require.Equal(t, 2, incrementalParser.translationCache.hits)
+
+	t.Log("translation of a deleted object is evicted")
+	require.NoError(t, cacheStores.Delete(ingressB))
+	requireIdenticalToFullTranslation(t)
+	require.Equal(t, 0, incrementalParser.translationCache.misses)
+	require.Equal(t, 3, incrementalParser.translationCache.hits)
+	require.Len(t, incrementalParser.translationCache.entries, 3)
+
+	t.Log("objects without resourceVersion are always translated")
+	ingressWithoutVersion := ingress("ingress-c", "/ingress-c")
+	ingressWithoutVersion.ResourceVersion = ""
+	require.NoError(t, cacheStores.Add(ingressWithoutVersion))
+	requireIdenticalToFullTranslation(t)
+	requireIdenticalToFullTranslation(t)
+	require.Equal(t, 1, incrementalParser.translationCache.misses)
+	require.Equal(t, 3, incrementalParser.translationCache.hits)
+}
+
+func kongConfigAsYAML(t *testing.T, p *Parser) string {
+	result := p.BuildKongConfig(context.Background())
+	content := deckgen.ToDeckContent(context.Background(), p.logger, result.KongState, deckgen.GenerateDeckContentParams{
+		PluginSchemas: emptyPluginSchemas{},
+	})
+	b, err := yaml.Marshal(content)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestTranslationCache_ReturnsRulesOwnedByCaller(t *testing.T) {
+	cache := newTranslationCache()
+	obj := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "default", ResourceVersion: "1"}}
+	translate := func(rules *ingressRules) error {
+		rules.ServiceNameToServices["service"] = kongstate.Service{
+			Service: kong.Service{Name: kong.String("service")},
+			Routes:  []kongstate.Route{{Route: kong.Route{Name: kong.String("route")}}},
+		}
+		return nil
+	}
+
+	cache.startBuild(store.New(store.NewCacheStores(), annotations.DefaultIngressClass, zapr.NewLogger(zap.NewNop())))
+	rules, err := cache.translate(obj, nil, translate)
+	require.NoError(t, err)
+	// Modify the returned rules like later stages of the translation do.
+	*rules.ServiceNameToServices["service"].Routes[0].Name = "modified"
+	*rules.ServiceNameToServices["service"].Name = "modified"
+
+	rules, err = cache.translate(obj, nil, translate)
+	require.NoError(t, err)
+	require.Equal(t, 1, cache.hits)
+	require.Equal(t, "route", *rules.ServiceNameToServices["service"].Routes[0].Name)
+	require.Equal(t, "service", *rules.ServiceNameToServices["service"].Name)
+}
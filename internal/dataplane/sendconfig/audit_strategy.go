@@ -0,0 +1,126 @@
+package sendconfig
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+)
+
+// UpdateStrategyWithAudit decorates any UpdateStrategy to record its updates in an audit log.
+type UpdateStrategyWithAudit struct {
+	decorated   UpdateStrategy
+	auditLogger *audit.Logger
+	gatewayURL  string
+	logger      logr.Logger
+}
+
+func NewUpdateStrategyWithAudit(
+	decorated UpdateStrategy,
+	auditLogger *audit.Logger,
+	gatewayURL string,
+	logger logr.Logger,
+) UpdateStrategyWithAudit {
+	return UpdateStrategyWithAudit{
+		decorated:   decorated,
+		auditLogger: auditLogger,
+		gatewayURL:  gatewayURL,
+		logger:      logger,
+	}
+}
+
+// Update calls the decorated UpdateStrategy.Update and records its outcome in the audit log along with the hash and
+// entity counts of the configuration and Kubernetes objects that triggered the update (when they're attached to ctx
+// with audit.WithTriggers). Updates skipped due to a backoff strategy are recorded as skipped.
+func (s UpdateStrategyWithAudit) Update(ctx context.Context, targetContent ContentWithHash) (
+	err error,
+	resourceErrors []ResourceError,
+	resourceErrorsParseErr error,
+) {
+	record := audit.Record{
+		Time:           time.Now(),
+		Kind:           audit.KindConfigPush,
+		GatewayURL:     s.gatewayURL,
+		ConfigHash:     hex.EncodeToString(targetContent.Hash),
+		EntityCounts:   countEntities(targetContent.Content),
+		UpdateStrategy: s.decorated.Type(),
+	}
+	if triggers, ok := audit.TriggersFromContext(ctx); ok {
+		record.TriggeredBy = &triggers
+	}
+
+	err, resourceErrors, resourceErrorsParseErr = s.decorated.Update(ctx, targetContent)
+
+	record.DurationMilliseconds = float64(time.Since(record.Time).Microseconds()) / 1000
+	switch {
+	case errors.As(err, &UpdateSkippedDueToBackoffStrategyError{}):
+		record.Outcome = audit.OutcomeSkipped
+		record.Error = err.Error()
+	case err != nil:
+		record.Outcome = audit.OutcomeFailure
+		record.Error = err.Error()
+		if apiErrs := deckerrors.ExtractAPIErrors(err); len(apiErrs) > 0 {
+			record.StatusCode = apiErrs[0].Code()
+		}
+	default:
+		record.Outcome = audit.OutcomeSuccess
+	}
+	if auditErr := s.auditLogger.Record(record); auditErr != nil {
+		s.logger.Error(auditErr, "failed to record configuration push in the audit log")
+	}
+
+	return err, resourceErrors, resourceErrorsParseErr
+}
+
+func (s UpdateStrategyWithAudit) MetricsProtocol() metrics.Protocol {
+	return s.decorated.MetricsProtocol()
+}
+
+func (s UpdateStrategyWithAudit) Type() string {
+	return fmt.Sprintf("WithAudit(%s)", s.decorated.Type())
+}
+
+// countEntities counts Kong entities in the content by their type, including entities nested in other ones.
+func countEntities(content *file.Content) map[string]int {
+	if content == nil {
+		return nil
+	}
+
+	counts := map[string]int{
+		"services":        len(content.Services),
+		"routes":          len(content.Routes),
+		"plugins":         len(content.Plugins),
+		"consumers":       len(content.Consumers),
+		"consumer_groups": len(content.ConsumerGroups),
+		"upstreams":       len(content.Upstreams),
+		"targets":         0,
+		"certificates":    len(content.Certificates),
+		"ca_certificates": len(content.CACertificates),
+		"vaults":          len(content.Vaults),
+	}
+	for _, s := range content.Services {
+		counts["routes"] += len(s.Routes)
+		counts["plugins"] += len(s.Plugins)
+		for _, r := range s.Routes {
+			counts["plugins"] += len(r.Plugins)
+		}
+	}
+	for _, r := range content.Routes {
+		counts["plugins"] += len(r.Plugins)
+	}
+	for _, c := range content.Consumers {
+		counts["plugins"] += len(c.Plugins)
+	}
+	for _, u := range content.Upstreams {
+		counts["targets"] += len(u.Targets)
+	}
+	return counts
+}
@@ -0,0 +1,119 @@
+package sendconfig_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/clock"
+)
+
+func TestUpdateStrategyWithAudit(t *testing.T) {
+	content := sendconfig.ContentWithHash{
+		Content: &file.Content{
+			Services: []file.FService{
+				{
+					Service: kong.Service{Name: kong.String("service")},
+					Routes: []*file.FRoute{
+						{Route: kong.Route{Name: kong.String("route-1")}},
+						{Route: kong.Route{Name: kong.String("route-2")}},
+					},
+				},
+			},
+			Plugins:   []file.FPlugin{{Plugin: kong.Plugin{Name: kong.String("key-auth")}}},
+			Upstreams: []file.FUpstream{{Targets: []*file.FTarget{{}, {}, {}}}},
+		},
+		Hash: []byte{0xab, 0xcd},
+	}
+	triggers := audit.Triggers{Objects: []audit.ObjectRef{{Kind: "Ingress", Namespace: "default", Name: "echo"}}}
+	ctx := audit.WithTriggers(context.Background(), triggers)
+
+	testCases := []struct {
+		name            string
+		decorated       sendconfig.UpdateStrategy
+		expectedOutcome audit.Outcome
+		expectError     bool
+	}{
+		{
+			name:            "successful update",
+			decorated:       newMockUpdateStrategy(true),
+			expectedOutcome: audit.OutcomeSuccess,
+		},
+		{
+			name:            "failed update",
+			decorated:       newMockUpdateStrategy(false),
+			expectedOutcome: audit.OutcomeFailure,
+			expectError:     true,
+		},
+		{
+			name: "update skipped due to backoff",
+			decorated: sendconfig.NewUpdateStrategyWithBackoff(
+				newMockUpdateStrategy(true), newMockBackoffStrategy(false), logr.Discard(),
+			),
+			expectedOutcome: audit.OutcomeSkipped,
+			expectError:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			strategy := sendconfig.NewUpdateStrategyWithAudit(
+				tc.decorated, audit.NewLogger(buf), "http://localhost:8001", logr.Discard(),
+			)
+
+			err, _, _ := strategy.Update(ctx, content)
+			if tc.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var record audit.Record
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+			require.Equal(t, audit.KindConfigPush, record.Kind)
+			require.Equal(t, "http://localhost:8001", record.GatewayURL)
+			require.Equal(t, tc.expectedOutcome, record.Outcome)
+			require.Equal(t, "abcd", record.ConfigHash)
+			require.Equal(t, tc.decorated.Type(), record.UpdateStrategy)
+			require.Equal(t, &triggers, record.TriggeredBy)
+			require.Equal(t, 1, record.EntityCounts["services"])
+			require.Equal(t, 2, record.EntityCounts["routes"])
+			require.Equal(t, 1, record.EntityCounts["plugins"])
+			require.Equal(t, 1, record.EntityCounts["upstreams"])
+			require.Equal(t, 3, record.EntityCounts["targets"])
+			require.Equal(t, 0, record.EntityCounts["consumers"])
+			if tc.expectError {
+				require.Equal(t, err.Error(), record.Error)
+			}
+		})
+	}
+}
+
+func TestDefaultUpdateStrategyResolver_ResolveUpdateStrategy_Audit(t *testing.T) {
+	resolver := sendconfig.NewDefaultUpdateStrategyResolver(
+		sendconfig.Config{InMemory: true},
+		logr.Discard(),
+		sendconfig.WithAuditLogger(audit.NewLogger(&bytes.Buffer{})),
+	)
+
+	client, err := adminapi.NewTestClient("http://localhost:8001")
+	require.NoError(t, err)
+	require.Equal(t, "WithAudit(InMemory)", resolver.ResolveUpdateStrategy(client).Type())
+
+	client.AttachBackoffStrategy(adminapi.NewGatewayBackoffStrategy(adminapi.GatewayBackoffConfig{
+		InitialInterval: adminapi.DefaultGatewayBackoffInitialInterval,
+		MaxInterval:     adminapi.DefaultGatewayBackoffMaxInterval,
+	}, clock.System{}))
+	require.Equal(t, "WithAudit(WithBackoff(InMemory))", resolver.ResolveUpdateStrategy(client).Type(),
+		"audit should be the outermost decorator to record updates skipped due to backoff")
+}
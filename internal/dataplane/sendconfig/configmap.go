@@ -0,0 +1,195 @@
+package sendconfig
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+const (
+	// ConfigMapConfigKey is the key of ConfigMaps' data holding a shard of the configuration.
+	ConfigMapConfigKey = "kong.json"
+	// ConfigMapHashAnnotation is the annotation of the first shard ConfigMap holding the hash of the configuration.
+	ConfigMapHashAnnotation = "konghq.com/declarative-config-hash"
+	// ConfigMapShardsAnnotation is the annotation of the first shard ConfigMap holding the number of shards.
+	ConfigMapShardsAnnotation = "konghq.com/declarative-config-shards"
+	// ConfigMapLabel is the label of all shard ConfigMaps holding the name of the first shard ConfigMap.
+	ConfigMapLabel = "konghq.com/declarative-config"
+
+	// DefaultConfigMapShardSize is the default maximum size of a single shard of the configuration. It leaves
+	// room for ConfigMap's metadata within the 1MiB limit of Kubernetes objects.
+	DefaultConfigMapShardSize = 900 * 1024
+)
+
+// UpdateStrategyConfigMaps implements the UpdateStrategy interface. It writes Kong's DB-less configuration to
+// a set of ConfigMaps, so that configurations exceeding the size limit of a single ConfigMap can be stored.
+// The configuration is split into shards stored under ConfigMapConfigKey of ConfigMaps named <name>, <name>-1,
+// <name>-2, and so on. The first ConfigMap is written last and annotated with the number of shards
+// (ConfigMapShardsAnnotation) and the configuration's hash (ConfigMapHashAnnotation), so that the configuration
+// is written only when it changes. The configuration is restored by concatenating the shards in order.
+type UpdateStrategyConfigMaps struct {
+	client          client.Client
+	namespace       string
+	name            string
+	shardSize       int
+	configConverter ContentToDBLessConfigConverter
+	logger          logr.Logger
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update;delete
+
+func NewUpdateStrategyConfigMaps(
+	client client.Client,
+	nn k8stypes.NamespacedName,
+	shardSize int,
+	configConverter ContentToDBLessConfigConverter,
+	logger logr.Logger,
+) UpdateStrategyConfigMaps {
+	return UpdateStrategyConfigMaps{
+		client:          client,
+		namespace:       nn.Namespace,
+		name:            nn.Name,
+		shardSize:       shardSize,
+		configConverter: configConverter,
+		logger:          logger,
+	}
+}
+
+func (s UpdateStrategyConfigMaps) Update(ctx context.Context, targetState ContentWithHash) (
+	err error,
+	resourceErrors []ResourceError,
+	resourceErrorsParseErr error,
+) {
+	config, err := targetState.dblessConfig(s.configConverter)
+	if err != nil {
+		return err, nil, nil
+	}
+
+	previousShardsCount, err := s.currentShardsCount(ctx)
+	if err != nil {
+		return err, nil, nil
+	}
+
+	shards := splitIntoShards(config, s.shardSize)
+	// The first shard is written last as it carries the hash and number of shards.
+	for i := len(shards) - 1; i >= 0; i-- {
+		annotations := map[string]string{}
+		if i == 0 {
+			annotations[ConfigMapHashAnnotation] = hex.EncodeToString(targetState.Hash)
+			annotations[ConfigMapShardsAnnotation] = strconv.Itoa(len(shards))
+		}
+		if err := s.writeShard(ctx, i, shards[i], annotations); err != nil {
+			return err, nil, nil
+		}
+	}
+
+	// Remove shards that are not needed anymore.
+	for i := len(shards); i < previousShardsCount; i++ {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: s.shardName(i)}}
+		if err := s.client.Delete(ctx, cm); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting configmap %s/%s: %w", s.namespace, cm.Name, err), nil, nil
+		}
+	}
+	s.logger.V(util.DebugLevel).Info("kong configuration written to configmaps", "target", s.Target(), "shards", len(shards))
+
+	return nil, nil, nil
+}
+
+func (s UpdateStrategyConfigMaps) HasConfigurationChanged(ctx context.Context, hash []byte) (bool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.client.Get(ctx, k8stypes.NamespacedName{Namespace: s.namespace, Name: s.name}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("getting configmap %s: %w", s.Target(), err)
+	}
+	return cm.Annotations[ConfigMapHashAnnotation] != hex.EncodeToString(hash), nil
+}
+
+func (s UpdateStrategyConfigMaps) Target() string {
+	return s.namespace + "/" + s.name
+}
+
+func (s UpdateStrategyConfigMaps) MetricsProtocol() metrics.Protocol {
+	return metrics.ProtocolConfigMap
+}
+
+func (s UpdateStrategyConfigMaps) Type() string {
+	return "ConfigMaps"
+}
+
+// currentShardsCount returns the number of shards the configuration is currently stored in.
+func (s UpdateStrategyConfigMaps) currentShardsCount(ctx context.Context) (int, error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.client.Get(ctx, k8stypes.NamespacedName{Namespace: s.namespace, Name: s.name}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("getting configmap %s: %w", s.Target(), err)
+	}
+	count, err := strconv.Atoi(cm.Annotations[ConfigMapShardsAnnotation])
+	if err != nil {
+		// The annotation is missing or invalid, so only the first shard is known to exist.
+		return 1, nil //nolint:nilerr
+	}
+	return count, nil
+}
+
+func (s UpdateStrategyConfigMaps) writeShard(ctx context.Context, index int, data string, annotations map[string]string) error {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: s.shardName(index)}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, s.client, cm, func() error {
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels[ConfigMapLabel] = s.name
+		if cm.Annotations == nil {
+			cm.Annotations = map[string]string{}
+		}
+		for k, v := range annotations {
+			cm.Annotations[k] = v
+		}
+		cm.Data = map[string]string{ConfigMapConfigKey: data}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("writing configmap %s/%s: %w", s.namespace, cm.Name, err)
+	}
+	return nil
+}
+
+func (s UpdateStrategyConfigMaps) shardName(index int) string {
+	if index == 0 {
+		return s.name
+	}
+	return fmt.Sprintf("%s-%d", s.name, index)
+}
+
+// splitIntoShards splits data into shards of at most shardSize bytes, without splitting UTF-8 encoded characters
+// (ConfigMaps' data has to be valid UTF-8). It always returns at least one shard.
+func splitIntoShards(data []byte, shardSize int) []string {
+	var shards []string
+	for len(data) > shardSize {
+		cut := shardSize
+		for cut > 0 && !utf8.RuneStart(data[cut]) {
+			cut--
+		}
+		if cut == 0 {
+			cut = shardSize
+		}
+		shards = append(shards, string(data[:cut]))
+		data = data[cut:]
+	}
+	return append(shards, string(data))
+}
@@ -0,0 +1,105 @@
+package sendconfig_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+)
+
+func TestUpdateStrategyConfigMaps(t *testing.T) {
+	ctx := context.Background()
+	const (
+		namespace = "kong"
+		name      = "kong-config"
+		shardSize = 512
+	)
+	cl := fake.NewClientBuilder().Build()
+	s := sendconfig.NewUpdateStrategyConfigMaps(
+		cl,
+		k8stypes.NamespacedName{Namespace: namespace, Name: name},
+		shardSize,
+		sendconfig.DefaultContentToDBLessConfigConverter{},
+		logr.Discard(),
+	)
+
+	contentWithServices := func(n int) *file.Content {
+		content := &file.Content{FormatVersion: "3.0"}
+		for i := 0; i < n; i++ {
+			content.Services = append(content.Services, file.FService{
+				Service: kong.Service{
+					Name: kong.String(fmt.Sprintf("service-%d", i)),
+					Host: kong.String("żółć.example.com"), // Non-ASCII characters make shards' boundaries fall within runes.
+				},
+			})
+		}
+		return content
+	}
+
+	// readConfig concatenates shards in order and returns the configuration along with the number of shards.
+	readConfig := func(t *testing.T) (sendconfig.DBLessConfig, int) {
+		var cms corev1.ConfigMapList
+		require.NoError(t, cl.List(ctx, &cms, client.InNamespace(namespace), client.MatchingLabels{sendconfig.ConfigMapLabel: name}))
+
+		first := &corev1.ConfigMap{}
+		require.NoError(t, cl.Get(ctx, k8stypes.NamespacedName{Namespace: namespace, Name: name}, first))
+		require.Equal(t, fmt.Sprint(len(cms.Items)), first.Annotations[sendconfig.ConfigMapShardsAnnotation])
+
+		var sb strings.Builder
+		for i := range cms.Items {
+			shardName := name
+			if i > 0 {
+				shardName = fmt.Sprintf("%s-%d", name, i)
+			}
+			cm := &corev1.ConfigMap{}
+			require.NoError(t, cl.Get(ctx, k8stypes.NamespacedName{Namespace: namespace, Name: shardName}, cm))
+			require.LessOrEqual(t, len(cm.Data[sendconfig.ConfigMapConfigKey]), shardSize)
+			sb.WriteString(cm.Data[sendconfig.ConfigMapConfigKey])
+		}
+
+		var config sendconfig.DBLessConfig
+		require.NoError(t, json.Unmarshal([]byte(sb.String()), &config))
+		return config, len(cms.Items)
+	}
+
+	hash := []byte{0xaa}
+	changed, err := s.HasConfigurationChanged(ctx, hash)
+	require.NoError(t, err)
+	require.True(t, changed, "configuration should be considered changed when no configmap was written yet")
+
+	t.Log("writing a configuration that spans multiple shards")
+	err, _, _ = s.Update(ctx, sendconfig.ContentWithHash{Content: contentWithServices(20), Hash: hash})
+	require.NoError(t, err)
+	config, shards := readConfig(t)
+	require.Len(t, config.Services, 20)
+	require.Greater(t, shards, 2)
+
+	changed, err = s.HasConfigurationChanged(ctx, hash)
+	require.NoError(t, err)
+	require.False(t, changed, "configuration with the recorded hash should not be considered changed")
+
+	t.Log("writing a smaller configuration removes shards that are not needed anymore")
+	newHash := []byte{0xbb}
+	err, _, _ = s.Update(ctx, sendconfig.ContentWithHash{Content: contentWithServices(1), Hash: newHash})
+	require.NoError(t, err)
+	config, shards = readConfig(t)
+	require.Len(t, config.Services, 1)
+	require.Equal(t, 1, shards)
+
+	changed, err = s.HasConfigurationChanged(ctx, hash)
+	require.NoError(t, err)
+	assert.True(t, changed, "configuration with the previous hash should be considered changed")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
 	"github.com/kong/deck/diff"
 	"github.com/kong/deck/dump"
 	"github.com/kong/deck/file"
@@ -24,6 +25,7 @@ type UpdateStrategyDBMode struct {
 	version     semver.Version
 	concurrency int
 	isKonnect   bool
+	logger      logr.Logger
 }
 
 func NewUpdateStrategyDBMode(
@@ -31,12 +33,14 @@ func NewUpdateStrategyDBMode(
 	dumpConfig dump.Config,
 	version semver.Version,
 	concurrency int,
+	logger logr.Logger,
 ) UpdateStrategyDBMode {
 	return UpdateStrategyDBMode{
 		client:      client,
 		dumpConfig:  dumpConfig,
 		version:     version,
 		concurrency: concurrency,
+		logger:      logger,
 	}
 }
 
@@ -45,8 +49,9 @@ func NewUpdateStrategyDBModeKonnect(
 	dumpConfig dump.Config,
 	version semver.Version,
 	concurrency int,
+	logger logr.Logger,
 ) UpdateStrategyDBMode {
-	s := NewUpdateStrategyDBMode(client, dumpConfig, version, concurrency)
+	s := NewUpdateStrategyDBMode(client, dumpConfig, version, concurrency, logger)
 	s.isKonnect = true
 	return s
 }
@@ -79,7 +84,10 @@ func (s UpdateStrategyDBMode) Update(ctx context.Context, targetContent ContentW
 
 	_, errs, _ := syncer.Solve(ctx, s.concurrency, false, false)
 	if errs != nil {
-		return deckutils.ErrArray{Errors: errs}, nil, nil
+		// Errors of entities being created or updated are attributed to Kubernetes resources using the target
+		// state, while errors of entities being deleted can only be found in the current state.
+		resourceErrors := parseDeckEntityErrors(errs, s.logger, ts, cs)
+		return deckutils.ErrArray{Errors: errs}, resourceErrors, nil
 	}
 
 	return nil, nil, nil
@@ -107,7 +115,9 @@ func (s UpdateStrategyDBMode) targetState(
 	currentState *state.KongState,
 	targetContent *file.Content,
 ) (*state.KongState, error) {
-	rawState, err := file.Get(ctx, targetContent, file.RenderConfig{
+	// decK modifies the content when building the state from it (e.g. it sets routes and plugins' references to
+	// entities they're nested in), while the same content is shared by all clients it's sent to concurrently.
+	rawState, err := file.Get(ctx, targetContent.DeepCopy(), file.RenderConfig{
 		CurrentState: currentState,
 		KongVersion:  s.version,
 	}, s.dumpConfig, s.client)
@@ -0,0 +1,93 @@
+package sendconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/diff"
+	deckutils "github.com/kong/deck/utils"
+	"github.com/samber/lo"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// Drift describes how the managed entities in a Kong Gateway differ from the desired configuration as a result
+// of changes made out of band (e.g. through the Admin API).
+type Drift struct {
+	// Added are managed entities that exist in Kong, but are not in the desired configuration.
+	Added []util.DriftedEntity `json:"added"`
+
+	// Removed are entities of the desired configuration that are missing in Kong.
+	Removed []util.DriftedEntity `json:"removed"`
+
+	// Modified are entities of the desired configuration that differ in Kong.
+	Modified []util.DriftedEntity `json:"modified"`
+}
+
+// Empty tells whether there's no drift.
+func (d Drift) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// DetectDrift compares the state of the Kong Gateway with targetContent without modifying it. Only entities
+// tagged with the configured filter tags are taken into account, so entities not managed by the controller
+// are not considered a drift. It's supported only for Kong Gateways running in DB mode. targetContent is not
+// modified, so it can be shared by detections for multiple Kong Gateways running concurrently.
+func DetectDrift(
+	ctx context.Context,
+	client UpdateClient,
+	config Config,
+	targetContent ContentWithHash,
+	logger logr.Logger,
+) (Drift, error) {
+	if client.IsKonnect() || config.InMemory {
+		return Drift{}, fmt.Errorf("drift detection is supported only for Kong Gateways in DB mode")
+	}
+	return newUpdateStrategyDBMode(client, config, logger).DetectDrift(ctx, targetContent)
+}
+
+// DetectDrift compares the current state of Kong with targetContent using decK's syncer in dry mode.
+func (s UpdateStrategyDBMode) DetectDrift(ctx context.Context, targetContent ContentWithHash) (Drift, error) {
+	cs, err := s.currentState(ctx)
+	if err != nil {
+		return Drift{}, fmt.Errorf("failed getting current state for %s: %w", s.client.BaseRootURL(), err)
+	}
+
+	ts, err := s.targetState(ctx, cs, targetContent.Content)
+	if err != nil {
+		return Drift{}, deckerrors.ConfigConflictError{Err: err}
+	}
+
+	syncer, err := diff.NewSyncer(diff.SyncerOpts{
+		CurrentState:    cs,
+		TargetState:     ts,
+		KongClient:      s.client,
+		SilenceWarnings: true,
+		IsKonnect:       s.isKonnect,
+	})
+	if err != nil {
+		return Drift{}, fmt.Errorf("creating a new syncer for %s: %w", s.client.BaseRootURL(), err)
+	}
+
+	// Changes are collected by the syncer without synchronization, hence a single goroutine is used.
+	_, errs, changes := syncer.Solve(ctx, 1, true, true)
+	if errs != nil {
+		return Drift{}, fmt.Errorf("diffing configuration of %s: %w", s.client.BaseRootURL(), deckutils.ErrArray{Errors: errs})
+	}
+
+	// decK describes changes that would bring Kong to the desired state, so entities it would create are the ones
+	// removed from Kong out of band and vice versa.
+	return Drift{
+		Added:    toDriftedEntities(changes.Deleting),
+		Removed:  toDriftedEntities(changes.Creating),
+		Modified: toDriftedEntities(changes.Updating),
+	}, nil
+}
+
+func toDriftedEntities(entities []diff.EntityState) []util.DriftedEntity {
+	return lo.Map(entities, func(e diff.EntityState, _ int) util.DriftedEntity {
+		return util.DriftedEntity{Kind: e.Kind, Name: e.Name}
+	})
+}
@@ -0,0 +1,146 @@
+package sendconfig_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// servicesEndpointMock is a mock of Kong Admin API in DB mode with the given services. Other entities are empty.
+type servicesEndpointMock struct {
+	services []kong.Service
+}
+
+func (m servicesEndpointMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	data := []kong.Service{}
+	if r.URL.Path == "/services" {
+		data = m.services
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data, "next": nil})
+}
+
+type updateClientMock struct {
+	client *kong.Client
+}
+
+func (c updateClientMock) IsKonnect() bool              { return false }
+func (c updateClientMock) KonnectControlPlane() string  { return "" }
+func (c updateClientMock) AdminAPIClient() *kong.Client { return c.client }
+
+func TestDetectDrift(t *testing.T) {
+	managedService := func(name, host string) kong.Service {
+		return kong.Service{
+			ID:       kong.String(name + "-id"),
+			Name:     kong.String(name),
+			Host:     kong.String(host),
+			Port:     kong.Int(80),
+			Protocol: kong.String("http"),
+			Path:     kong.String("/"),
+			Tags:     kong.StringSlice("managed-by-ingress-controller"),
+		}
+	}
+	content, err := sendconfig.PrepareContent(&file.Content{
+		FormatVersion: "3.0",
+		Services: []file.FService{
+			{Service: managedService("in-sync", "in-sync.example.com")},
+			{Service: managedService("modified", "modified.example.com")},
+			{Service: managedService("removed", "removed.example.com")},
+		},
+	}, false)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(servicesEndpointMock{services: []kong.Service{
+		managedService("in-sync", "in-sync.example.com"),
+		managedService("modified", "changed-out-of-band.example.com"),
+		managedService("added", "added.example.com"),
+	}})
+	t.Cleanup(server.Close)
+	client, err := kong.NewClient(kong.String(server.URL), server.Client())
+	require.NoError(t, err)
+
+	config := sendconfig.Config{
+		Version:     semver.MustParse("3.4.0"),
+		Concurrency: 1,
+		FilterTags:  []string{"managed-by-ingress-controller"},
+	}
+	drift, err := sendconfig.DetectDrift(context.Background(), updateClientMock{client: client}, config, content, logr.Discard())
+	require.NoError(t, err)
+	require.Equal(t, sendconfig.Drift{
+		Added:    []util.DriftedEntity{{Kind: "service", Name: "added"}},
+		Removed:  []util.DriftedEntity{{Kind: "service", Name: "removed"}},
+		Modified: []util.DriftedEntity{{Kind: "service", Name: "modified"}},
+	}, drift)
+	require.False(t, drift.Empty())
+
+	t.Run("DB-less gateways are not supported", func(t *testing.T) {
+		config := config
+		config.InMemory = true
+		_, err := sendconfig.DetectDrift(context.Background(), updateClientMock{client: client}, config, content, logr.Discard())
+		require.Error(t, err)
+	})
+}
+
+func TestDetectDrift_ContentSharedByClients(t *testing.T) {
+	// Plugins nested in routes nested in services are the entities decK modifies in place when building the
+	// target state from the content.
+	content, err := sendconfig.PrepareContent(&file.Content{
+		FormatVersion: "3.0",
+		Services: []file.FService{
+			{
+				Service: kong.Service{ID: kong.String("service-id"), Name: kong.String("service"), Host: kong.String("example.com")},
+				Routes: []*file.FRoute{
+					{
+						Route: kong.Route{ID: kong.String("route-id"), Name: kong.String("route"), Paths: kong.StringSlice("/")},
+						Plugins: []*file.FPlugin{
+							{Plugin: kong.Plugin{ID: kong.String("plugin-id"), Name: kong.String("key-auth")}},
+						},
+					},
+				},
+			},
+		},
+	}, false)
+	require.NoError(t, err)
+	config := sendconfig.Config{Version: semver.MustParse("3.4.0"), Concurrency: 1}
+
+	const clientsCount = 2
+	var wg sync.WaitGroup
+	drifts := make([]sendconfig.Drift, clientsCount)
+	errs := make([]error, clientsCount)
+	for i := 0; i < clientsCount; i++ {
+		server := httptest.NewServer(emptyDBModeMock{})
+		t.Cleanup(server.Close)
+		client, err := kong.NewClient(kong.String(server.URL), server.Client())
+		require.NoError(t, err)
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			drifts[i], errs[i] = sendconfig.DetectDrift(context.Background(), updateClientMock{client: client}, config, content, logr.Discard())
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range drifts {
+		require.NoError(t, errs[i])
+		require.Len(t, drifts[i].Removed, 3, "service, route and plugin should be missing in the gateway")
+	}
+	require.Nil(t, content.Content.Services[0].Routes[0].Plugins[0].Route,
+		"content shared by clients should not be modified by drift detection")
+}
@@ -0,0 +1,117 @@
+package sendconfig
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/state"
+	decktypes "github.com/kong/deck/types"
+	"github.com/samber/lo"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckerrors"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// parseDeckEntityErrors maps errors of decK's sync operations on Kong entities to errors associated with Kubernetes
+// resources. Resources are identified by tags of the entities, which are looked up in the states in order (i.e. the
+// target state first, so that errors of entities being deleted are attributed using the current state).
+func parseDeckEntityErrors(errs []error, logger logr.Logger, states ...*state.KongState) []ResourceError {
+	var resourceErrors []ResourceError
+	for _, entityErr := range deckerrors.ExtractEntityErrors(errs) {
+		tags, ok := lookUpEntityTags(entityErr.Kind, entityErr.Entity, states...)
+		if !ok {
+			logger.V(util.DebugLevel).Info("could not find entity of a failed operation", "kind", entityErr.Kind, "entity", entityErr.Entity)
+			continue
+		}
+
+		raw := rawResourceError{
+			Name: entityErr.Entity,
+			Tags: tags,
+			Problems: map[string]string{
+				// Match the key of entity errors reported by DB-less Kong Gateways.
+				fmt.Sprintf("%s:%s", entityErr.Kind, entityErr.Entity): entityErr.Err.Error(),
+			},
+		}
+		parsed, err := parseRawResourceError(raw)
+		if err != nil {
+			logger.Error(err, "entity tags missing fields", "name", entityErr.Entity)
+			continue
+		}
+		resourceErrors = append(resourceErrors, parsed)
+	}
+	return resourceErrors
+}
+
+// lookUpEntityTags returns tags of the entity of decK's kind with the given console representation found in
+// the first of the states having it.
+func lookUpEntityTags(kind string, entity string, states ...*state.KongState) ([]string, bool) {
+	for _, s := range states {
+		if s == nil {
+			continue
+		}
+		if tags, ok := entityTags(s, kind, entity); ok {
+			return tags, true
+		}
+	}
+	return nil, false
+}
+
+func entityTags(s *state.KongState, kind string, entity string) ([]string, bool) {
+	switch decktypes.EntityType(kind) { //nolint:exhaustive
+	case decktypes.Service:
+		return findEntityTags(s.Services.GetAll, entity, func(e *state.Service) []*string { return e.Tags })
+	case decktypes.Route:
+		return findEntityTags(s.Routes.GetAll, entity, func(e *state.Route) []*string { return e.Tags })
+	case decktypes.Upstream:
+		return findEntityTags(s.Upstreams.GetAll, entity, func(e *state.Upstream) []*string { return e.Tags })
+	case decktypes.Target:
+		return findEntityTags(s.Targets.GetAll, entity, func(e *state.Target) []*string { return e.Tags })
+	case decktypes.Plugin:
+		return findEntityTags(s.Plugins.GetAll, entity, func(e *state.Plugin) []*string { return e.Tags })
+	case decktypes.Certificate:
+		return findEntityTags(s.Certificates.GetAll, entity, func(e *state.Certificate) []*string { return e.Tags })
+	case decktypes.SNI:
+		return findEntityTags(s.SNIs.GetAll, entity, func(e *state.SNI) []*string { return e.Tags })
+	case decktypes.CACertificate:
+		return findEntityTags(s.CACertificates.GetAll, entity, func(e *state.CACertificate) []*string { return e.Tags })
+	case decktypes.Consumer:
+		return findEntityTags(s.Consumers.GetAll, entity, func(e *state.Consumer) []*string { return e.Tags })
+	case decktypes.ConsumerGroup:
+		return findEntityTags(s.ConsumerGroups.GetAll, entity, func(e *state.ConsumerGroup) []*string { return e.Tags })
+	case decktypes.KeyAuth:
+		return findEntityTags(s.KeyAuths.GetAll, entity, func(e *state.KeyAuth) []*string { return e.Tags })
+	case decktypes.HMACAuth:
+		return findEntityTags(s.HMACAuths.GetAll, entity, func(e *state.HMACAuth) []*string { return e.Tags })
+	case decktypes.JWTAuth:
+		return findEntityTags(s.JWTAuths.GetAll, entity, func(e *state.JWTAuth) []*string { return e.Tags })
+	case decktypes.BasicAuth:
+		return findEntityTags(s.BasicAuths.GetAll, entity, func(e *state.BasicAuth) []*string { return e.Tags })
+	case decktypes.ACLGroup:
+		return findEntityTags(s.ACLGroups.GetAll, entity, func(e *state.ACLGroup) []*string { return e.Tags })
+	case decktypes.OAuth2Cred:
+		return findEntityTags(s.Oauth2Creds.GetAll, entity, func(e *state.Oauth2Credential) []*string { return e.Tags })
+	case decktypes.MTLSAuth:
+		return findEntityTags(s.MTLSAuths.GetAll, entity, func(e *state.MTLSAuth) []*string { return e.Tags })
+	default:
+		return nil, false
+	}
+}
+
+// findEntityTags returns tags of the entity whose console representation (used by decK in its errors) matches
+// the given one.
+func findEntityTags[T interface{ Console() string }](
+	getAll func() ([]T, error),
+	console string,
+	tags func(T) []*string,
+) ([]string, bool) {
+	entities, err := getAll()
+	if err != nil {
+		return nil, false
+	}
+	for _, e := range entities {
+		if e.Console() == console {
+			return lo.Map(tags(e), func(tag *string, _ int) string { return lo.FromPtr(tag) }), true
+		}
+	}
+	return nil, false
+}
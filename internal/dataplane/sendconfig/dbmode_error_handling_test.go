@@ -0,0 +1,97 @@
+package sendconfig
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/zapr"
+	"github.com/kong/deck/state"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseDeckEntityErrors(t *testing.T) {
+	logger := zapr.NewLogger(zap.NewNop())
+	// deckEventErr returns an error the way decK's syncer wraps errors of processing events.
+	deckEventErr := func(op, kind, entity string, cause error) error {
+		return fmt.Errorf("while processing event: %w", fmt.Errorf("{%s} %s %s failed: %w", op, kind, entity, cause))
+	}
+	k8sTags := func(kind, group, version, name, uid string) []*string {
+		return kong.StringSlice(
+			"k8s-name:"+name,
+			"k8s-namespace:default",
+			"k8s-kind:"+kind,
+			"k8s-uid:"+uid,
+			"k8s-group:"+group,
+			"k8s-version:"+version,
+		)
+	}
+
+	targetState, err := state.NewKongState()
+	require.NoError(t, err)
+	require.NoError(t, targetState.Services.Add(state.Service{Service: kong.Service{
+		ID:   kong.String("service-id"),
+		Name: kong.String("default.httpbin.80"),
+		Tags: k8sTags("Service", "", "v1", "httpbin", "service-uid"),
+	}}))
+	require.NoError(t, targetState.Routes.Add(state.Route{Route: kong.Route{
+		ID:      kong.String("route-id"),
+		Name:    kong.String("default.httpbin.httpbin..80"),
+		Service: &kong.Service{ID: kong.String("service-id")},
+		Tags:    k8sTags("Ingress", "networking.k8s.io", "v1", "httpbin", "ingress-uid"),
+	}}))
+	currentState, err := state.NewKongState()
+	require.NoError(t, err)
+	require.NoError(t, currentState.Consumers.Add(state.Consumer{Consumer: kong.Consumer{
+		ID:       kong.String("consumer-id"),
+		Username: kong.String("alice"),
+		Tags:     k8sTags("KongConsumer", "configuration.konghq.com", "v1", "alice", "consumer-uid"),
+	}}))
+
+	apiErr := kong.NewAPIError(http.StatusBadRequest, "schema violation (paths.1: should start with: /)")
+	errs := []error{
+		deckEventErr("Create", "route", "default.httpbin.httpbin..80", apiErr),
+		deckEventErr("Update", "service", "default.httpbin.80", apiErr),
+		deckEventErr("Delete", "consumer", "alice", apiErr),
+		// Errors not associated with entities or associated with unknown entities are skipped.
+		errors.New("failed to sync all entities: context canceled"),
+		deckEventErr("Create", "route", "unknown", apiErr),
+	}
+
+	resourceErrors := parseDeckEntityErrors(errs, logger, targetState, currentState)
+	require.Equal(t, []ResourceError{
+		{
+			Name:       "httpbin",
+			Namespace:  "default",
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1",
+			UID:        "ingress-uid",
+			Problems: map[string]string{
+				"route:default.httpbin.httpbin..80": apiErr.Error(),
+			},
+		},
+		{
+			Name:       "httpbin",
+			Namespace:  "default",
+			Kind:       "Service",
+			APIVersion: "v1",
+			UID:        "service-uid",
+			Problems: map[string]string{
+				"service:default.httpbin.80": apiErr.Error(),
+			},
+		},
+		{
+			Name:       "alice",
+			Namespace:  "default",
+			Kind:       "KongConsumer",
+			APIVersion: "configuration.konghq.com/v1",
+			UID:        "consumer-uid",
+			Problems: map[string]string{
+				"consumer:alice": apiErr.Error(),
+			},
+		},
+	}, resourceErrors)
+}
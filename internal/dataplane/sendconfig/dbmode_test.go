@@ -0,0 +1,94 @@
+package sendconfig_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/dump"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+)
+
+// emptyDBModeMock is a mock of Kong Admin API in DB mode without any entities. Entities written to it are
+// responded with as created, but are not stored. Plugins' schemas have no fields in their configurations.
+type emptyDBModeMock struct{}
+
+func (emptyDBModeMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/schemas/plugins/") {
+		_, _ = w.Write([]byte(`{"fields": [{"config": {"type": "record", "fields": []}}]}`))
+		return
+	}
+	if r.Method == http.MethodGet {
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": []any{}, "next": nil})
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write(body)
+}
+
+func TestUpdateStrategyDBMode_UpdateWithContentSharedByClients(t *testing.T) {
+	// Plugins nested in routes nested in services are the entities decK modifies in place when building the
+	// target state from the content.
+	content, err := sendconfig.PrepareContent(&file.Content{
+		FormatVersion: "3.0",
+		Services: []file.FService{
+			{
+				Service: kong.Service{
+					ID:   kong.String("service-id"),
+					Name: kong.String("service"),
+					Host: kong.String("example.com"),
+				},
+				Routes: []*file.FRoute{
+					{
+						Route: kong.Route{
+							ID:    kong.String("route-id"),
+							Name:  kong.String("route"),
+							Paths: kong.StringSlice("/"),
+						},
+						Plugins: []*file.FPlugin{
+							{Plugin: kong.Plugin{ID: kong.String("plugin-id"), Name: kong.String("key-auth")}},
+						},
+					},
+				},
+			},
+		},
+	}, false)
+	require.NoError(t, err)
+
+	const clientsCount = 2
+	var wg sync.WaitGroup
+	errs := make([]error, clientsCount)
+	for i := 0; i < clientsCount; i++ {
+		server := httptest.NewServer(emptyDBModeMock{})
+		t.Cleanup(server.Close)
+		client, err := kong.NewClient(kong.String(server.URL), server.Client())
+		require.NoError(t, err)
+		strategy := sendconfig.NewUpdateStrategyDBMode(client, dump.Config{}, semver.MustParse("3.4.0"), 1, logr.Discard())
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i], _, _ = strategy.Update(context.Background(), content)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Nil(t, content.Content.Services[0].Routes[0].Plugins[0].Route,
+		"content shared by clients should not be modified by updates")
+}
@@ -0,0 +1,114 @@
+package sendconfig
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// FileHashSuffix is the suffix of the file next to the configuration file that holds the hash of the written
+// configuration.
+const FileHashSuffix = ".sha256"
+
+// UpdateStrategyFile implements the UpdateStrategy interface. It writes Kong's DB-less configuration to a file
+// that Kong Gateway can load with its `declarative_config` setting. The hash of the written configuration is
+// recorded in a file next to it (with the FileHashSuffix suffix), so that the configuration is written only
+// when it changes, also across controller restarts.
+type UpdateStrategyFile struct {
+	path            string
+	configConverter ContentToDBLessConfigConverter
+	logger          logr.Logger
+}
+
+func NewUpdateStrategyFile(
+	path string,
+	configConverter ContentToDBLessConfigConverter,
+	logger logr.Logger,
+) UpdateStrategyFile {
+	return UpdateStrategyFile{
+		path:            path,
+		configConverter: configConverter,
+		logger:          logger,
+	}
+}
+
+func (s UpdateStrategyFile) Update(_ context.Context, targetState ContentWithHash) (
+	err error,
+	resourceErrors []ResourceError,
+	resourceErrorsParseErr error,
+) {
+	config, err := targetState.dblessConfig(s.configConverter)
+	if err != nil {
+		return err, nil, nil
+	}
+
+	// The hash is written after the configuration, so that a failure in between results in the configuration
+	// being written again on the next update.
+	if err := writeFileAtomically(s.path, config); err != nil {
+		return fmt.Errorf("writing kong configuration: %w", err), nil, nil
+	}
+	if err := writeFileAtomically(s.hashPath(), []byte(hex.EncodeToString(targetState.Hash))); err != nil {
+		return fmt.Errorf("writing kong configuration hash: %w", err), nil, nil
+	}
+	s.logger.V(util.DebugLevel).Info("kong configuration written to file", "path", s.path)
+
+	return nil, nil, nil
+}
+
+func (s UpdateStrategyFile) HasConfigurationChanged(_ context.Context, hash []byte) (bool, error) {
+	recordedHash, err := os.ReadFile(s.hashPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return true, nil
+		}
+		return false, fmt.Errorf("reading kong configuration hash: %w", err)
+	}
+	return string(recordedHash) != hex.EncodeToString(hash), nil
+}
+
+func (s UpdateStrategyFile) Target() string {
+	return s.path
+}
+
+func (s UpdateStrategyFile) MetricsProtocol() metrics.Protocol {
+	return metrics.ProtocolFile
+}
+
+func (s UpdateStrategyFile) Type() string {
+	return "File"
+}
+
+func (s UpdateStrategyFile) hashPath() string {
+	return s.path + FileHashSuffix
+}
+
+// writeFileAtomically writes data to a temporary file in the target's directory and renames it to the target,
+// so that readers never observe a partially written file.
+func writeFileAtomically(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(0o644); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
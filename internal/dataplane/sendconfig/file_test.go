@@ -0,0 +1,59 @@
+package sendconfig_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+)
+
+func TestUpdateStrategyFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "kong.json")
+	s := sendconfig.NewUpdateStrategyFile(path, sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard())
+
+	content := &file.Content{
+		FormatVersion: "3.0",
+		Services: []file.FService{
+			{Service: kong.Service{Name: kong.String("svc"), Host: kong.String("example.com")}},
+		},
+	}
+	hash := []byte{0x01, 0x02}
+
+	changed, err := s.HasConfigurationChanged(ctx, hash)
+	require.NoError(t, err)
+	require.True(t, changed, "configuration should be considered changed when no file was written yet")
+
+	err, resourceErrors, parseErr := s.Update(ctx, sendconfig.ContentWithHash{Content: content, Hash: hash})
+	require.NoError(t, err)
+	require.NoError(t, parseErr)
+	require.Empty(t, resourceErrors)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var written sendconfig.DBLessConfig
+	require.NoError(t, json.Unmarshal(b, &written))
+	require.Len(t, written.Services, 1)
+	assert.Equal(t, "svc", *written.Services[0].Name)
+
+	recordedHash, err := os.ReadFile(path + sendconfig.FileHashSuffix)
+	require.NoError(t, err)
+	assert.Equal(t, "0102", string(recordedHash))
+
+	changed, err = s.HasConfigurationChanged(ctx, hash)
+	require.NoError(t, err)
+	assert.False(t, changed, "configuration with the recorded hash should not be considered changed")
+
+	changed, err = s.HasConfigurationChanged(ctx, []byte{0x03})
+	require.NoError(t, err)
+	assert.True(t, changed, "configuration with a different hash should be considered changed")
+}
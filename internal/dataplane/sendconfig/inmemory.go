@@ -1,14 +1,13 @@
 package sendconfig
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
 )
@@ -30,22 +29,53 @@ type ContentToDBLessConfigConverter interface {
 
 // UpdateStrategyInMemory implements the UpdateStrategy interface. It updates Kong's data-plane
 // configuration using its `POST /config` endpoint that is used by ConfigService.ReloadDeclarativeRawConfig.
+// The configuration is serialized while it's being sent, so its serialized form is never kept in memory as a whole.
 type UpdateStrategyInMemory struct {
 	configService   ConfigService
 	configConverter ContentToDBLessConfigConverter
 	logger          logr.Logger
+
+	// compressedConfigService is used to send the configuration compressed with gzip when set, as long as
+	// compressionSupport doesn't tell the Kong Gateway doesn't support it.
+	compressedConfigService ConfigService
+	compressionSupport      *ConfigCompressionSupport
+}
+
+// UpdateStrategyInMemoryOption is a functional option for NewUpdateStrategyInMemory.
+type UpdateStrategyInMemoryOption func(*UpdateStrategyInMemory)
+
+// WithConfigCompression makes the strategy send the configuration compressed with gzip using compressedConfigService.
+// When the Kong Gateway responds with ErrConfigCompressionUnsupported, the configuration is sent uncompressed and
+// this is recorded in compressionSupport, so that next updates don't attempt compression anymore. Kong Gateways
+// not supporting compression may respond with other errors as well (e.g. 400 Bad Request as they fail to parse
+// the configuration), so until the Kong Gateway accepts compressed configuration for the first time, the
+// configuration is sent uncompressed when it responds with any non-2xx status code. If the uncompressed
+// configuration is accepted, compression is recorded as unsupported.
+func WithConfigCompression(
+	compressedConfigService ConfigService,
+	compressionSupport *ConfigCompressionSupport,
+) UpdateStrategyInMemoryOption {
+	return func(s *UpdateStrategyInMemory) {
+		s.compressedConfigService = compressedConfigService
+		s.compressionSupport = compressionSupport
+	}
 }
 
 func NewUpdateStrategyInMemory(
 	configService ConfigService,
 	configConverter ContentToDBLessConfigConverter,
 	logger logr.Logger,
+	opts ...UpdateStrategyInMemoryOption,
 ) UpdateStrategyInMemory {
-	return UpdateStrategyInMemory{
+	s := UpdateStrategyInMemory{
 		configService:   configService,
 		configConverter: configConverter,
 		logger:          logger,
 	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
 }
 
 func (s UpdateStrategyInMemory) Update(ctx context.Context, targetState ContentWithHash) (
@@ -53,17 +83,52 @@ func (s UpdateStrategyInMemory) Update(ctx context.Context, targetState ContentW
 	resourceErrors []ResourceError,
 	resourceErrorsParseErr error,
 ) {
-	dblessConfig := s.configConverter.Convert(targetState.Content)
-	config, err := json.Marshal(dblessConfig)
-	if err != nil {
-		return fmt.Errorf("constructing kong configuration: %w", err), nil, nil
+	config := targetState.convertedDBLessConfig(s.configConverter)
+
+	if s.compressedConfigService != nil && s.compressionSupport.Supported() {
+		errBody, err := s.reloadConfig(ctx, s.compressedConfigService, config, true)
+		switch {
+		case err == nil:
+			s.compressionSupport.SetSupported()
+			return nil, nil, nil
+		case errors.Is(err, ErrConfigCompressionUnsupported):
+			s.logger.Info("Kong Gateway doesn't support compressed configuration, sending it uncompressed")
+			s.compressionSupport.SetUnsupported()
+		case !s.compressionSupport.Known() && errors.As(err, new(*kong.APIError)):
+			s.logger.Info("Kong Gateway rejected compressed configuration, sending it uncompressed", "error", err.Error())
+			errBody, err := s.reloadConfig(ctx, s.configService, config, false)
+			if err == nil {
+				s.logger.Info("Kong Gateway accepted uncompressed configuration, not compressing it anymore")
+				s.compressionSupport.SetUnsupported()
+			}
+			return s.handleReloadResult(errBody, err)
+		default:
+			return s.handleReloadResult(errBody, err)
+		}
 	}
 
-	if errBody, err := s.configService.ReloadDeclarativeRawConfig(ctx, bytes.NewReader(config), true, true); err != nil {
+	errBody, err := s.reloadConfig(ctx, s.configService, config, false)
+	return s.handleReloadResult(errBody, err)
+}
+
+// reloadConfig sends the configuration with configService while it's being serialized (and compressed).
+func (s UpdateStrategyInMemory) reloadConfig(
+	ctx context.Context,
+	configService ConfigService,
+	config DBLessConfig,
+	compress bool,
+) ([]byte, error) {
+	body := newDBLessConfigReader(config, compress)
+	// Closing the reader stops the serialization in case the config service didn't read the whole body.
+	defer body.Close()
+	return configService.ReloadDeclarativeRawConfig(ctx, body, true, true)
+}
+
+func (s UpdateStrategyInMemory) handleReloadResult(errBody []byte, err error) (error, []ResourceError, error) {
+	if err != nil {
 		resourceErrors, parseErr := parseFlatEntityErrors(errBody, s.logger)
 		return err, resourceErrors, parseErr
 	}
-
 	return nil, nil, nil
 }
 
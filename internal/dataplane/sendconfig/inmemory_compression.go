@@ -0,0 +1,100 @@
+package sendconfig
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// ErrConfigCompressionUnsupported is returned by a ConfigService sending compressed configuration when the Kong
+// Gateway responds it doesn't support the compression.
+var ErrConfigCompressionUnsupported = errors.New("kong gateway doesn't support compressed configuration")
+
+const (
+	configCompressionSupportUnknown int32 = iota
+	configCompressionSupported
+	configCompressionUnsupported
+)
+
+// ConfigCompressionSupport keeps track of whether a Kong Gateway supports compressed configuration. It's assumed
+// to support it until it's found not to, but it's known to support it only once it accepted compressed
+// configuration. It's safe for concurrent use.
+type ConfigCompressionSupport struct {
+	state atomic.Int32
+}
+
+// Supported returns false once the Kong Gateway was found not to support compressed configuration.
+func (s *ConfigCompressionSupport) Supported() bool {
+	return s.state.Load() != configCompressionUnsupported
+}
+
+// Known tells whether the Kong Gateway was found to either support compressed configuration or not.
+func (s *ConfigCompressionSupport) Known() bool {
+	return s.state.Load() != configCompressionSupportUnknown
+}
+
+// SetSupported records that the Kong Gateway accepted compressed configuration.
+func (s *ConfigCompressionSupport) SetSupported() {
+	s.state.Store(configCompressionSupported)
+}
+
+// SetUnsupported records that the Kong Gateway doesn't support compressed configuration.
+func (s *ConfigCompressionSupport) SetUnsupported() {
+	s.state.Store(configCompressionUnsupported)
+}
+
+// GzipConfigService is a ConfigService sending configuration compressed with gzip to Kong's `POST /config`
+// endpoint. It expects the configuration it's given to be compressed already.
+type GzipConfigService struct {
+	client *kong.Client
+}
+
+func NewGzipConfigService(client *kong.Client) GzipConfigService {
+	return GzipConfigService{client: client}
+}
+
+// ReloadDeclarativeRawConfig sends the gzip-compressed config to the Kong Gateway. It returns
+// ErrConfigCompressionUnsupported when the Kong Gateway responds with 415 Unsupported Media Type and
+// a *kong.APIError when it responds with any other non-2xx status code.
+func (s GzipConfigService) ReloadDeclarativeRawConfig(
+	ctx context.Context,
+	config io.Reader,
+	checkHash bool,
+	flattenErrors bool,
+) ([]byte, error) {
+	b, err := postConfig(ctx, s.client, config, checkHash, flattenErrors, "gzip")
+	var apiErr *kong.APIError
+	if errors.As(err, &apiErr) && apiErr.Code() == http.StatusUnsupportedMediaType {
+		return b, ErrConfigCompressionUnsupported
+	}
+	return b, err
+}
+
+// newDBLessConfigReader returns a reader of the config serialized to JSON and, when compress is true, compressed
+// with gzip. The config is serialized while it's being read, so that its serialized form isn't kept in memory as
+// a whole. The reader has to be closed to release the serializing goroutine if it's not read to the end.
+func newDBLessConfigReader(config DBLessConfig, compress bool) io.ReadCloser {
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(encodeDBLessConfig(w, config, compress))
+	}()
+	return r
+}
+
+func encodeDBLessConfig(w io.Writer, config DBLessConfig, compress bool) error {
+	if !compress {
+		return json.NewEncoder(w).Encode(config)
+	}
+
+	gw := gzip.NewWriter(w)
+	if err := json.NewEncoder(gw).Encode(config); err != nil {
+		return err
+	}
+	return gw.Close()
+}
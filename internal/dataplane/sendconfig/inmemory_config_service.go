@@ -0,0 +1,83 @@
+package sendconfig
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// UncompressedConfigService is a ConfigService sending uncompressed configuration to Kong's `POST /config` endpoint.
+// Unlike kong.Client's ReloadDeclarativeRawConfig, it returns a *kong.APIError carrying the status code when
+// the Kong Gateway responds with a non-2xx status code, so that the error can be told apart from server
+// and network errors (e.g. by GatewayBackoffStrategy).
+type UncompressedConfigService struct {
+	client *kong.Client
+}
+
+func NewUncompressedConfigService(client *kong.Client) UncompressedConfigService {
+	return UncompressedConfigService{client: client}
+}
+
+// ReloadDeclarativeRawConfig sends the config to the Kong Gateway. It returns a *kong.APIError when the Kong
+// Gateway responds with a non-2xx status code.
+func (s UncompressedConfigService) ReloadDeclarativeRawConfig(
+	ctx context.Context,
+	config io.Reader,
+	checkHash bool,
+	flattenErrors bool,
+) ([]byte, error) {
+	return postConfig(ctx, s.client, config, checkHash, flattenErrors, "")
+}
+
+// postConfig sends the config to Kong's `POST /config` endpoint with the given Content-Encoding (none if empty).
+// It returns the response body along with a *kong.APIError when the Kong Gateway responds with a non-2xx status code.
+func postConfig(
+	ctx context.Context,
+	client *kong.Client,
+	config io.Reader,
+	checkHash bool,
+	flattenErrors bool,
+	contentEncoding string,
+) ([]byte, error) {
+	type sendConfigParams struct {
+		CheckHash     int `url:"check_hash,omitempty"`
+		FlattenErrors int `url:"flatten_errors,omitempty"`
+	}
+	var params sendConfigParams
+	if checkHash {
+		params.CheckHash = 1
+	}
+	if flattenErrors {
+		params.FlattenErrors = 1
+	}
+
+	req, err := client.NewRequest(http.MethodPost, "/config", params, config)
+	if err != nil {
+		return nil, fmt.Errorf("creating new HTTP request for /config: %w", err)
+	}
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+
+	resp, err := client.DoRAW(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed posting new config to /config: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read /config %d status response body: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return b, kong.NewAPIErrorWithRaw(
+			resp.StatusCode,
+			fmt.Sprintf("failed posting new config to /config: got status code %d", resp.StatusCode),
+			b,
+		)
+	}
+	return b, nil
+}
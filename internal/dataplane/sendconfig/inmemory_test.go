@@ -0,0 +1,275 @@
+package sendconfig_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/clock"
+)
+
+// configEndpointMock is a mock of Kong Admin API's `POST /config` endpoint recording configurations it received.
+type configEndpointMock struct {
+	lock sync.Mutex
+	// supportsCompression tells whether the endpoint accepts gzip-compressed configuration.
+	supportsCompression bool
+	// compressionUnsupportedStatus is the status code responded with to gzip-compressed configuration when
+	// it's not supported. Defaults to 415 Unsupported Media Type.
+	compressionUnsupportedStatus int
+	requests                     []configRequest
+}
+
+type configRequest struct {
+	contentEncoding string
+	config          sendconfig.DBLessConfig
+}
+
+func (m *configEndpointMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	contentEncoding := r.Header.Get("Content-Encoding")
+	m.requests = append(m.requests, configRequest{contentEncoding: contentEncoding})
+	body := io.Reader(r.Body)
+	if contentEncoding == "gzip" {
+		if !m.supportsCompression {
+			status := http.StatusUnsupportedMediaType
+			if m.compressionUnsupportedStatus != 0 {
+				status = m.compressionUnsupportedStatus
+			}
+			w.WriteHeader(status)
+			return
+		}
+		gr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body = gr
+	}
+	if err := json.NewDecoder(body).Decode(&m.requests[len(m.requests)-1].config); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (m *configEndpointMock) Requests() []configRequest {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]configRequest(nil), m.requests...)
+}
+
+func TestUpdateStrategyInMemory(t *testing.T) {
+	ctx := context.Background()
+	content, err := sendconfig.PrepareContent(&file.Content{
+		FormatVersion: "3.0",
+		Services: []file.FService{
+			{Service: kong.Service{Name: kong.String("svc"), Host: kong.String("example.com")}},
+		},
+	}, true)
+	require.NoError(t, err)
+
+	setupWithEndpoint := func(t *testing.T, endpoint *configEndpointMock) (*configEndpointMock, *kong.Client) {
+		server := httptest.NewServer(endpoint)
+		t.Cleanup(server.Close)
+		client, err := kong.NewClient(kong.String(server.URL), server.Client())
+		require.NoError(t, err)
+		return endpoint, client
+	}
+	setup := func(t *testing.T, supportsCompression bool) (*configEndpointMock, *kong.Client) {
+		return setupWithEndpoint(t, &configEndpointMock{supportsCompression: supportsCompression})
+	}
+	update := func(t *testing.T, s sendconfig.UpdateStrategyInMemory) {
+		err, resourceErrors, parseErr := s.Update(ctx, content)
+		require.NoError(t, err)
+		require.NoError(t, parseErr)
+		require.Empty(t, resourceErrors)
+	}
+
+	t.Run("uncompressed", func(t *testing.T) {
+		endpoint, client := setup(t, true)
+		s := sendconfig.NewUpdateStrategyInMemory(sendconfig.NewUncompressedConfigService(client), sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard())
+
+		update(t, s)
+		requests := endpoint.Requests()
+		require.Len(t, requests, 1)
+		assert.Empty(t, requests[0].contentEncoding)
+		assert.Equal(t, *content.DBLessConfig, requests[0].config)
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		endpoint, client := setup(t, true)
+		s := sendconfig.NewUpdateStrategyInMemory(sendconfig.NewUncompressedConfigService(client), sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard(),
+			sendconfig.WithConfigCompression(sendconfig.NewGzipConfigService(client), &sendconfig.ConfigCompressionSupport{}),
+		)
+
+		update(t, s)
+		requests := endpoint.Requests()
+		require.Len(t, requests, 1)
+		assert.Equal(t, "gzip", requests[0].contentEncoding)
+		assert.Equal(t, *content.DBLessConfig, requests[0].config)
+	})
+
+	t.Run("falls back to uncompressed when compression is not supported", func(t *testing.T) {
+		endpoint, client := setup(t, false)
+		support := &sendconfig.ConfigCompressionSupport{}
+		s := sendconfig.NewUpdateStrategyInMemory(sendconfig.NewUncompressedConfigService(client), sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard(),
+			sendconfig.WithConfigCompression(sendconfig.NewGzipConfigService(client), support),
+		)
+
+		update(t, s)
+		requests := endpoint.Requests()
+		require.Len(t, requests, 2)
+		assert.Equal(t, "gzip", requests[0].contentEncoding)
+		assert.Empty(t, requests[1].contentEncoding)
+		assert.Equal(t, *content.DBLessConfig, requests[1].config)
+		assert.False(t, support.Supported())
+
+		t.Log("next update doesn't attempt compression anymore")
+		update(t, s)
+		requests = endpoint.Requests()
+		require.Len(t, requests, 3)
+		assert.Empty(t, requests[2].contentEncoding)
+	})
+
+	for _, status := range []int{http.StatusBadRequest, http.StatusInternalServerError} {
+		status := status
+		t.Run(fmt.Sprintf("falls back to uncompressed when compressed configuration is rejected with %d", status), func(t *testing.T) {
+			endpoint, client := setupWithEndpoint(t, &configEndpointMock{compressionUnsupportedStatus: status})
+			support := &sendconfig.ConfigCompressionSupport{}
+			s := sendconfig.NewUpdateStrategyInMemory(sendconfig.NewUncompressedConfigService(client), sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard(),
+				sendconfig.WithConfigCompression(sendconfig.NewGzipConfigService(client), support),
+			)
+
+			update(t, s)
+			requests := endpoint.Requests()
+			require.Len(t, requests, 2)
+			assert.Equal(t, "gzip", requests[0].contentEncoding)
+			assert.Empty(t, requests[1].contentEncoding)
+			assert.Equal(t, *content.DBLessConfig, requests[1].config)
+			assert.False(t, support.Supported())
+
+			t.Log("next update doesn't attempt compression anymore")
+			update(t, s)
+			requests = endpoint.Requests()
+			require.Len(t, requests, 3)
+			assert.Empty(t, requests[2].contentEncoding)
+		})
+	}
+
+	t.Run("doesn't fall back to uncompressed once compression is known to be supported", func(t *testing.T) {
+		endpoint, client := setup(t, true)
+		support := &sendconfig.ConfigCompressionSupport{}
+		s := sendconfig.NewUpdateStrategyInMemory(sendconfig.NewUncompressedConfigService(client), sendconfig.DefaultContentToDBLessConfigConverter{}, logr.Discard(),
+			sendconfig.WithConfigCompression(sendconfig.NewGzipConfigService(client), support),
+		)
+
+		update(t, s)
+		require.True(t, support.Known())
+		require.True(t, support.Supported())
+
+		t.Log("configuration rejected by the Kong Gateway is not sent again uncompressed")
+		endpoint.lock.Lock()
+		endpoint.supportsCompression, endpoint.compressionUnsupportedStatus = false, http.StatusBadRequest
+		endpoint.lock.Unlock()
+		err, _, _ := s.Update(ctx, content)
+		require.Error(t, err)
+		requests := endpoint.Requests()
+		require.Len(t, requests, 2)
+		assert.Equal(t, "gzip", requests[1].contentEncoding)
+		assert.True(t, support.Supported())
+	})
+}
+
+func TestUpdateStrategyInMemory_ConfigRejectedByGatewayIsNotBackedOff(t *testing.T) {
+	ctx := context.Background()
+	content, err := sendconfig.PrepareContent(&file.Content{
+		FormatVersion: "3.0",
+		Services: []file.FService{
+			{Service: kong.Service{Name: kong.String("svc"), Host: kong.String("example.com")}},
+		},
+	}, true)
+	require.NoError(t, err)
+
+	const rejectedConfigResponse = `{
+		"code": 14,
+		"name": "invalid declarative configuration",
+		"message": "declarative config is invalid: {}",
+		"flattened_errors": [],
+		"fields": {}
+	}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/config" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(rejectedConfigResponse))
+	}))
+	t.Cleanup(server.Close)
+	client, err := kong.NewClient(kong.String(server.URL), server.Client())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		opts []sendconfig.UpdateStrategyInMemoryOption
+	}{
+		{
+			name: "uncompressed",
+		},
+		{
+			name: "compressed",
+			opts: []sendconfig.UpdateStrategyInMemoryOption{
+				sendconfig.WithConfigCompression(sendconfig.NewGzipConfigService(client), &sendconfig.ConfigCompressionSupport{}),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			backoffStrategy := adminapi.NewGatewayBackoffStrategy(adminapi.GatewayBackoffConfig{
+				InitialInterval: time.Minute,
+				MaxInterval:     time.Hour,
+			}, clock.System{})
+			s := sendconfig.NewUpdateStrategyWithBackoff(
+				sendconfig.NewUpdateStrategyInMemory(
+					sendconfig.NewUncompressedConfigService(client),
+					sendconfig.DefaultContentToDBLessConfigConverter{},
+					logr.Discard(),
+					tc.opts...,
+				),
+				backoffStrategy,
+				logr.Discard(),
+			)
+
+			err, _, parseErr := s.Update(ctx, content)
+			require.NoError(t, parseErr)
+			var apiErr *kong.APIError
+			require.ErrorAs(t, err, &apiErr)
+			require.Equal(t, http.StatusBadRequest, apiErr.Code())
+
+			t.Log("the rejected config is not sent again, but the circuit is not open for other configs")
+			canUpdate, _ := backoffStrategy.CanUpdate(content.Hash)
+			assert.False(t, canUpdate)
+			canUpdate, _ = backoffStrategy.CanUpdate([]byte("another-config-hash"))
+			assert.True(t, canUpdate)
+			assert.False(t, backoffStrategy.Status().Open)
+		})
+	}
+}
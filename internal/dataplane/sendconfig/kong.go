@@ -37,6 +37,28 @@ type Config struct {
 
 	// ExpressionRoutes indicates whether to use Kong's expression routes.
 	ExpressionRoutes bool
+
+	// DryRun indicates that the configuration should be generated, but never sent to Kong Gateways nor Konnect.
+	DryRun bool
+
+	// CompressDBLessConfig enables compressing configuration sent to DB-less Kong Gateways with gzip, given that
+	// their version supports it.
+	CompressDBLessConfig bool
+
+	// GatewaySyncQuorumPercent is the percentage of Kong Gateways that have to successfully apply the configuration
+	// for the sync to be considered successful. Zero value means that all of them have to.
+	GatewaySyncQuorumPercent int
+}
+
+// GatewaySyncQuorum returns the number of Kong Gateways out of gatewaysCount that have to successfully apply
+// the configuration for the sync to be considered successful.
+func (c Config) GatewaySyncQuorum(gatewaysCount int) int {
+	percent := c.GatewaySyncQuorumPercent
+	if percent <= 0 || percent > 100 {
+		percent = 100
+	}
+	// Round up, so that e.g. 50% of 3 gateways requires 2 of them.
+	return (gatewaysCount*percent + 99) / 100
 }
 
 // Init sets up variables that need external calls.
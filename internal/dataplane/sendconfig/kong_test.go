@@ -0,0 +1,30 @@
+package sendconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+)
+
+func TestConfigGatewaySyncQuorum(t *testing.T) {
+	testCases := []struct {
+		percent       int
+		gatewaysCount int
+		expected      int
+	}{
+		{percent: 0, gatewaysCount: 3, expected: 3},
+		{percent: 100, gatewaysCount: 3, expected: 3},
+		{percent: 50, gatewaysCount: 3, expected: 2},
+		{percent: 50, gatewaysCount: 4, expected: 2},
+		{percent: 1, gatewaysCount: 3, expected: 1},
+		{percent: 50, gatewaysCount: 0, expected: 0},
+		{percent: 150, gatewaysCount: 2, expected: 2},
+	}
+	for _, tc := range testCases {
+		config := sendconfig.Config{GatewaySyncQuorumPercent: tc.percent}
+		require.Equal(t, tc.expected, config.GatewaySyncQuorum(tc.gatewaysCount),
+			"percent: %d, gateways: %d", tc.percent, tc.gatewaysCount)
+	}
+}
@@ -2,19 +2,19 @@ package sendconfig
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/kong/deck/file"
 	"github.com/kong/go-kong/kong"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 
-	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 )
 
@@ -37,26 +37,31 @@ type AdminAPIClient interface {
 	KonnectControlPlane() string
 }
 
-// PerformUpdate writes `targetContent` to Kong Admin API specified by `kongConfig`.
+// PerformUpdate writes `targetContent` to Kong Admin API specified by `kongConfig`. The content is expected to be
+// prepared with PrepareContent, so that it can be shared between clients.
 func PerformUpdate(
 	ctx context.Context,
 	logger logr.Logger,
 	client AdminAPIClient,
 	config Config,
-	targetContent *file.Content,
+	targetContent ContentWithHash,
 	promMetrics *metrics.CtrlFuncMetrics,
 	updateStrategyResolver UpdateStrategyResolver,
 	configChangeDetector ConfigurationChangeDetector,
-) ([]byte, []failures.ResourceFailure, error) {
+) (_ []byte, _ []failures.ResourceFailure, err error) {
+	ctx, span := tracing.StartSpan(ctx, "sendconfig.PerformUpdate",
+		tracing.AttributeKeyGatewayURL.String(client.BaseRootURL()),
+		tracing.AttributeKeyKonnect.Bool(client.IsKonnect()),
+		tracing.AttributeKeyConfigHash.String(hex.EncodeToString(targetContent.Hash)),
+	)
+	defer func() { tracing.EndSpan(span, err) }()
+
 	oldSHA := client.LastConfigSHA()
-	newSHA, err := deckgen.GenerateSHA(targetContent)
-	if err != nil {
-		return oldSHA, []failures.ResourceFailure{}, err
-	}
+	newSHA := targetContent.Hash
 
 	// disable optimization if reverse sync is enabled
 	if !config.EnableReverseSync {
-		configurationChanged, err := configChangeDetector.HasConfigurationChanged(ctx, oldSHA, newSHA, targetContent, client, client.AdminAPIClient())
+		configurationChanged, err := configChangeDetector.HasConfigurationChanged(ctx, oldSHA, newSHA, targetContent.Content, client, client.AdminAPIClient())
 		if err != nil {
 			return nil, []failures.ResourceFailure{}, err
 		}
@@ -66,23 +71,23 @@ func PerformUpdate(
 			} else {
 				logger.V(util.DebugLevel).Info("no configuration change, skipping sync to Kong")
 			}
+			span.SetAttributes(tracing.AttributeKeyUpdateSkipped.Bool(true))
 			return oldSHA, []failures.ResourceFailure{}, nil
 		}
 	}
 
 	updateStrategy := updateStrategyResolver.ResolveUpdateStrategy(client)
 	logger = logger.WithValues("update_strategy", updateStrategy.Type())
+	span.SetAttributes(tracing.AttributeKeyUpdateStrategy.String(updateStrategy.Type()))
 	timeStart := time.Now()
-	err, resourceErrors, resourceErrorsParseErr := updateStrategy.Update(ctx, ContentWithHash{
-		Content: targetContent,
-		Hash:    newSHA,
-	})
+	err, resourceErrors, resourceErrorsParseErr := updateStrategy.Update(ctx, targetContent)
 	duration := time.Since(timeStart)
 
 	metricsProtocol := updateStrategy.MetricsProtocol()
 	if err != nil {
 		// Not pushing metrics in case it's an update skip due to a backoff.
 		if errors.As(err, &UpdateSkippedDueToBackoffStrategyError{}) {
+			span.SetAttributes(tracing.AttributeKeyUpdateSkipped.Bool(true))
 			return nil, []failures.ResourceFailure{}, err
 		}
 
@@ -102,6 +107,46 @@ func PerformUpdate(
 	return newSHA, nil, nil
 }
 
+// PerformDeclarativeConfigOutputUpdate writes `targetContent` to the declarative configuration output unless
+// the output already holds a configuration with the same hash. The content is expected to be prepared with
+// PrepareContent, so that it can be shared between outputs.
+func PerformDeclarativeConfigOutputUpdate(
+	ctx context.Context,
+	logger logr.Logger,
+	output DeclarativeConfigOutput,
+	config Config,
+	targetContent ContentWithHash,
+	promMetrics *metrics.CtrlFuncMetrics,
+) ([]byte, error) {
+	newSHA := targetContent.Hash
+
+	// disable optimization if reverse sync is enabled
+	if !config.EnableReverseSync {
+		configurationChanged, err := output.HasConfigurationChanged(ctx, newSHA)
+		if err != nil {
+			return nil, err
+		}
+		if !configurationChanged {
+			logger.V(util.DebugLevel).Info("no configuration change, skipping write to declarative configuration output")
+			return newSHA, nil
+		}
+	}
+
+	logger = logger.WithValues("update_strategy", output.Type())
+	timeStart := time.Now()
+	err, _, _ := output.Update(ctx, targetContent)
+	duration := time.Since(timeStart)
+
+	if err != nil {
+		promMetrics.RecordPushFailure(output.MetricsProtocol(), duration, output.Target(), 0, err)
+		return nil, err
+	}
+	promMetrics.RecordPushSuccess(output.MetricsProtocol(), duration, output.Target())
+	logger.V(util.InfoLevel).Info("successfully wrote configuration to declarative configuration output")
+
+	return newSHA, nil
+}
+
 // -----------------------------------------------------------------------------
 // Sendconfig - Private Functions
 // -----------------------------------------------------------------------------
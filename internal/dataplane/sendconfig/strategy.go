@@ -2,6 +2,9 @@ package sendconfig
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/deck/dump"
@@ -9,13 +12,61 @@ import (
 	"github.com/kong/go-kong/kong"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/versions"
 )
 
-// ContentWithHash encapsulates file.Content along with its precalculated hash.
+// ContentWithHash encapsulates file.Content along with its precalculated hash and, optionally, its precalculated
+// DB-less form. Precalculating these allows sharing them between all clients the content is sent to.
 type ContentWithHash struct {
 	Content *file.Content
 	Hash    []byte
+	// Size is the size of Content serialized to JSON in bytes.
+	Size int
+
+	// DBLessConfig is Content converted with DefaultContentToDBLessConfigConverter. It's serialized by every
+	// strategy on its own, so that it can be streamed without keeping the serialized form in memory.
+	// When not set, strategies writing DB-less configuration convert Content on their own.
+	DBLessConfig *DBLessConfig
+}
+
+// PrepareContent calculates the hash and size of the content and, when dbless is true, its DB-less form.
+// As the conversion to the DB-less form may modify the content, the content shouldn't be modified afterwards.
+func PrepareContent(content *file.Content, dbless bool) (ContentWithHash, error) {
+	hash, size, err := deckgen.GenerateSHAAndSize(content)
+	if err != nil {
+		return ContentWithHash{}, err
+	}
+	prepared := ContentWithHash{
+		Content: content,
+		Hash:    hash,
+		Size:    size,
+	}
+	if dbless {
+		config := DefaultContentToDBLessConfigConverter{}.Convert(content)
+		prepared.DBLessConfig = &config
+	}
+	return prepared, nil
+}
+
+// convertedDBLessConfig returns the DB-less form of the content, converting it with the converter only when
+// it wasn't precalculated.
+func (c ContentWithHash) convertedDBLessConfig(converter ContentToDBLessConfigConverter) DBLessConfig {
+	if c.DBLessConfig != nil {
+		return *c.DBLessConfig
+	}
+	return converter.Convert(c.Content)
+}
+
+// dblessConfig returns the serialized DB-less form of the content.
+func (c ContentWithHash) dblessConfig(converter ContentToDBLessConfigConverter) ([]byte, error) {
+	config, err := json.Marshal(c.convertedDBLessConfig(converter))
+	if err != nil {
+		return nil, fmt.Errorf("constructing kong configuration: %w", err)
+	}
+	return config, nil
 }
 
 // UpdateStrategy is the way we approach updating data-plane's configuration, depending on its type.
@@ -34,6 +85,19 @@ type UpdateStrategy interface {
 	Type() string
 }
 
+// DeclarativeConfigOutput is an UpdateStrategy writing DB-less configuration to a target other than Kong's
+// Admin API (e.g. a file) that Kong Gateways load their configuration from.
+type DeclarativeConfigOutput interface {
+	UpdateStrategy
+
+	// HasConfigurationChanged tells whether the configuration with the given hash differs from the one
+	// that was last written to the output.
+	HasConfigurationChanged(ctx context.Context, hash []byte) (bool, error)
+
+	// Target returns a human-readable representation of the output's target (e.g. the file's path).
+	Target() string
+}
+
 type UpdateClient interface {
 	IsKonnect() bool
 	KonnectControlPlane() string
@@ -58,27 +122,60 @@ type ResourceError struct {
 type DefaultUpdateStrategyResolver struct {
 	config Config
 	logger logr.Logger
+
+	// compressionSupport holds *ConfigCompressionSupport of Kong Gateways indexed by their Admin API base URL.
+	compressionSupport *sync.Map
+
+	// auditLogger, when set, records updates done with the resolved strategies.
+	auditLogger *audit.Logger
 }
 
-func NewDefaultUpdateStrategyResolver(config Config, logger logr.Logger) DefaultUpdateStrategyResolver {
-	return DefaultUpdateStrategyResolver{
-		config: config,
-		logger: logger,
+// DefaultUpdateStrategyResolverOption is an option of DefaultUpdateStrategyResolver.
+type DefaultUpdateStrategyResolverOption func(*DefaultUpdateStrategyResolver)
+
+// WithAuditLogger makes the resolved strategies record their updates in the audit log.
+func WithAuditLogger(auditLogger *audit.Logger) DefaultUpdateStrategyResolverOption {
+	return func(r *DefaultUpdateStrategyResolver) {
+		r.auditLogger = auditLogger
+	}
+}
+
+func NewDefaultUpdateStrategyResolver(
+	config Config, logger logr.Logger, opts ...DefaultUpdateStrategyResolverOption,
+) DefaultUpdateStrategyResolver {
+	r := DefaultUpdateStrategyResolver{
+		config:             config,
+		logger:             logger,
+		compressionSupport: &sync.Map{},
+	}
+	for _, opt := range opts {
+		opt(&r)
 	}
+	return r
 }
 
 // ResolveUpdateStrategy returns an UpdateStrategy based on the client and configuration.
 // The UpdateStrategy can be either UpdateStrategyDBMode or UpdateStrategyInMemory. Both
 // of them implement different ways to populate Kong instances with data-plane configuration.
 // If the client implements UpdateClientWithBackoff interface, its strategy will be decorated
-// with the backoff strategy it provides.
+// with the backoff strategy it provides. When an audit logger is configured, the strategy will be
+// decorated to record its updates, including the ones skipped due to the backoff strategy.
 func (r DefaultUpdateStrategyResolver) ResolveUpdateStrategy(
 	client UpdateClient,
 ) UpdateStrategy {
 	updateStrategy := r.resolveUpdateStrategy(client)
 
 	if clientWithBackoff, ok := client.(UpdateClientWithBackoff); ok {
-		return NewUpdateStrategyWithBackoff(updateStrategy, clientWithBackoff.BackoffStrategy(), r.logger)
+		// Gateway clients have a backoff strategy attached only when it's enabled.
+		if backoffStrategy := clientWithBackoff.BackoffStrategy(); backoffStrategy != nil {
+			updateStrategy = NewUpdateStrategyWithBackoff(updateStrategy, backoffStrategy, r.logger)
+		}
+	}
+
+	if r.auditLogger != nil {
+		updateStrategy = NewUpdateStrategyWithAudit(
+			updateStrategy, r.auditLogger, client.AdminAPIClient().BaseRootURL(), r.logger,
+		)
 	}
 
 	return updateStrategy
@@ -87,35 +184,57 @@ func (r DefaultUpdateStrategyResolver) ResolveUpdateStrategy(
 func (r DefaultUpdateStrategyResolver) resolveUpdateStrategy(client UpdateClient) UpdateStrategy {
 	adminAPIClient := client.AdminAPIClient()
 
+	if client.IsKonnect() || !r.config.InMemory {
+		return newUpdateStrategyDBMode(client, r.config, r.logger)
+	}
+
+	var inMemoryOpts []UpdateStrategyInMemoryOption
+	if r.config.CompressDBLessConfig && r.config.Version.GTE(versions.DBLessConfigCompressionVersionCutoff) {
+		inMemoryOpts = append(inMemoryOpts, WithConfigCompression(
+			NewGzipConfigService(adminAPIClient),
+			r.configCompressionSupport(adminAPIClient.BaseRootURL()),
+		))
+	}
+	return NewUpdateStrategyInMemory(
+		NewUncompressedConfigService(adminAPIClient),
+		DefaultContentToDBLessConfigConverter{},
+		r.logger,
+		inMemoryOpts...,
+	)
+}
+
+// newUpdateStrategyDBMode returns an UpdateStrategyDBMode for the client.
+func newUpdateStrategyDBMode(client UpdateClient, config Config, logger logr.Logger) UpdateStrategyDBMode {
 	// In case the client communicates with Konnect Admin API, we know it has to use DB-mode. There's no need to check
 	// config.InMemory that is meant for regular Kong Gateway clients.
 	if client.IsKonnect() {
 		return NewUpdateStrategyDBModeKonnect(
-			adminAPIClient,
+			client.AdminAPIClient(),
 			dump.Config{
 				SkipCACerts:         true,
 				KonnectControlPlane: client.KonnectControlPlane(),
 			},
-			r.config.Version,
-			r.config.Concurrency,
-		)
-	}
-
-	if !r.config.InMemory {
-		return NewUpdateStrategyDBMode(
-			adminAPIClient,
-			dump.Config{
-				SkipCACerts:  r.config.SkipCACertificates,
-				SelectorTags: r.config.FilterTags,
-			},
-			r.config.Version,
-			r.config.Concurrency,
+			config.Version,
+			config.Concurrency,
+			logger,
 		)
 	}
 
-	return NewUpdateStrategyInMemory(
-		adminAPIClient,
-		DefaultContentToDBLessConfigConverter{},
-		r.logger,
+	return NewUpdateStrategyDBMode(
+		client.AdminAPIClient(),
+		dump.Config{
+			SkipCACerts:  config.SkipCACertificates,
+			SelectorTags: config.FilterTags,
+		},
+		config.Version,
+		config.Concurrency,
+		logger,
 	)
 }
+
+// configCompressionSupport returns compression support of the Kong Gateway with the given Admin API base URL,
+// which is shared by all strategies resolved for it.
+func (r DefaultUpdateStrategyResolver) configCompressionSupport(baseURL string) *ConfigCompressionSupport {
+	support, _ := r.compressionSupport.LoadOrStore(baseURL, &ConfigCompressionSupport{})
+	return support.(*ConfigCompressionSupport)
+}
@@ -5,14 +5,18 @@ import (
 	"testing"
 
 	"github.com/go-logr/zapr"
+	gojson "github.com/goccy/go-json"
 	"github.com/google/uuid"
+	"github.com/kong/deck/file"
 	"github.com/kong/go-kong/kong"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/clock"
 )
 
 type clientMock struct {
@@ -99,3 +103,56 @@ func TestDefaultUpdateStrategyResolver_ResolveUpdateStrategy(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultUpdateStrategyResolver_ResolveUpdateStrategy_GatewayBackoff(t *testing.T) {
+	resolver := sendconfig.NewDefaultUpdateStrategyResolver(sendconfig.Config{InMemory: true}, zapr.NewLogger(zap.NewNop()))
+
+	t.Run("gateway client without backoff strategy is not decorated", func(t *testing.T) {
+		client, err := adminapi.NewTestClient("http://localhost:8001")
+		require.NoError(t, err)
+		require.Equal(t, "InMemory", resolver.ResolveUpdateStrategy(client).Type())
+	})
+
+	t.Run("gateway client with backoff strategy is decorated", func(t *testing.T) {
+		client, err := adminapi.NewTestClient("http://localhost:8001")
+		require.NoError(t, err)
+		client.AttachBackoffStrategy(adminapi.NewGatewayBackoffStrategy(adminapi.GatewayBackoffConfig{
+			InitialInterval: adminapi.DefaultGatewayBackoffInitialInterval,
+			MaxInterval:     adminapi.DefaultGatewayBackoffMaxInterval,
+		}, clock.System{}))
+		require.Equal(t, "WithBackoff(InMemory)", resolver.ResolveUpdateStrategy(client).Type())
+	})
+}
+
+func TestPrepareContent(t *testing.T) {
+	newContent := func() *file.Content {
+		return &file.Content{
+			FormatVersion: "3.0",
+			Services: []file.FService{
+				{Service: kong.Service{Name: kong.String("svc"), Host: kong.String("example.com")}},
+			},
+		}
+	}
+	expectedHash, err := deckgen.GenerateSHA(newContent())
+	require.NoError(t, err)
+	serialized, err := gojson.Marshal(newContent())
+	require.NoError(t, err)
+
+	t.Run("db mode", func(t *testing.T) {
+		prepared, err := sendconfig.PrepareContent(newContent(), false)
+		require.NoError(t, err)
+		require.Equal(t, expectedHash, prepared.Hash)
+		require.Equal(t, len(serialized), prepared.Size)
+		require.Nil(t, prepared.DBLessConfig)
+	})
+
+	t.Run("dbless", func(t *testing.T) {
+		expectedConfig := sendconfig.DefaultContentToDBLessConfigConverter{}.Convert(newContent())
+
+		prepared, err := sendconfig.PrepareContent(newContent(), true)
+		require.NoError(t, err)
+		require.Equal(t, expectedHash, prepared.Hash)
+		require.NotNil(t, prepared.DBLessConfig)
+		require.Equal(t, expectedConfig, *prepared.DBLessConfig)
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	dataplaneutil "github.com/kong/kubernetes-ingress-controller/v2/internal/util/dataplane"
 )
 
@@ -29,14 +30,38 @@ const (
 	DefaultSyncSeconds float32 = 3.0
 
 	DefaultCacheSyncWaitDuration = 5 * time.Second
+
+	// DefaultSyncDebounce is the default period without changes the event-driven synchronizer waits for
+	// before updating the data-plane.
+	DefaultSyncDebounce = 500 * time.Millisecond
+
+	// DefaultSyncMaxWait is the default maximum period the event-driven synchronizer delays updating
+	// the data-plane for while changes keep coming.
+	DefaultSyncMaxWait = 3 * time.Second
+
+	// DefaultResyncPeriod is the default period of the event-driven synchronizer's periodic updates
+	// of the data-plane that are performed regardless of changes.
+	DefaultResyncPeriod = time.Minute
 )
 
 // -----------------------------------------------------------------------------
 // Synchronizer - Public Types
 // -----------------------------------------------------------------------------
 
+// GatewayClientsChangesNotifier notifies about changes of the set of gateway clients.
+type GatewayClientsChangesNotifier interface {
+	SubscribeToGatewayClientsChanges() (<-chan struct{}, bool)
+}
+
+// UpdateRetryAwareClient is a Client that can tell whether an update should be retried despite succeeding,
+// e.g. because some of the gateways didn't apply the configuration.
+type UpdateRetryAwareClient interface {
+	UpdateRetryNeeded() bool
+}
+
 // Synchronizer is a threadsafe object which starts a goroutine to updates
-// the data-plane at regular intervals.
+// the data-plane at regular intervals or, when event-driven, whenever it's
+// notified about changes.
 type Synchronizer struct {
 	logger logr.Logger
 
@@ -51,6 +76,14 @@ type Synchronizer struct {
 	isServerRunning bool
 	initWaitPeriod  time.Duration
 
+	// event-driven synchronization configuration, enabled when eventDriven is true
+	eventDriven                   bool
+	debounce                      time.Duration
+	maxWait                       time.Duration
+	resyncPeriod                  time.Duration
+	changes                       chan struct{}
+	gatewayClientsChangesNotifier GatewayClientsChangesNotifier
+
 	lock sync.RWMutex
 }
 
@@ -70,6 +103,28 @@ func WithInitCacheSyncDuration(period time.Duration) SynchronizerOption {
 	}
 }
 
+// WithEventDrivenSync returns a SynchronizerOption which makes the synchronizer update the data-plane
+// when notified about changes (see NotifyChange) instead of at regular intervals. An update is performed
+// once no changes were notified for the debounce period, but no later than maxWait after the first of
+// the pending changes. Regardless of changes, the data-plane is updated every resyncPeriod. Failed updates
+// are retried after the stagger period.
+func WithEventDrivenSync(debounce, maxWait, resyncPeriod time.Duration) SynchronizerOption {
+	return func(s *Synchronizer) {
+		s.eventDriven = true
+		s.debounce = debounce
+		s.maxWait = maxWait
+		s.resyncPeriod = resyncPeriod
+	}
+}
+
+// WithGatewayClientsChangesNotifier returns a SynchronizerOption which makes the event-driven synchronizer
+// update the data-plane on changes of the set of gateway clients.
+func WithGatewayClientsChangesNotifier(notifier GatewayClientsChangesNotifier) SynchronizerOption {
+	return func(s *Synchronizer) {
+		s.gatewayClientsChangesNotifier = notifier
+	}
+}
+
 // NewSynchronizer will provide a new Synchronizer object with a specified
 // stagger time for data-plane updates to occur. Note that this starts some
 // background goroutines and the caller is resonsible for marking the provided
@@ -82,6 +137,7 @@ func NewSynchronizer(logger logr.Logger, client Client, opts ...SynchronizerOpti
 		dataplaneClient: client,
 		configApplied:   false,
 		dbMode:          client.DBMode(),
+		changes:         make(chan struct{}, 1),
 	}
 
 	for _, opt := range opts {
@@ -116,13 +172,27 @@ func (p *Synchronizer) Start(ctx context.Context) error {
 		return fmt.Errorf("server is already running")
 	}
 
-	p.syncTicker = time.NewTicker(p.stagger)
-	go p.startUpdateServer(ctx)
+	if p.eventDriven {
+		p.syncTicker = time.NewTicker(p.resyncPeriod)
+		go p.startEventDrivenUpdateServer(ctx)
+	} else {
+		p.syncTicker = time.NewTicker(p.stagger)
+		go p.startUpdateServer(ctx)
+	}
 	p.isServerRunning = true
 
 	return nil
 }
 
+// NotifyChange notifies the event-driven synchronizer that the configuration may have changed and
+// the data-plane should be updated. It never blocks.
+func (p *Synchronizer) NotifyChange() {
+	select {
+	case p.changes <- struct{}{}:
+	default:
+	}
+}
+
 // IsRunning informs the caller whether the synchronization server is running.
 func (p *Synchronizer) IsRunning() bool {
 	p.lock.RLock()
@@ -166,21 +236,11 @@ func (p *Synchronizer) startUpdateServer(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
-			p.logger.Info("context done: shutting down the proxy update server")
-			if err := ctx.Err(); err != nil && !errors.Is(err, context.Canceled) {
-				p.logger.Error(err, "context completed with error")
-			}
-			p.syncTicker.Stop()
-
-			p.lock.Lock()
-			defer p.lock.Unlock()
-			p.isServerRunning = false
-			p.configApplied = false
-
+			p.shutdownUpdateServer(ctx)
 			return
 
 		case <-p.syncTicker.C:
-			if err := p.dataplaneClient.Update(ctx); err != nil {
+			if err := p.update(ctx); err != nil {
 				p.logger.Error(err, "could not update kong admin")
 				continue
 			}
@@ -189,10 +249,119 @@ func (p *Synchronizer) startUpdateServer(ctx context.Context) {
 	}
 }
 
+// startEventDrivenUpdateServer runs a server in a background goroutine that is responsible for
+// updating the kong proxy backend whenever it's notified about changes, and at regular intervals.
+func (p *Synchronizer) startEventDrivenUpdateServer(ctx context.Context) {
+	var (
+		initialConfig sync.Once
+		pending       bool
+		debounceTimer = newStoppedTimer()
+		maxWaitTimer  = newStoppedTimer()
+		retryTimer    = newStoppedTimer()
+
+		gatewayClientsChanges <-chan struct{}
+	)
+	if p.gatewayClientsChangesNotifier != nil {
+		if ch, ok := p.gatewayClientsChangesNotifier.SubscribeToGatewayClientsChanges(); ok {
+			gatewayClientsChanges = ch
+		}
+	}
+
+	update := func() {
+		pending = false
+		stopTimer(debounceTimer)
+		stopTimer(maxWaitTimer)
+		stopTimer(retryTimer)
+
+		if err := p.update(ctx); err != nil {
+			p.logger.Error(err, "could not update kong admin, retrying", "retry_in", p.stagger)
+			retryTimer.Reset(p.stagger)
+			return
+		}
+		initialConfig.Do(p.markConfigApplied)
+		if c, ok := p.dataplaneClient.(UpdateRetryAwareClient); ok && c.UpdateRetryNeeded() {
+			retryTimer.Reset(p.stagger)
+		}
+	}
+	changed := func() {
+		if !pending {
+			pending = true
+			maxWaitTimer.Reset(p.maxWait)
+		}
+		stopTimer(debounceTimer)
+		debounceTimer.Reset(p.debounce)
+	}
+
+	// Apply the configuration populated in the cache so far right away.
+	update()
+	for {
+		select {
+		case <-ctx.Done():
+			stopTimer(debounceTimer)
+			stopTimer(maxWaitTimer)
+			stopTimer(retryTimer)
+			p.shutdownUpdateServer(ctx)
+			return
+		case <-p.changes:
+			changed()
+		case <-gatewayClientsChanges:
+			changed()
+		case <-debounceTimer.C:
+			update()
+		case <-maxWaitTimer.C:
+			update()
+		case <-retryTimer.C:
+			update()
+		case <-p.syncTicker.C:
+			update()
+		}
+	}
+}
+
+// update updates the dataplane client, tracing the whole pipeline of the update as a single trace.
+func (p *Synchronizer) update(ctx context.Context) error {
+	ctx, span := tracing.StartSpan(ctx, "Synchronizer.Update")
+	err := p.dataplaneClient.Update(ctx)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// shutdownUpdateServer marks the update server as stopped once its context is done.
+func (p *Synchronizer) shutdownUpdateServer(ctx context.Context) {
+	p.logger.Info("context done: shutting down the proxy update server")
+	if err := ctx.Err(); err != nil && !errors.Is(err, context.Canceled) {
+		p.logger.Error(err, "context completed with error")
+	}
+	p.syncTicker.Stop()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.isServerRunning = false
+	p.configApplied = false
+}
+
 // -----------------------------------------------------------------------------
 // Synchronizer - Private Methods - Helper
 // -----------------------------------------------------------------------------
 
+// newStoppedTimer returns a timer that doesn't fire until it's reset.
+func newStoppedTimer() *time.Timer {
+	t := time.NewTimer(time.Hour)
+	stopTimer(t)
+	return t
+}
+
+// stopTimer stops the timer and drains its channel, so that it can be safely reset. It must be called
+// only by the goroutine receiving from the timer's channel.
+func stopTimer(t *time.Timer) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+}
+
 // markConfigApplied marks that config has been applied.
 func (p *Synchronizer) markConfigApplied() {
 	p.lock.Lock()
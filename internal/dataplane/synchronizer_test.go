@@ -123,6 +123,92 @@ func TestSynchronizer_IsReadyDoesntBlockWhenDataPlaneIsBlocked(t *testing.T) {
 	}
 }
 
+func TestSynchronizer_EventDriven(t *testing.T) {
+	const (
+		debounce = 50 * time.Millisecond
+		maxWait  = 200 * time.Millisecond
+	)
+
+	newStartedSynchronizer := func(t *testing.T, resyncPeriod time.Duration, opts ...SynchronizerOption) (*Synchronizer, *fakeDataplaneClient) {
+		c := &fakeDataplaneClient{dbmode: "off", t: t}
+		s, err := NewSynchronizer(
+			zapr.NewLogger(zap.NewNop()),
+			c,
+			append([]SynchronizerOption{
+				WithStagger(time.Hour),
+				WithInitCacheSyncDuration(testSynchronizerTick),
+				WithEventDrivenSync(debounce, maxWait, resyncPeriod),
+			}, opts...)...,
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		require.NoError(t, s.Start(ctx))
+
+		t.Log("verifying that the configuration is applied right after starting")
+		require.Eventually(t, func() bool { return s.IsReady() }, time.Second, testSynchronizerTick)
+		require.Equal(t, 1, c.totalUpdates())
+		return s, c
+	}
+
+	t.Run("changes are debounced", func(t *testing.T) {
+		s, c := newStartedSynchronizer(t, time.Hour)
+
+		t.Log("verifying that no update happens without changes")
+		time.Sleep(2 * debounce)
+		require.Equal(t, 1, c.totalUpdates())
+
+		t.Log("verifying that a burst of changes results in a single update")
+		for i := 0; i < 5; i++ {
+			s.NotifyChange()
+		}
+		require.Eventually(t, func() bool { return c.totalUpdates() == 2 }, time.Second, testSynchronizerTick)
+		time.Sleep(2 * debounce)
+		require.Equal(t, 2, c.totalUpdates())
+	})
+
+	t.Run("continuous changes are not delayed longer than max wait", func(t *testing.T) {
+		s, c := newStartedSynchronizer(t, time.Hour)
+
+		stop := time.After(3 * maxWait)
+		ticker := time.NewTicker(debounce / 5)
+		defer ticker.Stop()
+	loop:
+		for {
+			select {
+			case <-stop:
+				break loop
+			case <-ticker.C:
+				s.NotifyChange()
+			}
+		}
+		require.GreaterOrEqual(t, c.totalUpdates(), 3, "updates should happen every max wait period despite changes")
+	})
+
+	t.Run("gateway clients changes trigger an update", func(t *testing.T) {
+		notifier := &fakeGatewayClientsChangesNotifier{ch: make(chan struct{}, 1)}
+		_, c := newStartedSynchronizer(t, time.Hour, WithGatewayClientsChangesNotifier(notifier))
+
+		notifier.ch <- struct{}{}
+		require.Eventually(t, func() bool { return c.totalUpdates() == 2 }, time.Second, testSynchronizerTick)
+	})
+
+	t.Run("periodic resync happens without changes", func(t *testing.T) {
+		_, c := newStartedSynchronizer(t, debounce)
+
+		require.Eventually(t, func() bool { return c.totalUpdates() >= 3 }, time.Second, testSynchronizerTick)
+	})
+}
+
+type fakeGatewayClientsChangesNotifier struct {
+	ch chan struct{}
+}
+
+func (n *fakeGatewayClientsChangesNotifier) SubscribeToGatewayClientsChanges() (<-chan struct{}, bool) {
+	return n.ch, true
+}
+
 // fakeDataplaneClient fakes the dataplane.Client interface so that we can
 // unit test the dataplane.Synchronizer.
 type fakeDataplaneClient struct {
@@ -0,0 +1,206 @@
+package dataplane
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/sourcegraph/conc/iter"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/capabilities"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// GatewayCapabilitiesInjector is injected with capabilities of Kong Gateways the configuration is generated for
+// (e.g. the parser).
+type GatewayCapabilitiesInjector interface {
+	InjectCapabilities(capabilities.Registry)
+}
+
+// GatewayDetector detects the version and capabilities of the Kong Gateway the client communicates with.
+type GatewayDetector func(ctx context.Context, client *adminapi.Client) (capabilities.Gateway, error)
+
+// VersionSkewDetectionConfig configures detection of Kong Gateways running different versions, e.g. during a rolling
+// upgrade. The configuration is generated once for capabilities all the gateways have (the lowest common
+// denominator), so that it's accepted by each of them.
+type VersionSkewDetectionConfig struct {
+	// Detector detects Kong Gateways that the configuration is pushed to for the first time.
+	Detector GatewayDetector
+	// Injector is injected with capabilities common to all Kong Gateways whenever they change.
+	Injector GatewayCapabilitiesInjector
+	// Initial describes Kong Gateways the configuration is generated for when the controller starts.
+	Initial capabilities.Gateway
+}
+
+// versionSkewDetection holds the version skew detection configuration and state of KongClient. It's guarded by
+// KongClient's lock.
+type versionSkewDetection struct {
+	config VersionSkewDetectionConfig
+
+	// detected are Kong Gateways that have been detected, indexed by their Admin API URLs. A gateway is detected
+	// only once, as its version doesn't change without a restart, after which it's discovered with a new URL.
+	detected map[string]capabilities.Gateway
+
+	// target is the Kong Gateway the configuration is generated for.
+	target capabilities.Gateway
+}
+
+// SetVersionSkewDetection enables detection of Kong Gateways running different versions. The configuration is
+// generated for capabilities common to all of them.
+func (c *KongClient) SetVersionSkewDetection(config VersionSkewDetectionConfig) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.versionSkew = &versionSkewDetection{
+		config:   config,
+		detected: map[string]capabilities.Gateway{},
+		target:   config.Initial,
+	}
+}
+
+// detectVersionSkew detects Kong Gateways that haven't been detected yet, reports their versions in metrics and
+// diagnostics and injects capabilities common to all of them when they change.
+func (c *KongClient) detectVersionSkew(ctx context.Context) {
+	if c.versionSkew == nil {
+		return
+	}
+
+	gatewayClients := c.clientsProvider.GatewayClients()
+	var undetected []*adminapi.Client
+	for _, client := range gatewayClients {
+		if _, ok := c.versionSkew.detected[client.BaseRootURL()]; !ok {
+			undetected = append(undetected, client)
+		}
+	}
+	type detectionResult struct {
+		gateway capabilities.Gateway
+		err     error
+	}
+	detectionResults := iter.Map(undetected, func(client **adminapi.Client) detectionResult {
+		g, err := c.detectGateway(ctx, *client)
+		return detectionResult{gateway: g, err: err}
+	})
+	for i, r := range detectionResults {
+		if r.err == nil {
+			c.versionSkew.detected[undetected[i].BaseRootURL()] = r.gateway
+		}
+	}
+
+	var (
+		urls       = make([]string, 0, len(gatewayClients))
+		gateways   = make([]capabilities.Gateway, 0, len(gatewayClients))
+		versions   = make(map[string]string, len(gatewayClients))
+		diagnostic = util.GatewayVersions{Gateways: []util.GatewayVersion{}, Time: time.Now()}
+	)
+	for _, client := range gatewayClients {
+		urls = append(urls, client.BaseRootURL())
+	}
+	sort.Strings(urls)
+
+	current := make(map[string]capabilities.Gateway, len(urls))
+	for _, url := range urls {
+		g, ok := c.versionSkew.detected[url]
+		if !ok {
+			continue
+		}
+		current[url] = g
+		gateways = append(gateways, g)
+		versions[url] = g.Version.String()
+		diagnostic.Gateways = append(diagnostic.Gateways, gatewayVersion(url, g))
+	}
+	// Forget gateways that are not present anymore.
+	c.versionSkew.detected = current
+
+	for i, r := range detectionResults {
+		if r.err != nil {
+			diagnostic.Gateways = append(diagnostic.Gateways, util.GatewayVersion{
+				URL:   undetected[i].BaseRootURL(),
+				Error: r.err.Error(),
+			})
+		}
+	}
+
+	distinctVersions := make(map[string]struct{}, len(versions))
+	for _, v := range versions {
+		distinctVersions[v] = struct{}{}
+	}
+	diagnostic.Skew = len(distinctVersions) > 1
+
+	// Capabilities are not changed until at least one gateway is detected.
+	if len(gateways) > 0 {
+		target := capabilities.CommonGateway(gateways...)
+		if !target.Equal(c.versionSkew.target) {
+			registry := capabilities.NewRegistry(target)
+			c.logger.Info("capabilities of Kong Gateways changed, configuration is generated for what all of them support",
+				"versions", versions,
+				"targetVersion", target.Version.String(),
+				"capabilities", registry.Supported(),
+			)
+			c.versionSkew.config.Injector.InjectCapabilities(registry)
+			c.versionSkew.target = target
+		}
+	}
+	diagnostic.Target = gatewayVersion("", c.versionSkew.target)
+
+	c.prometheusMetrics.RecordGatewaysVersion(versions)
+	if c.diagnostic.GatewayVersions != nil {
+		select {
+		case c.diagnostic.GatewayVersions <- diagnostic:
+		default:
+			c.logger.Error(nil, "gateway versions diagnostic buffer full, dropping diagnostic")
+		}
+	}
+}
+
+// pluginSchemasClient returns the gateway client whose plugins' schemas are used to fill in plugins' defaults in
+// the configuration generated for all gatewayClients. With version skew detection enabled, it's a gateway running
+// the lowest detected version, as the configuration is generated for it and defaults of newer versions may include
+// fields older ones don't know. Otherwise, or when no gateway has been detected, it's the first gateway client.
+func (c *KongClient) pluginSchemasClient(gatewayClients []*adminapi.Client) *adminapi.Client {
+	if c.versionSkew == nil {
+		return gatewayClients[0]
+	}
+
+	var (
+		selected        *adminapi.Client
+		selectedVersion semver.Version
+	)
+	for _, client := range gatewayClients {
+		g, ok := c.versionSkew.detected[client.BaseRootURL()]
+		if !ok {
+			continue
+		}
+		// Gateways of the same version are ordered by their URLs, so that the same one is selected every time.
+		if selected == nil || g.Version.LT(selectedVersion) ||
+			(g.Version.EQ(selectedVersion) && client.BaseRootURL() < selected.BaseRootURL()) {
+			selected, selectedVersion = client, g.Version
+		}
+	}
+	if selected == nil {
+		return gatewayClients[0]
+	}
+	return selected
+}
+
+// detectGateway detects a single Kong Gateway. Failures are logged, the detection is retried with the next update.
+func (c *KongClient) detectGateway(ctx context.Context, client *adminapi.Client) (capabilities.Gateway, error) {
+	timedCtx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+	g, err := c.versionSkew.config.Detector(timedCtx, client)
+	if err != nil {
+		c.logger.Error(err, "failed to detect Kong Gateway version", "url", client.BaseRootURL())
+		return capabilities.Gateway{}, err
+	}
+	return g, nil
+}
+
+func gatewayVersion(url string, g capabilities.Gateway) util.GatewayVersion {
+	return util.GatewayVersion{
+		URL:          url,
+		Version:      g.Version.String(),
+		Edition:      string(g.Edition),
+		RouterFlavor: g.RouterFlavor,
+	}
+}
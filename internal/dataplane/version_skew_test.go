@@ -0,0 +1,118 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/capabilities"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+type mockGatewayCapabilitiesInjector struct {
+	injected []capabilities.Registry
+}
+
+func (m *mockGatewayCapabilitiesInjector) InjectCapabilities(r capabilities.Registry) {
+	m.injected = append(m.injected, r)
+}
+
+func TestKongClientUpdate_DetectsVersionSkew(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		oldGateway = mustSampleGatewayClient(t)
+		newGateway = mustSampleGatewayClient(t)
+		failing    = mustSampleGatewayClient(t)
+		oldVersion = capabilities.Gateway{
+			Version:      semver.MustParse("3.4.1"),
+			Edition:      capabilities.EditionEnterprise,
+			RouterFlavor: "traditional_compatible",
+		}
+		newVersion = capabilities.Gateway{
+			Version:      semver.MustParse("3.5.0"),
+			Edition:      capabilities.EditionEnterprise,
+			RouterFlavor: "traditional_compatible",
+		}
+		detections     = map[string]int{}
+		detectionsLock sync.Mutex
+		detector       = func(_ context.Context, client *adminapi.Client) (capabilities.Gateway, error) {
+			detectionsLock.Lock()
+			detections[client.BaseRootURL()]++
+			detectionsLock.Unlock()
+			switch client.BaseRootURL() {
+			case oldGateway.BaseRootURL():
+				return oldVersion, nil
+			case newGateway.BaseRootURL():
+				return newVersion, nil
+			default:
+				return capabilities.Gateway{}, errors.New("connection refused")
+			}
+		}
+
+		updateStrategyResolver = newMockUpdateStrategyResolver(t)
+		configChangeDetector   = mockConfigurationChangeDetector{hasConfigurationChanged: true}
+		configBuilder          = newMockKongConfigBuilder()
+		kongRawStateGetter     = &mockKongLastValidConfigFetcher{}
+		injector               = &mockGatewayCapabilitiesInjector{}
+		diagnostics            = make(chan util.GatewayVersions, 10)
+	)
+	kongClient := setupTestKongClient(t, updateStrategyResolver, mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{newGateway},
+	}, configChangeDetector, configBuilder, nil, kongRawStateGetter)
+	kongClient.diagnostic.GatewayVersions = diagnostics
+	kongClient.SetVersionSkewDetection(VersionSkewDetectionConfig{
+		Detector: detector,
+		Injector: injector,
+		Initial:  newVersion,
+	})
+
+	t.Log("gateways of the initial version don't change capabilities")
+	require.NoError(t, kongClient.Update(ctx))
+	require.Empty(t, injector.injected)
+	versions := <-diagnostics
+	require.False(t, versions.Skew)
+	require.Equal(t, []util.GatewayVersion{
+		{URL: newGateway.BaseRootURL(), Version: "3.5.0", Edition: "enterprise", RouterFlavor: "traditional_compatible"},
+	}, versions.Gateways)
+
+	t.Log("a gateway of an older version makes the configuration generated for it")
+	kongClient.clientsProvider = mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{newGateway, oldGateway, failing},
+	}
+	require.NoError(t, kongClient.Update(ctx))
+	require.Len(t, injector.injected, 1)
+	require.True(t, oldVersion.Equal(injector.injected[0].Gateway()))
+	versions = <-diagnostics
+	require.True(t, versions.Skew)
+	require.Equal(t, "3.4.1", versions.Target.Version)
+	require.Len(t, versions.Gateways, 3)
+	require.Equal(t, failing.BaseRootURL(), versions.Gateways[2].URL)
+	require.Equal(t, "connection refused", versions.Gateways[2].Error)
+	require.Equal(t, oldGateway, kongClient.pluginSchemasClient(kongClient.clientsProvider.GatewayClients()),
+		"plugins' defaults should be filled in from schemas of the older gateway")
+
+	t.Log("detected gateways are not detected again, failing ones are")
+	require.NoError(t, kongClient.Update(ctx))
+	require.Len(t, injector.injected, 1, "capabilities should not be injected again when they don't change")
+	require.Equal(t, 1, detections[newGateway.BaseRootURL()])
+	require.Equal(t, 1, detections[oldGateway.BaseRootURL()])
+	require.Equal(t, 2, detections[failing.BaseRootURL()])
+	<-diagnostics
+
+	t.Log("once the older gateway is gone, the configuration is generated for the newer version")
+	kongClient.clientsProvider = mockGatewayClientsProvider{
+		gatewayClients: []*adminapi.Client{newGateway},
+	}
+	require.NoError(t, kongClient.Update(ctx))
+	require.Len(t, injector.injected, 2)
+	require.True(t, newVersion.Equal(injector.injected[1].Gateway()))
+	versions = <-diagnostics
+	require.False(t, versions.Skew)
+	require.Equal(t, "3.5.0", versions.Target.Version)
+	require.Equal(t, newGateway, kongClient.pluginSchemasClient(kongClient.clientsProvider.GatewayClients()))
+}
@@ -0,0 +1,183 @@
+package diagnostics
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/kong/deck/file"
+)
+
+// EntityDiff describes a single Kong entity that differs between two configurations.
+type EntityDiff struct {
+	// Kind is the kind of the entity as named in the declarative configuration (e.g. services, routes).
+	Kind string `json:"kind"`
+	// Key identifies the entity within its kind. It's the entity's ID when available, otherwise its name or
+	// another natural key. Nested entities' keys are prefixed with their parent's key.
+	Key string `json:"key"`
+	// ChangedFields lists the top-level fields of the entity that differ. It's only populated for modified entities.
+	ChangedFields []string `json:"changed_fields,omitempty"`
+}
+
+// ConfigDiff is an entity-level difference between two configurations.
+type ConfigDiff struct {
+	From     ConfigHistoryEntryMeta `json:"from"`
+	To       ConfigHistoryEntryMeta `json:"to"`
+	Added    []EntityDiff           `json:"added"`
+	Removed  []EntityDiff           `json:"removed"`
+	Modified []EntityDiff           `json:"modified"`
+}
+
+// entityKeyFields are the fields used to identify an entity, in order of preference.
+var entityKeyFields = []string{"id", "name", "username", "custom_id", "target", "key", "prefix"}
+
+// flatEntity is a single entity with its nested entities stripped.
+type flatEntity struct {
+	kind   string
+	key    string
+	fields map[string]any
+}
+
+// DiffConfigs calculates an entity-level diff between two configurations.
+func DiffConfigs(from, to file.Content) (added, removed, modified []EntityDiff, err error) {
+	fromEntities, err := flattenContent(from)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to flatten source config: %w", err)
+	}
+	toEntities, err := flattenContent(to)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to flatten target config: %w", err)
+	}
+
+	added, removed, modified = []EntityDiff{}, []EntityDiff{}, []EntityDiff{}
+	for id, toEntity := range toEntities {
+		fromEntity, ok := fromEntities[id]
+		if !ok {
+			added = append(added, EntityDiff{Kind: toEntity.kind, Key: toEntity.key})
+			continue
+		}
+		if changed := changedFields(fromEntity.fields, toEntity.fields); len(changed) > 0 {
+			modified = append(modified, EntityDiff{Kind: toEntity.kind, Key: toEntity.key, ChangedFields: changed})
+		}
+	}
+	for id, fromEntity := range fromEntities {
+		if _, ok := toEntities[id]; !ok {
+			removed = append(removed, EntityDiff{Kind: fromEntity.kind, Key: fromEntity.key})
+		}
+	}
+
+	for _, diffs := range [][]EntityDiff{added, removed, modified} {
+		sortEntityDiffs(diffs)
+	}
+	return added, removed, modified, nil
+}
+
+// flattenContent converts the configuration into a flat set of entities keyed by their kind and key.
+func flattenContent(content file.Content) (map[string]flatEntity, error) {
+	b, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+
+	out := map[string]flatEntity{}
+	for kind, value := range raw {
+		entities, ok := asEntityList(value)
+		if !ok {
+			// Not a list of entities (e.g. _format_version, _info).
+			continue
+		}
+		flattenEntities(out, kind, "", entities)
+	}
+	return out, nil
+}
+
+func flattenEntities(out map[string]flatEntity, kind, parentKey string, entities []map[string]any) {
+	for _, entity := range entities {
+		key := entityKey(entity)
+		if parentKey != "" {
+			key = parentKey + "/" + key
+		}
+
+		fields := make(map[string]any, len(entity))
+		for field, value := range entity {
+			if nested, ok := asEntityList(value); ok {
+				flattenEntities(out, field, key, nested)
+				continue
+			}
+			fields[field] = value
+		}
+
+		id := kind + ":" + key
+		if _, exists := out[id]; exists {
+			// Entities without a distinguishing natural key (e.g. two plugins of the same name) would collide.
+			// Fall back to their content hash to keep both.
+			key = key + "@" + contentHash(fields)
+			id = kind + ":" + key
+		}
+		out[id] = flatEntity{kind: kind, key: key, fields: fields}
+	}
+}
+
+// asEntityList returns the value as a list of entities if it's a non-empty list of JSON objects.
+func asEntityList(value any) ([]map[string]any, bool) {
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		return nil, false
+	}
+	out := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, obj)
+	}
+	return out, true
+}
+
+func entityKey(entity map[string]any) string {
+	for _, field := range entityKeyFields {
+		if v, ok := entity[field].(string); ok && v != "" {
+			return v
+		}
+	}
+	return contentHash(entity)
+}
+
+func contentHash(v any) string {
+	b, _ := json.Marshal(v)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8])
+}
+
+func changedFields(from, to map[string]any) []string {
+	var changed []string
+	for field, toValue := range to {
+		if fromValue, ok := from[field]; !ok || !reflect.DeepEqual(fromValue, toValue) {
+			changed = append(changed, field)
+		}
+	}
+	for field := range from {
+		if _, ok := to[field]; !ok {
+			changed = append(changed, field)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func sortEntityDiffs(diffs []EntityDiff) {
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Kind != diffs[j].Kind {
+			return diffs[i].Kind < diffs[j].Kind
+		}
+		return diffs[i].Key < diffs[j].Key
+	})
+}
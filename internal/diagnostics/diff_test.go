@@ -0,0 +1,64 @@
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	from := file.Content{
+		FormatVersion: "3.0",
+		Services: []file.FService{
+			{
+				Service: kong.Service{ID: kong.String("svc-1"), Name: kong.String("svc-1"), Host: kong.String("example.com")},
+				Routes: []*file.FRoute{
+					{Route: kong.Route{ID: kong.String("route-1"), Paths: kong.StringSlice("/foo")}},
+					{Route: kong.Route{ID: kong.String("route-2"), Paths: kong.StringSlice("/bar")}},
+				},
+			},
+		},
+		Consumers: []file.FConsumer{
+			{Consumer: kong.Consumer{Username: kong.String("alice")}},
+		},
+	}
+	to := file.Content{
+		FormatVersion: "3.0",
+		Services: []file.FService{
+			{
+				Service: kong.Service{ID: kong.String("svc-1"), Name: kong.String("svc-1"), Host: kong.String("example.org")},
+				Routes: []*file.FRoute{
+					{Route: kong.Route{ID: kong.String("route-1"), Paths: kong.StringSlice("/foo")}},
+					{Route: kong.Route{ID: kong.String("route-3"), Paths: kong.StringSlice("/baz")}},
+				},
+			},
+		},
+		Plugins: []file.FPlugin{
+			{Plugin: kong.Plugin{Name: kong.String("cors")}},
+		},
+	}
+
+	added, removed, modified, err := DiffConfigs(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, []EntityDiff{
+		{Kind: "plugins", Key: "cors"},
+		{Kind: "routes", Key: "svc-1/route-3"},
+	}, added)
+	assert.Equal(t, []EntityDiff{
+		{Kind: "consumers", Key: "alice"},
+		{Kind: "routes", Key: "svc-1/route-2"},
+	}, removed)
+	assert.Equal(t, []EntityDiff{
+		{Kind: "services", Key: "svc-1", ChangedFields: []string{"host"}},
+	}, modified)
+
+	t.Log("diffing identical configs should yield no differences")
+	added, removed, modified, err = DiffConfigs(to, to)
+	require.NoError(t, err)
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+	assert.Empty(t, modified)
+}
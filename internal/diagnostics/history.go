@@ -0,0 +1,177 @@
+package diagnostics
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kong/deck/file"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+// DefaultConfigHistorySize is the default number of configurations kept in the diagnostics config history.
+const DefaultConfigHistorySize = 10
+
+// ConfigHistoryEntryMeta describes a configuration stored in the config history without its content.
+type ConfigHistoryEntryMeta struct {
+	// ID is a sequence number of the entry, unique for the lifetime of the process.
+	ID uint64 `json:"id"`
+	// Hash is the SHA256 checksum of the configuration.
+	Hash string `json:"hash"`
+	// Target is what the configuration was generated for, e.g. "gateways" or "konnect".
+	Target string `json:"target,omitempty"`
+	// Failed indicates whether the most recent attempt to apply this configuration failed.
+	Failed bool `json:"failed"`
+	// DryRun indicates that the configuration was generated in dry-run mode, so it was never applied.
+	DryRun bool `json:"dry_run,omitempty"`
+	// FirstSeen is the time the configuration was first received.
+	FirstSeen time.Time `json:"first_seen"`
+	// LastSeen is the time the configuration was most recently received.
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// ConfigHistoryEntry is a configuration stored in the config history.
+type ConfigHistoryEntry struct {
+	ConfigHistoryEntryMeta
+	Config file.Content `json:"config"`
+}
+
+// configHistory is a ring buffer of the most recently pushed configurations. Configurations of different targets
+// are kept apart, so that e.g. dumps for Konnect don't interleave with dumps for gateways. Consecutive dumps of
+// the same configuration for a target (e.g. when it didn't change between syncs) are collapsed into a single entry.
+type configHistory struct {
+	lock    sync.RWMutex
+	entries []ConfigHistoryEntry
+	size    int
+	nextID  uint64
+	now     func() time.Time
+
+	// lastAppliedIDs are IDs of the most recent entries that were successfully applied, by target.
+	lastAppliedIDs map[string]uint64
+}
+
+func newConfigHistory(size int) *configHistory {
+	if size <= 0 {
+		size = DefaultConfigHistorySize
+	}
+	return &configHistory{
+		size:           size,
+		nextID:         1,
+		now:            time.Now,
+		lastAppliedIDs: make(map[string]uint64),
+	}
+}
+
+// Record stores the dumped config in the history, evicting the oldest entry if the history is full. Configs
+// generated in dry-run mode are kept apart from the applied ones, so they're never considered applied. The dump's
+// hash is used when provided, otherwise it's generated from the dumped config.
+func (h *configHistory) Record(dump util.ConfigDump) error {
+	sha := dump.Hash
+	if len(sha) == 0 {
+		var err error
+		if sha, err = deckgen.GenerateSHA(&dump.Config); err != nil {
+			return fmt.Errorf("failed to generate config hash: %w", err)
+		}
+	}
+	hash := hex.EncodeToString(sha)
+	now := h.now()
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	applied := !dump.Failed && !dump.DryRun
+	if latest := h.latest(dump.Target); latest != nil && latest.Hash == hash && latest.DryRun == dump.DryRun {
+		latest.Failed = dump.Failed
+		latest.LastSeen = now
+		if applied {
+			h.lastAppliedIDs[dump.Target] = latest.ID
+		}
+		return nil
+	}
+
+	entry := ConfigHistoryEntry{
+		ConfigHistoryEntryMeta: ConfigHistoryEntryMeta{
+			ID:        h.nextID,
+			Hash:      hash,
+			Target:    dump.Target,
+			Failed:    dump.Failed,
+			DryRun:    dump.DryRun,
+			FirstSeen: now,
+			LastSeen:  now,
+		},
+		Config: dump.Config,
+	}
+	h.nextID++
+	if applied {
+		h.lastAppliedIDs[dump.Target] = entry.ID
+	}
+
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > h.size {
+		// Copy to a new slice so that the evicted entries' configs can be garbage collected.
+		h.entries = append([]ConfigHistoryEntry(nil), h.entries[len(h.entries)-h.size:]...)
+	}
+	return nil
+}
+
+// List returns metadata of all the entries in the history, from the oldest to the newest.
+func (h *configHistory) List() []ConfigHistoryEntryMeta {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	out := make([]ConfigHistoryEntryMeta, 0, len(h.entries))
+	for _, e := range h.entries {
+		out = append(out, e.ConfigHistoryEntryMeta)
+	}
+	return out
+}
+
+// Get returns the entry with the given ID if it's still in the history.
+func (h *configHistory) Get(id uint64) (ConfigHistoryEntry, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	for _, e := range h.entries {
+		if e.ID == id {
+			return e, true
+		}
+	}
+	return ConfigHistoryEntry{}, false
+}
+
+// Latest returns the most recently translated configuration for the target, regardless of whether it was
+// applied successfully.
+func (h *configHistory) Latest(target string) (ConfigHistoryEntry, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if latest := h.latest(target); latest != nil {
+		return *latest, true
+	}
+	return ConfigHistoryEntry{}, false
+}
+
+// LastApplied returns the most recent configuration for the target that was successfully applied.
+func (h *configHistory) LastApplied(target string) (ConfigHistoryEntry, bool) {
+	h.lock.RLock()
+	id, ok := h.lastAppliedIDs[target]
+	h.lock.RUnlock()
+
+	if !ok {
+		return ConfigHistoryEntry{}, false
+	}
+	return h.Get(id)
+}
+
+// latest returns the most recent entry for the target or nil if there's none. It must be called with the lock held.
+func (h *configHistory) latest(target string) *ConfigHistoryEntry {
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		if h.entries[i].Target == target {
+			return &h.entries[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/kong/deck/file"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+func configWithServices(names ...string) file.Content {
+	c := file.Content{FormatVersion: "3.0"}
+	for _, name := range names {
+		c.Services = append(c.Services, file.FService{Service: kong.Service{Name: kong.String(name)}})
+	}
+	return c
+}
+
+func TestConfigHistory(t *testing.T) {
+	h := newConfigHistory(2)
+
+	_, ok := h.Latest("")
+	require.False(t, ok)
+	_, ok = h.LastApplied("")
+	require.False(t, ok)
+
+	require.NoError(t, h.Record(util.ConfigDump{Config: configWithServices("a")}))
+	// Same config again (e.g. when it didn't change between syncs) should be collapsed into a single entry.
+	require.NoError(t, h.Record(util.ConfigDump{Config: configWithServices("a")}))
+	require.Len(t, h.List(), 1)
+
+	require.NoError(t, h.Record(util.ConfigDump{Config: configWithServices("a", "b"), Failed: true}))
+	entries := h.List()
+	require.Len(t, entries, 2)
+	assert.Equal(t, uint64(1), entries[0].ID)
+	assert.Equal(t, uint64(2), entries[1].ID)
+	assert.True(t, entries[1].Failed)
+
+	latest, ok := h.Latest("")
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), latest.ID)
+	applied, ok := h.LastApplied("")
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), applied.ID)
+
+	t.Log("recording a third config should evict the oldest one")
+	require.NoError(t, h.Record(util.ConfigDump{Config: configWithServices("c")}))
+	entries = h.List()
+	require.Len(t, entries, 2)
+	assert.Equal(t, uint64(2), entries[0].ID)
+	assert.Equal(t, uint64(3), entries[1].ID)
+	_, ok = h.Get(1)
+	assert.False(t, ok)
+
+	applied, ok = h.LastApplied("")
+	require.True(t, ok)
+	assert.Equal(t, uint64(3), applied.ID)
+}
+
+func TestConfigHistory_DryRunConfigsAreNeverApplied(t *testing.T) {
+	h := newConfigHistory(10)
+
+	require.NoError(t, h.Record(util.ConfigDump{Config: configWithServices("a")}))
+	require.NoError(t, h.Record(util.ConfigDump{Config: configWithServices("b"), DryRun: true}))
+	// A dry-run config is not collapsed with the same config applied before.
+	require.NoError(t, h.Record(util.ConfigDump{Config: configWithServices("a"), DryRun: true}))
+
+	entries := h.List()
+	require.Len(t, entries, 3)
+	assert.False(t, entries[0].DryRun)
+	assert.True(t, entries[1].DryRun)
+	assert.True(t, entries[2].DryRun)
+	for _, e := range entries {
+		assert.False(t, e.Failed)
+	}
+
+	applied, ok := h.LastApplied("")
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), applied.ID)
+}
+
+func TestConfigHistory_TargetsAreKeptApart(t *testing.T) {
+	h := newConfigHistory(10)
+
+	gatewaysHash, konnectHash := []byte{0x1}, []byte{0x2}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, h.Record(util.ConfigDump{Config: configWithServices("a"), Hash: gatewaysHash, Target: "gateways"}))
+		require.NoError(t, h.Record(util.ConfigDump{Config: configWithServices("a"), Hash: konnectHash, Target: "konnect", Failed: true}))
+	}
+
+	entries := h.List()
+	require.Len(t, entries, 2, "same configs of each target should be collapsed even though they interleave")
+	assert.Equal(t, "gateways", entries[0].Target)
+	assert.Equal(t, "01", entries[0].Hash, "precomputed hash should be used")
+	assert.False(t, entries[0].Failed)
+	assert.Equal(t, "konnect", entries[1].Target)
+	assert.Equal(t, "02", entries[1].Hash, "precomputed hash should be used")
+	assert.True(t, entries[1].Failed)
+
+	latest, ok := h.Latest("gateways")
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), latest.ID)
+	applied, ok := h.LastApplied("gateways")
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), applied.ID)
+	_, ok = h.LastApplied("konnect")
+	assert.False(t, ok)
+}
@@ -7,32 +7,67 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/pprof"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kong/deck/file"
 
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/metrics"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 )
 
 // Server is an HTTP server running exposing the pprof profiling tool, and processing diagnostic dumps of Kong configurations.
+// It should be created with NewServer.
 type Server struct {
 	Logger           logr.Logger
 	ProfilingEnabled bool
 	ConfigDumps      util.ConfigDumpDiagnostic
 	ConfigLock       *sync.RWMutex
+
+	history *configHistory
+
+	// gatewaySyncStatuses are results of the most recent configuration sync with each of Kong Gateways.
+	gatewaySyncStatuses []util.GatewaySyncStatus
+
+	// gatewayConfigDrifts are results of the most recent drift detection with each of Kong Gateways.
+	gatewayConfigDrifts []util.GatewayConfigDrift
+
+	// gatewayVersions is the result of the most recent detection of Kong Gateways' versions.
+	gatewayVersions *util.GatewayVersions
+
+	// failingObjects are Kubernetes objects that failed to be translated or pushed in the most recent update.
+	failingObjects []util.FailingObject
 }
 
 var (
 	successfulConfigDump file.Content
 	failedConfigDump     file.Content
+	dryRunConfigDump     file.Content
 )
 
 const (
 	defaultHTTPReadHeaderTimeout = 10 * time.Second
 )
 
+// NewServer creates a Server. configHistorySize is the number of most recent configurations kept for the config
+// history endpoints. When not positive, DefaultConfigHistorySize is used.
+func NewServer(
+	logger logr.Logger,
+	profilingEnabled bool,
+	configDumps util.ConfigDumpDiagnostic,
+	configHistorySize int,
+) *Server {
+	return &Server{
+		Logger:           logger,
+		ProfilingEnabled: profilingEnabled,
+		ConfigDumps:      configDumps,
+		ConfigLock:       &sync.RWMutex{},
+		history:          newConfigHistory(configHistorySize),
+	}
+}
+
 // Listen starts up the HTTP server and blocks until ctx expires.
 func (s *Server) Listen(ctx context.Context, port int) error {
 	mux := http.NewServeMux()
@@ -79,12 +114,34 @@ func (s *Server) receiveConfig(ctx context.Context) {
 		select {
 		case dump := <-s.ConfigDumps.Configs:
 			s.ConfigLock.Lock()
-			if dump.Failed {
+			switch {
+			case dump.DryRun:
+				dryRunConfigDump = dump.Config
+			case dump.Failed:
 				failedConfigDump = dump.Config
-			} else {
+			default:
 				successfulConfigDump = dump.Config
 			}
 			s.ConfigLock.Unlock()
+			if err := s.history.Record(dump); err != nil {
+				s.Logger.Error(err, "failed to record config in diagnostic config history")
+			}
+		case statuses := <-s.ConfigDumps.GatewaySyncStatuses:
+			s.ConfigLock.Lock()
+			s.gatewaySyncStatuses = statuses
+			s.ConfigLock.Unlock()
+		case drifts := <-s.ConfigDumps.GatewayConfigDrifts:
+			s.ConfigLock.Lock()
+			s.gatewayConfigDrifts = drifts
+			s.ConfigLock.Unlock()
+		case versions := <-s.ConfigDumps.GatewayVersions:
+			s.ConfigLock.Lock()
+			s.gatewayVersions = &versions
+			s.ConfigLock.Unlock()
+		case failing := <-s.ConfigDumps.FailingObjects:
+			s.ConfigLock.Lock()
+			s.failingObjects = failing
+			s.ConfigLock.Unlock()
 		case <-ctx.Done():
 			if err := ctx.Err(); err != nil && !errors.Is(err, context.Canceled) {
 				s.Logger.Error(err, "shutting down diagnostic config collection: context completed with error")
@@ -115,6 +172,14 @@ func installProfilingHandlers(mux *http.ServeMux) {
 func (s *Server) installDumpHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/debug/config/successful", s.lastConfig(&successfulConfigDump))
 	mux.HandleFunc("/debug/config/failed", s.lastConfig(&failedConfigDump))
+	mux.HandleFunc("/debug/config/dry-run", s.lastConfig(&dryRunConfigDump))
+	mux.HandleFunc("/debug/config/history", s.configHistoryList)
+	mux.HandleFunc("/debug/config/history/config", s.configHistoryEntry)
+	mux.HandleFunc("/debug/config/diff", s.configDiff)
+	mux.HandleFunc("/debug/config/gateways", s.gatewaysSyncStatus)
+	mux.HandleFunc("/debug/config/drift", s.gatewaysConfigDrift)
+	mux.HandleFunc("/debug/config/versions", s.gatewaysVersions)
+	mux.HandleFunc("/debug/config/failures", s.failures)
 }
 
 // redirectTo redirects request to a certain destination.
@@ -134,3 +199,148 @@ func (s *Server) lastConfig(config *file.Content) func(rw http.ResponseWriter, r
 		s.ConfigLock.RUnlock()
 	}
 }
+
+// configHistoryList responds with metadata of all configurations stored in the config history.
+func (s *Server) configHistoryList(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, http.StatusOK, s.history.List())
+}
+
+// configHistoryEntry responds with a single configuration from the config history. The configuration is selected
+// with the "id" and "target" query parameters (see resolveHistoryEntry for accepted values).
+func (s *Server) configHistoryEntry(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	entry, err := s.resolveHistoryEntry(query.Get("id"), configSelectorLatest, query.Get("target"))
+	if err != nil {
+		writeJSON(rw, http.StatusNotFound, errorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(rw, http.StatusOK, entry)
+}
+
+// configDiff responds with an entity-level diff between two configurations from the config history selected with
+// "from" and "to" query parameters of the target selected with the "target" query parameter (see resolveHistoryEntry
+// for accepted values). By default, it compares the last successfully applied configuration with the most recently
+// translated one.
+func (s *Server) configDiff(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	target := query.Get("target")
+	from, err := s.resolveHistoryEntry(query.Get("from"), configSelectorApplied, target)
+	if err != nil {
+		writeJSON(rw, http.StatusNotFound, errorResponse{Error: fmt.Sprintf("from: %s", err)})
+		return
+	}
+	to, err := s.resolveHistoryEntry(query.Get("to"), configSelectorLatest, target)
+	if err != nil {
+		writeJSON(rw, http.StatusNotFound, errorResponse{Error: fmt.Sprintf("to: %s", err)})
+		return
+	}
+
+	added, removed, modified, err := DiffConfigs(from.Config, to.Config)
+	if err != nil {
+		writeJSON(rw, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(rw, http.StatusOK, ConfigDiff{
+		From:     from.ConfigHistoryEntryMeta,
+		To:       to.ConfigHistoryEntryMeta,
+		Added:    added,
+		Removed:  removed,
+		Modified: modified,
+	})
+}
+
+// gatewaysSyncStatus responds with results of the most recent configuration sync with each of Kong Gateways.
+func (s *Server) gatewaysSyncStatus(rw http.ResponseWriter, _ *http.Request) {
+	s.ConfigLock.RLock()
+	statuses := s.gatewaySyncStatuses
+	s.ConfigLock.RUnlock()
+	if statuses == nil {
+		statuses = []util.GatewaySyncStatus{}
+	}
+	writeJSON(rw, http.StatusOK, statuses)
+}
+
+// gatewaysVersions responds with the result of the most recent detection of Kong Gateways' versions.
+func (s *Server) gatewaysVersions(rw http.ResponseWriter, _ *http.Request) {
+	s.ConfigLock.RLock()
+	versions := s.gatewayVersions
+	s.ConfigLock.RUnlock()
+	if versions == nil {
+		versions = &util.GatewayVersions{Gateways: []util.GatewayVersion{}}
+	}
+	writeJSON(rw, http.StatusOK, versions)
+}
+
+// gatewaysConfigDrift responds with results of the most recent drift detection with each of Kong Gateways.
+func (s *Server) gatewaysConfigDrift(rw http.ResponseWriter, _ *http.Request) {
+	s.ConfigLock.RLock()
+	drifts := s.gatewayConfigDrifts
+	s.ConfigLock.RUnlock()
+	if drifts == nil {
+		drifts = []util.GatewayConfigDrift{}
+	}
+	writeJSON(rw, http.StatusOK, drifts)
+}
+
+// failures responds with Kubernetes objects that failed to be translated or pushed in the most recent update.
+func (s *Server) failures(rw http.ResponseWriter, _ *http.Request) {
+	s.ConfigLock.RLock()
+	failing := s.failingObjects
+	s.ConfigLock.RUnlock()
+	if failing == nil {
+		failing = []util.FailingObject{}
+	}
+	writeJSON(rw, http.StatusOK, failing)
+}
+
+const (
+	// configSelectorLatest selects the most recently translated configuration.
+	configSelectorLatest = "latest"
+	// configSelectorApplied selects the most recent configuration that was successfully applied.
+	configSelectorApplied = "applied"
+)
+
+// resolveHistoryEntry returns a config history entry selected by either its numeric ID, configSelectorLatest or
+// configSelectorApplied. An empty selector is replaced with the provided default. configSelectorLatest and
+// configSelectorApplied select configurations of the target, which defaults to gateways when empty.
+func (s *Server) resolveHistoryEntry(selector, defaultSelector, target string) (ConfigHistoryEntry, error) {
+	if selector == "" {
+		selector = defaultSelector
+	}
+	if target == "" {
+		target = metrics.GenerationTargetGateways
+	}
+
+	switch selector {
+	case configSelectorLatest:
+		if entry, ok := s.history.Latest(target); ok {
+			return entry, nil
+		}
+		return ConfigHistoryEntry{}, fmt.Errorf("no configuration has been translated for %s yet", target)
+	case configSelectorApplied:
+		if entry, ok := s.history.LastApplied(target); ok {
+			return entry, nil
+		}
+		return ConfigHistoryEntry{}, fmt.Errorf("no successfully applied configuration for %s in history", target)
+	default:
+		id, err := strconv.ParseUint(selector, 10, 64)
+		if err != nil {
+			return ConfigHistoryEntry{}, fmt.Errorf("invalid config selector %q, expected an ID, %q or %q",
+				selector, configSelectorLatest, configSelectorApplied)
+		}
+		if entry, ok := s.history.Get(id); ok {
+			return entry, nil
+		}
+		return ConfigHistoryEntry{}, fmt.Errorf("configuration %d not found in history", id)
+	}
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(rw http.ResponseWriter, status int, v any) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_ = json.NewEncoder(rw).Encode(v)
+}
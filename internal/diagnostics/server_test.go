@@ -0,0 +1,32 @@
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
+)
+
+func TestServer_RecordsConfigsReceivedWhileListening(t *testing.T) {
+	dumps := util.ConfigDumpDiagnostic{Configs: make(chan util.ConfigDump, 1)}
+	s := NewServer(logr.Discard(), false, dumps, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = s.Listen(ctx, 0) }()
+
+	dumps.Configs <- util.ConfigDump{Config: configWithServices("a")}
+	require.Eventually(t, func() bool {
+		rec := httptest.NewRecorder()
+		s.configHistoryList(rec, httptest.NewRequest(http.MethodGet, "/debug/config/history", nil))
+		var entries []ConfigHistoryEntryMeta
+		return json.Unmarshal(rec.Body.Bytes(), &entries) == nil && len(entries) == 1
+	}, time.Second, 10*time.Millisecond)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/samber/mo"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/labels"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -16,14 +17,18 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/admission"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/annotations"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/gateway"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/diagnostics"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/konnect"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/license"
 	cfgtypes "github.com/kong/kubernetes-ingress-controller/v2/internal/manager/config/types"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/featuregates"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/flags"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object/status"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/versions"
 )
 
 type OptionalNamespacedName = mo.Option[k8stypes.NamespacedName]
@@ -49,12 +54,38 @@ type Config struct {
 	KongAdminInitializationRetryDelay time.Duration
 	KongAdminToken                    string
 	KongAdminTokenPath                string
+	KongAdminCompressDBLessConfig     bool
 	KongWorkspace                     string
 	AnonymousReports                  bool
 	EnableReverseSync                 bool
 	SyncPeriod                        time.Duration
 	SkipCACertificates                bool
 	CacheSyncTimeout                  time.Duration
+	DryRun                            bool
+
+	// Reloading of Kong Admin API TLS material and token files
+	KongAdminCredentialsReloadInterval time.Duration
+
+	// Discovery of Kong Admin APIs outside of Kubernetes Services
+	KongAdminDNSSRVRecords     []string
+	KongAdminURLsFile          string
+	KongAdminURLsConfigMap     OptionalNamespacedName
+	KongAdminDiscoveryInterval time.Duration
+
+	// Backoff of configuration updates of Kong Gateways failing to apply them
+	KongAdminUpdateBackoffEnabled         bool
+	KongAdminUpdateBackoffInitialInterval time.Duration
+	KongAdminUpdateBackoffMaxInterval     time.Duration
+
+	// Audit log of changes made to Kong
+	AuditLogPath            string
+	AuditLogMaxSize         int
+	AuditLogMaxBackups      int
+	AuditLogCaptureRequests bool
+
+	// OpenTelemetry tracing of the pipeline from reconciling objects to pushing configuration to Kong
+	TracingOTLPEndpoint  string
+	TracingSamplingRatio float64
 
 	// Kong Proxy configurations
 	APIServerHost               string
@@ -66,12 +97,39 @@ type Config struct {
 	MetricsAddr                 string
 	ProbeAddr                   string
 	KongAdminURLs               []string
-	KongAdminSvc                OptionalNamespacedName
+	KongAdminSvcs               []k8stypes.NamespacedName
+	KongAdminSvcLabelSelector   labels.Selector
 	GatewayDiscoveryDNSStrategy cfgtypes.DNSStrategy
 	KongAdminSvcPortNames       []string
 	ProxySyncSeconds            float32
+	ProxySyncDebounce           time.Duration
+	ProxySyncMaxWait            time.Duration
+	ProxyResyncPeriod           time.Duration
 	InitCacheSyncDuration       time.Duration
 	ProxyTimeoutSeconds         float32
+	GatewaySyncQuorumPercent    int
+
+	// Staged rollout of configuration to Kong Gateways
+	GatewayCanaryPercent           int
+	GatewayCanaryBakePeriod        time.Duration
+	GatewayCanaryCheckInterval     time.Duration
+	GatewayCanaryMaxErrorRate      float64
+	GatewayCanaryHealthCheck       string
+	GatewayCanaryMaxProxyErrorRate float64
+
+	// Verification of Kong Gateways' convergence to pushed configuration
+	GatewayConvergenceTimeout      time.Duration
+	GatewayConvergencePollInterval time.Duration
+
+	// Detection of changes made to managed entities in DB-mode Kong Gateways out of band
+	GatewayDriftDetectionInterval time.Duration
+	GatewayDriftAutoCorrect       bool
+
+	// Declarative configuration outputs
+	DeclarativeConfigOutputFile      string
+	DeclarativeConfigOutputConfigMap OptionalNamespacedName
+	DeclarativeConfigKongVersion     string
+	DeclarativeConfigRouterFlavor    string
 
 	// Kubernetes configurations
 	KubeconfigPath           string
@@ -109,10 +167,11 @@ type Config struct {
 	AdmissionServer admission.ServerConfig
 
 	// Diagnostics and performance
-	EnableProfiling      bool
-	EnableConfigDumps    bool
-	DumpSensitiveConfig  bool
-	DiagnosticServerPort int
+	EnableProfiling       bool
+	EnableConfigDumps     bool
+	DumpSensitiveConfig   bool
+	ConfigDumpHistorySize int
+	DiagnosticServerPort  int
 
 	// Feature Gates
 	FeatureGates map[string]bool
@@ -156,12 +215,39 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.DurationVar(&c.KongAdminInitializationRetryDelay, "kong-admin-init-retry-delay", time.Second*1, "The time delay between every attempt (on controller startup) to connect to the Kong Admin API")
 	flagSet.StringVar(&c.KongAdminToken, "kong-admin-token", "", `The Kong Enterprise RBAC token used by the controller.`)
 	flagSet.StringVar(&c.KongAdminTokenPath, "kong-admin-token-file", "", `Path to the Kong Enterprise RBAC token file used by the controller.`)
+	flagSet.DurationVar(&c.KongAdminCredentialsReloadInterval, "kong-admin-credentials-reload-interval", adminapi.DefaultTransportReloadInterval,
+		"Interval of checking Kong Admin API CA certificate, mTLS client certificate and key, and RBAC token files for changes (e.g. rotation), which are then reloaded without a restart. Zero disables reloading.")
+	flagSet.BoolVar(&c.KongAdminCompressDBLessConfig, "kong-admin-compress-dbless-config", false,
+		`Compress configuration sent to DB-less Kong Gateways' Admin API with gzip. Gateways responding they don't support it get it uncompressed.`)
+	flagSet.BoolVar(&c.KongAdminUpdateBackoffEnabled, "kong-admin-update-backoff-enabled", true,
+		`Stop sending configuration updates to a Kong Gateway failing to apply them for a while: a configuration rejected by the gateway is not sent again until it changes, `+
+			`while server and network errors make updates back off exponentially until one succeeds.`)
+	flagSet.DurationVar(&c.KongAdminUpdateBackoffInitialInterval, "kong-admin-update-backoff-initial-interval", adminapi.DefaultGatewayBackoffInitialInterval,
+		`Initial interval of backing off configuration updates of a Kong Gateway after a server or network error (see --kong-admin-update-backoff-enabled).`)
+	flagSet.DurationVar(&c.KongAdminUpdateBackoffMaxInterval, "kong-admin-update-backoff-max-interval", adminapi.DefaultGatewayBackoffMaxInterval,
+		`Maximum interval of backing off configuration updates of a Kong Gateway after consecutive server or network errors (see --kong-admin-update-backoff-enabled).`)
+	flagSet.StringVar(&c.AuditLogPath, "audit-log-path", "",
+		`Path of a file to write an audit log of configuration pushes and mutating Kong Admin API requests to, as lines of JSON. Use "-" to write it to the standard output. Leave this empty to disable the audit log.`)
+	flagSet.IntVar(&c.AuditLogMaxSize, "audit-log-max-size", audit.DefaultMaxFileSizeMegabytes,
+		`Size in megabytes the audit log file (see --audit-log-path) is rotated at.`)
+	flagSet.IntVar(&c.AuditLogMaxBackups, "audit-log-max-backups", audit.DefaultMaxFileBackups,
+		`Number of rotated audit log files (see --audit-log-path) that are kept.`)
+	flagSet.BoolVar(&c.AuditLogCaptureRequests, "audit-log-capture-requests", false,
+		`Record raw mutating Kong Admin API requests (headers with credentials redacted, and bodies) in the audit log (see --audit-log-path). Meant for debugging, as records may get large and contain sensitive configuration.`)
+	flagSet.StringVar(&c.TracingOTLPEndpoint, "tracing-otlp-endpoint", "",
+		`URL of an OTLP/HTTP endpoint (e.g. http://otel-collector:4318) to export OpenTelemetry traces of reconciling objects and pushing configuration to Kong to. `+
+			`The trace context is propagated to Kong in Admin API request headers. Leave this empty to disable tracing.`)
+	flagSet.Float64Var(&c.TracingSamplingRatio, "tracing-sampling-ratio", tracing.DefaultSamplingRatio,
+		`Ratio (between 0 and 1) of traces that are sampled (see --tracing-otlp-endpoint).`)
 	flagSet.StringVar(&c.KongWorkspace, "kong-workspace", "", "Kong Enterprise workspace to configure. Leave this empty if not using Kong workspaces.")
 	flagSet.BoolVar(&c.AnonymousReports, "anonymous-reports", true, `Send anonymized usage data to help improve Kong`)
 	flagSet.BoolVar(&c.EnableReverseSync, "enable-reverse-sync", false, `Send configuration to Kong even if the configuration checksum has not changed since previous update.`)
 	flagSet.DurationVar(&c.SyncPeriod, "sync-period", time.Hour*48, `Relist and confirm cloud resources this often`) // 48 hours derived from controller-runtime defaults
 	flagSet.BoolVar(&c.SkipCACertificates, "skip-ca-certificates", false, `disable syncing CA certificate syncing (for use with multi-workspace environments)`)
 	flagSet.DurationVar(&c.CacheSyncTimeout, "cache-sync-timeout", 0, `The time limit set to wait for syncing controllers' caches. Leave this empty to use default from controller-runtime.`)
+	flagSet.BoolVar(&c.DryRun, "dry-run", false, `Generate Kong configuration without ever sending it to Kong or Konnect. `+
+		`The generated configuration is exposed via the diagnostics server (see --dump-config) and compared with the configuration loaded by Kong. `+
+		`When running next to another controller instance, use a different --election-id and consider disabling --update-status.`)
 	flagSet.StringVar(&c.KongAdminAPIConfig.TLSClient.CertFile, "kong-admin-tls-client-cert-file", "", "mTLS client certificate file for authentication.")
 	flagSet.StringVar(&c.KongAdminAPIConfig.TLSClient.KeyFile, "kong-admin-tls-client-key-file", "", "mTLS client key file for authentication.")
 	flagSet.StringVar(&c.KongAdminAPIConfig.TLSClient.Cert, "kong-admin-tls-client-cert", "", "mTLS client certificate for authentication.")
@@ -171,13 +257,38 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.StringSliceVar(&c.KongAdminURLs, "kong-admin-url", []string{"http://localhost:8001"},
 		`Kong Admin URL(s) to connect to in the format "protocol://address:port". `+
 			`More than 1 URL can be provided, in such case the flag should be used multiple times or a corresponding env variable should use comma delimited addresses.`)
-	flagSet.Var(flags.NewValidatedValue(&c.KongAdminSvc, namespacedNameFromFlagValue, nnTypeNameOverride), "kong-admin-svc",
-		`Kong Admin API Service namespaced name in "namespace/name" format, to use for Kong Gateway service discovery.`)
+	flagSet.Var(flags.NewValidatedValue(&c.KongAdminSvcs, namespacedNamesFromFlagValue, flags.WithTypeNameOverride[[]k8stypes.NamespacedName]("namespacedNames")), "kong-admin-svc",
+		`Kong Admin API Service namespaced name(s) in "namespace/name" format, to use for Kong Gateway service discovery. `+
+			`More than 1 Service can be provided as a comma delimited list.`)
+	flagSet.Var(flags.NewValidatedValue(&c.KongAdminSvcLabelSelector, labelSelectorFromFlagValue, flags.WithTypeNameOverride[labels.Selector]("labelSelector")), "kong-admin-svc-label-selector",
+		`Label selector of Kong Admin API Services in any namespace to use for Kong Gateway service discovery, in addition to Services set with --kong-admin-svc. `+
+			`When --watch-namespace is set, only matching Services in the watched namespaces are discovered.`)
+	flagSet.StringSliceVar(&c.KongAdminDNSSRVRecords, "kong-admin-dns-srv", nil,
+		`DNS SRV record name(s) (e.g. "_kong-admin._tcp.gateways.example.com") to discover Kong Admin APIs from, e.g. ones of Kong Gateways running outside of the cluster. `+
+			`Admin APIs are assumed to be served over HTTPS.`)
+	flagSet.StringVar(&c.KongAdminURLsFile, "kong-admin-urls-file", "",
+		`Path of a file listing Kong Admin API URLs in the format "protocol://address:port" (one per line) to discover Kong Admin APIs from. The file is watched for changes.`)
+	flagSet.Var(flags.NewValidatedValue(&c.KongAdminURLsConfigMap, namespacedNameFromFlagValue, nnTypeNameOverride), "kong-admin-urls-configmap",
+		`ConfigMap namespaced name in "namespace/name" format whose values list Kong Admin API URLs in the format "protocol://address:port" (one per line) to discover Kong Admin APIs from. The ConfigMap is watched for changes.`)
+	flagSet.DurationVar(&c.KongAdminDiscoveryInterval, "kong-admin-discovery-interval", adminapi.DefaultDiscoverySourcePollInterval,
+		`Interval of polling --kong-admin-dns-srv, --kong-admin-urls-file and --kong-admin-urls-configmap for changes of discovered Kong Admin APIs.`)
 	flagSet.StringSliceVar(&c.KongAdminSvcPortNames, "kong-admin-svc-port-names", []string{"admin", "admin-tls", "kong-admin", "kong-admin-tls"},
 		"Names of ports on Kong Admin API service to take into account when doing gateway discovery.")
 	flagSet.Var(flags.NewValidatedValue(&c.GatewayDiscoveryDNSStrategy, dnsStrategyFromFlagValue, flags.WithDefault(cfgtypes.IPDNSStrategy), flags.WithTypeNameOverride[cfgtypes.DNSStrategy]("dns-strategy")),
 		"gateway-discovery-dns-strategy", "DNS strategy to use when creating Gateway's Admin API addresses. One of: ip, service, pod.")
 
+	// Declarative configuration outputs
+	flagSet.StringVar(&c.DeclarativeConfigOutputFile, "declarative-config-output-file", "",
+		`Path of a file to write Kong's DB-less configuration to instead of sending it to Kong's Admin API. `+
+			`The file can be loaded by Kong Gateway with its "declarative_config" setting.`)
+	flagSet.Var(flags.NewValidatedValue(&c.DeclarativeConfigOutputConfigMap, namespacedNameFromFlagValue, nnTypeNameOverride), "declarative-config-output-configmap",
+		`ConfigMap namespaced name in "namespace/name" format to write Kong's DB-less configuration to instead of sending it to Kong's Admin API. `+
+			`Configurations exceeding the size limit of a ConfigMap are split into ConfigMaps suffixed with "-1", "-2", etc.`)
+	flagSet.StringVar(&c.DeclarativeConfigKongVersion, "declarative-config-kong-version", versions.KICv3VersionCutoff.String(),
+		`Version of Kong Gateway to generate the configuration for when writing it to a declarative configuration output.`)
+	flagSet.StringVar(&c.DeclarativeConfigRouterFlavor, "declarative-config-router-flavor", "traditional_compatible",
+		`Router flavor of Kong Gateway to generate the configuration for when writing it to a declarative configuration output.`)
+
 	// Kong Proxy and Proxy Cache configurations
 	flagSet.StringVar(&c.APIServerHost, "apiserver-host", "", `The Kubernetes API server URL. If not set, the controller will use cluster config discovery.`)
 	flagSet.IntVar(&c.APIServerQPS, "apiserver-qps", 100, "The Kubernetes API RateLimiter maximum queries per second")
@@ -185,9 +296,38 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.StringVar(&c.MetricsAddr, "metrics-bind-address", fmt.Sprintf(":%v", MetricsPort), "The address the metric endpoint binds to.")
 	flagSet.StringVar(&c.ProbeAddr, "health-probe-bind-address", fmt.Sprintf(":%v", HealthzPort), "The address the probe endpoint binds to.")
 	flagSet.Float32Var(&c.ProxySyncSeconds, "proxy-sync-seconds", dataplane.DefaultSyncSeconds,
-		"Define the rate (in seconds) in which configuration updates will be applied to the Kong Admin API.")
+		"Define the rate (in seconds) in which configuration updates will be applied to the Kong Admin API when event-driven sync is disabled. "+
+			"When it's enabled, failed configuration updates are retried at this rate.")
+	flagSet.DurationVar(&c.ProxySyncDebounce, "proxy-sync-debounce", dataplane.DefaultSyncDebounce,
+		"Period without changes of Kubernetes objects to wait for before applying configuration updates to the Kong Admin API. Zero disables event-driven sync in favor of applying updates at the --proxy-sync-seconds rate.")
+	flagSet.DurationVar(&c.ProxySyncMaxWait, "proxy-sync-max-wait", dataplane.DefaultSyncMaxWait,
+		"Maximum period a change of Kubernetes objects waits for being applied to the Kong Admin API while further changes keep coming (event-driven sync only).")
+	flagSet.DurationVar(&c.ProxyResyncPeriod, "proxy-resync-period", dataplane.DefaultResyncPeriod,
+		"Period of applying configuration updates to the Kong Admin API regardless of changes of Kubernetes objects (event-driven sync only).")
 	flagSet.Float32Var(&c.ProxyTimeoutSeconds, "proxy-timeout-seconds", dataplane.DefaultTimeoutSeconds,
 		"Sets the timeout (in seconds) for all requests to Kong's Admin API.")
+	flagSet.Var(flags.NewValidatedValue(&c.GatewaySyncQuorumPercent, percentFromFlagValue, flags.WithDefault(100), flags.WithTypeNameOverride[int]("int")), "gateway-sync-quorum-percent",
+		"Percentage of Kong Gateways that have to apply the configuration for a sync to be considered successful. Gateways that failed to apply it are retried with the next sync.")
+	flagSet.IntVar(&c.GatewayCanaryPercent, "gateway-canary-percent", 0,
+		"Percentage of Kong Gateways that get a changed configuration first, before it's rolled out to the rest of them. Zero disables the staged rollout.")
+	flagSet.DurationVar(&c.GatewayCanaryBakePeriod, "gateway-canary-bake-period", dataplane.DefaultCanaryBakePeriod,
+		"Period canary Kong Gateways are health-checked for before the configuration is rolled out to the rest of them.")
+	flagSet.DurationVar(&c.GatewayCanaryCheckInterval, "gateway-canary-check-interval", dataplane.DefaultCanaryCheckInterval,
+		"Interval of canary Kong Gateways' health checks during the bake period.")
+	flagSet.Float64Var(&c.GatewayCanaryMaxErrorRate, "gateway-canary-max-error-rate", 0,
+		"Maximum ratio (0 to 1) of failed canary Kong Gateways' health checks during the bake period that still allows rolling out the configuration. Otherwise, canaries are reverted to the last valid configuration.")
+	flagSet.StringVar(&c.GatewayCanaryHealthCheck, "gateway-canary-health-check", dataplane.CanaryHealthCheckStatus,
+		`Signal canary Kong Gateways are health-checked with during the bake period. Allowed values are status (readiness of the Admin API's /status endpoint) and proxy-error-rate (rate of proxied requests that got a 5xx response, reported on the Admin API's /metrics endpoint by the Prometheus plugin).`)
+	flagSet.Float64Var(&c.GatewayCanaryMaxProxyErrorRate, "gateway-canary-max-proxy-error-rate", dataplane.DefaultCanaryMaxProxyErrorRate,
+		"Maximum ratio (0 to 1) of requests proxied by a canary Kong Gateway between its health checks that may get a 5xx response for the check to pass (--gateway-canary-health-check=proxy-error-rate only).")
+	flagSet.DurationVar(&c.GatewayConvergenceTimeout, "gateway-convergence-timeout", 0,
+		"Maximum time to wait for DB-less Kong Gateways to report the pushed configuration's hash in their /status endpoint. Gateways reporting a different configuration are pushed it again. Zero disables the verification.")
+	flagSet.DurationVar(&c.GatewayConvergencePollInterval, "gateway-convergence-poll-interval", dataplane.DefaultConvergencePollInterval,
+		"Interval of polling DB-less Kong Gateways' /status endpoint while waiting for them to report the pushed configuration.")
+	flagSet.DurationVar(&c.GatewayDriftDetectionInterval, "gateway-drift-detection-interval", 0,
+		"Interval of detecting changes made out of band (e.g. through the Admin API) to entities tagged as managed by the controller in DB-mode Kong Gateways. Zero disables the detection.")
+	flagSet.BoolVar(&c.GatewayDriftAutoCorrect, "gateway-drift-auto-correct", false,
+		"Push the configuration again to DB-mode Kong Gateways whose managed entities were found changed out of band. Otherwise, the drift is only reported.")
 
 	// Kubernetes configurations
 	flagSet.Var(flags.NewValidatedValue(&c.GatewayAPIControllerName, gatewayAPIControllerNameFromFlagValue, flags.WithDefault(string(gateway.GetControllerName()))), "gateway-api-controller-name", "The controller name to match on Gateway API resources.")
@@ -246,6 +386,8 @@ func (c *Config) FlagSet() *pflag.FlagSet {
 	flagSet.BoolVar(&c.EnableProfiling, "profiling", false, fmt.Sprintf("Enable profiling via web interface host:%v/debug/pprof/", DiagnosticsPort))
 	flagSet.BoolVar(&c.EnableConfigDumps, "dump-config", false, fmt.Sprintf("Enable config dumps via web interface host:%v/debug/config", DiagnosticsPort))
 	flagSet.BoolVar(&c.DumpSensitiveConfig, "dump-sensitive-config", false, "Include credentials and TLS secrets in configs exposed with --dump-config")
+	flagSet.IntVar(&c.ConfigDumpHistorySize, "dump-config-history-size", diagnostics.DefaultConfigHistorySize,
+		fmt.Sprintf("Number of most recent configs kept for history and diff endpoints exposed with --dump-config via web interface host:%v/debug/config/history", DiagnosticsPort))
 
 	// Feature Gates (see FEATURE_GATES.md)
 	flagSet.Var(cliflag.NewMapStringBool(&c.FeatureGates), "feature-gates", "A set of key=value pairs that describe feature gates for alpha/beta/experimental features. "+
@@ -296,6 +438,38 @@ func (c *Config) Resolve() error {
 	return nil
 }
 
+// EventDrivenSyncEnabled tells whether configuration updates are applied to the Kong Admin API in reaction to
+// changes of Kubernetes objects rather than at a fixed rate.
+func (c *Config) EventDrivenSyncEnabled() bool {
+	return c.ProxySyncDebounce > 0
+}
+
+// AdminAPIServiceSelector returns the selector of Kong Admin API Services to use for Kong Gateway service discovery.
+func (c *Config) AdminAPIServiceSelector() adminapi.ServiceSelector {
+	return adminapi.ServiceSelector{
+		Services:      c.KongAdminSvcs,
+		LabelSelector: c.KongAdminSvcLabelSelector,
+	}
+}
+
+// GatewayDiscoveryEnabled tells whether Kong Gateways are discovered dynamically, either through Kong Admin API
+// Services or other discovery sources.
+func (c *Config) GatewayDiscoveryEnabled() bool {
+	return !c.AdminAPIServiceSelector().IsEmpty() || c.adminAPIDiscoverySourcesEnabled()
+}
+
+// adminAPIDiscoverySourcesEnabled tells whether Kong Admin APIs are discovered from sources other than Kong Admin
+// API Services, which are polled for changes.
+func (c *Config) adminAPIDiscoverySourcesEnabled() bool {
+	return len(c.KongAdminDNSSRVRecords) > 0 || c.KongAdminURLsFile != "" || c.KongAdminURLsConfigMap.IsPresent()
+}
+
+// DeclarativeConfigOutputsEnabled tells whether the configuration is written to declarative configuration
+// outputs instead of being sent to Kong's Admin API.
+func (c *Config) DeclarativeConfigOutputsEnabled() bool {
+	return c.DeclarativeConfigOutputFile != "" || c.DeclarativeConfigOutputConfigMap.IsPresent()
+}
+
 func (c *Config) GetKubeconfig() (*rest.Config, error) {
 	config, err := clientcmd.BuildConfigFromFlags(c.APIServerHost, c.KubeconfigPath)
 	if err != nil {
@@ -4,13 +4,18 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/blang/semver/v4"
 	"github.com/samber/mo"
+	"k8s.io/apimachinery/pkg/labels"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
 	cfgtypes "github.com/kong/kubernetes-ingress-controller/v2/internal/manager/config/types"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	dataplaneutil "github.com/kong/kubernetes-ingress-controller/v2/internal/util/dataplane"
 )
 
@@ -38,6 +43,29 @@ func namespacedNameFromFlagValue(flagValue string) (OptionalNamespacedName, erro
 	}), nil
 }
 
+func namespacedNamesFromFlagValue(flagValue string) ([]k8stypes.NamespacedName, error) {
+	var nns []k8stypes.NamespacedName
+	for _, v := range strings.Split(flagValue, ",") {
+		nn, err := namespacedNameFromFlagValue(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %q: %w", v, err)
+		}
+		nns = append(nns, nn.MustGet())
+	}
+	return nns, nil
+}
+
+func labelSelectorFromFlagValue(flagValue string) (labels.Selector, error) {
+	selector, err := labels.Parse(flagValue)
+	if err != nil {
+		return nil, err
+	}
+	if selector.Empty() {
+		return nil, errors.New("label selector cannot be empty")
+	}
+	return selector, nil
+}
+
 func gatewayAPIControllerNameFromFlagValue(flagValue string) (string, error) {
 	if !gatewayAPIControllerNameRegex.MatchString(flagValue) {
 		return "", errors.New("the expected format is example.com/controller-name")
@@ -53,6 +81,17 @@ func dnsStrategyFromFlagValue(flagValue string) (cfgtypes.DNSStrategy, error) {
 	return strategy, nil
 }
 
+func percentFromFlagValue(flagValue string) (int, error) {
+	percent, err := strconv.Atoi(flagValue)
+	if err != nil {
+		return 0, errors.New("the expected format is an integer")
+	}
+	if percent < 1 || percent > 100 {
+		return 0, errors.New("the value has to be between 1 and 100")
+	}
+	return percent, nil
+}
+
 // Validate validates the config. It should be used to validate the config variables' interdependencies.
 // When a single variable is to be validated, *FromFlagValue function should be implemented.
 func (c *Config) Validate() error {
@@ -60,6 +99,13 @@ func (c *Config) Validate() error {
 		if c.flagSet.Changed("kong-admin-svc") && c.flagSet.Changed("kong-admin-url") {
 			return fmt.Errorf("can't set both --kong-admin-svc and --kong-admin-url")
 		}
+		for _, discoveryFlag := range []string{
+			"kong-admin-svc-label-selector", "kong-admin-dns-srv", "kong-admin-urls-file", "kong-admin-urls-configmap",
+		} {
+			if c.flagSet.Changed(discoveryFlag) && c.flagSet.Changed("kong-admin-url") {
+				return fmt.Errorf("can't set both --%s and --kong-admin-url", discoveryFlag)
+			}
+		}
 	}
 	if c.KongAdminToken != "" && c.KongAdminTokenPath != "" {
 		return errors.New("both admin token and admin token file specified, only one allowed")
@@ -71,6 +117,59 @@ func (c *Config) Validate() error {
 	if err := c.validateKongAdminAPI(); err != nil {
 		return fmt.Errorf("invalid kong admin api configuration: %w", err)
 	}
+	if err := c.validateDeclarativeConfigOutputs(); err != nil {
+		return fmt.Errorf("invalid declarative configuration outputs: %w", err)
+	}
+	if err := c.validateGatewayCanaryRollout(); err != nil {
+		return fmt.Errorf("invalid gateway canary rollout configuration: %w", err)
+	}
+	if c.EventDrivenSyncEnabled() {
+		if c.ProxySyncMaxWait < c.ProxySyncDebounce {
+			return errors.New("--proxy-sync-max-wait can't be shorter than --proxy-sync-debounce")
+		}
+		if c.ProxyResyncPeriod <= 0 {
+			return errors.New("--proxy-resync-period has to be positive")
+		}
+	}
+	if c.GatewayConvergenceTimeout < 0 {
+		return errors.New("--gateway-convergence-timeout can't be negative")
+	}
+	if c.GatewayConvergenceTimeout > 0 && c.GatewayConvergencePollInterval <= 0 {
+		return errors.New("--gateway-convergence-poll-interval has to be positive")
+	}
+	if c.KongAdminCredentialsReloadInterval < 0 {
+		return errors.New("--kong-admin-credentials-reload-interval can't be negative")
+	}
+	if c.adminAPIDiscoverySourcesEnabled() && c.KongAdminDiscoveryInterval <= 0 {
+		return errors.New("--kong-admin-discovery-interval has to be positive")
+	}
+	if c.KongAdminUpdateBackoffEnabled {
+		if c.KongAdminUpdateBackoffInitialInterval <= 0 {
+			return errors.New("--kong-admin-update-backoff-initial-interval has to be positive")
+		}
+		if c.KongAdminUpdateBackoffMaxInterval < c.KongAdminUpdateBackoffInitialInterval {
+			return errors.New("--kong-admin-update-backoff-max-interval can't be shorter than --kong-admin-update-backoff-initial-interval")
+		}
+	}
+	if c.GatewayDriftDetectionInterval < 0 {
+		return errors.New("--gateway-drift-detection-interval can't be negative")
+	}
+	if c.TracingOTLPEndpoint != "" {
+		if err := tracing.ValidateEndpoint(c.TracingOTLPEndpoint); err != nil {
+			return fmt.Errorf("invalid --tracing-otlp-endpoint: %w", err)
+		}
+		if c.TracingSamplingRatio < 0 || c.TracingSamplingRatio > 1 {
+			return errors.New("--tracing-sampling-ratio has to be between 0 and 1")
+		}
+	}
+	if c.AuditLogPath != "" {
+		if c.AuditLogMaxSize <= 0 {
+			return errors.New("--audit-log-max-size has to be positive")
+		}
+		if c.AuditLogMaxBackups < 0 {
+			return errors.New("--audit-log-max-backups can't be negative")
+		}
+	}
 
 	return nil
 }
@@ -81,8 +180,8 @@ func (c *Config) validateKonnect() error {
 		return nil
 	}
 
-	if c.KongAdminSvc.IsAbsent() {
-		return errors.New("--kong-admin-svc has to be set when using --konnect-sync-enabled")
+	if !c.GatewayDiscoveryEnabled() {
+		return errors.New("gateway discovery (e.g. --kong-admin-svc) has to be enabled when using --konnect-sync-enabled")
 	}
 	if konnect.Address == "" {
 		return errors.New("address not specified")
@@ -106,6 +205,29 @@ func (c *Config) validateKongAdminAPI() error {
 	return nil
 }
 
+func (c *Config) validateDeclarativeConfigOutputs() error {
+	if !c.DeclarativeConfigOutputsEnabled() {
+		return nil
+	}
+
+	if c.flagSet != nil && c.flagSet.Changed("kong-admin-url") {
+		return errors.New("--kong-admin-url can't be set when writing configuration to declarative configuration outputs")
+	}
+	if c.GatewayDiscoveryEnabled() {
+		return errors.New("gateway discovery (e.g. --kong-admin-svc) can't be enabled when writing configuration to declarative configuration outputs")
+	}
+	if c.Konnect.ConfigSynchronizationEnabled {
+		return errors.New("--konnect-sync-enabled can't be set when writing configuration to declarative configuration outputs")
+	}
+	if c.DryRun {
+		return errors.New("--dry-run can't be set when writing configuration to declarative configuration outputs")
+	}
+	if _, err := semver.ParseTolerant(c.DeclarativeConfigKongVersion); err != nil {
+		return fmt.Errorf("invalid --declarative-config-kong-version %q: %w", c.DeclarativeConfigKongVersion, err)
+	}
+	return nil
+}
+
 func validateClientTLS(clientTLS adminapi.TLSClientConfig) error {
 	if clientTLS.Cert != "" && clientTLS.CertFile != "" {
 		return errors.New("both client certificate and client certificate file specified, only one allowed")
@@ -132,7 +254,7 @@ func validateClientTLS(clientTLS adminapi.TLSClientConfig) error {
 // gateway discovery is only supported in db-less mode in its initial release:
 // https://github.com/Kong/kubernetes-ingress-controller/issues/3401
 func (c *Config) ValidateGatewayDiscovery(dbMode string) error {
-	if c.KongAdminSvc.IsAbsent() {
+	if !c.GatewayDiscoveryEnabled() {
 		return nil
 	}
 	if !dataplaneutil.IsDBLessMode(dbMode) {
@@ -140,3 +262,33 @@ func (c *Config) ValidateGatewayDiscovery(dbMode string) error {
 	}
 	return nil
 }
+
+func (c *Config) validateGatewayCanaryRollout() error {
+	if c.GatewayCanaryPercent < 0 || c.GatewayCanaryPercent > 100 {
+		return fmt.Errorf("--gateway-canary-percent has to be between 0 and 100, got %d", c.GatewayCanaryPercent)
+	}
+	if c.GatewayCanaryPercent == 0 {
+		return nil
+	}
+
+	if c.GatewayCanaryBakePeriod <= 0 {
+		return errors.New("--gateway-canary-bake-period has to be positive")
+	}
+	if c.GatewayCanaryCheckInterval <= 0 {
+		return errors.New("--gateway-canary-check-interval has to be positive")
+	}
+	if c.GatewayCanaryMaxErrorRate < 0 || c.GatewayCanaryMaxErrorRate > 1 {
+		return fmt.Errorf("--gateway-canary-max-error-rate has to be between 0 and 1, got %v", c.GatewayCanaryMaxErrorRate)
+	}
+	switch c.GatewayCanaryHealthCheck {
+	case "", dataplane.CanaryHealthCheckStatus:
+	case dataplane.CanaryHealthCheckProxyErrorRate:
+		if c.GatewayCanaryMaxProxyErrorRate < 0 || c.GatewayCanaryMaxProxyErrorRate > 1 {
+			return fmt.Errorf("--gateway-canary-max-proxy-error-rate has to be between 0 and 1, got %v", c.GatewayCanaryMaxProxyErrorRate)
+		}
+	default:
+		return fmt.Errorf("--gateway-canary-health-check has to be one of %q and %q, got %q",
+			dataplane.CanaryHealthCheckStatus, dataplane.CanaryHealthCheckProxyErrorRate, c.GatewayCanaryHealthCheck)
+	}
+	return nil
+}
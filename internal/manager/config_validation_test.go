@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/samber/mo"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/labels"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
@@ -140,9 +142,19 @@ func TestConfigValidatedVars(t *testing.T) {
 			{
 				Input: "namespace/servicename",
 				ExtractValueFn: func(c manager.Config) any {
-					return c.KongAdminSvc
+					return c.KongAdminSvcs
+				},
+				ExpectedValue: []k8stypes.NamespacedName{{Namespace: "namespace", Name: "servicename"}},
+			},
+			{
+				Input: "namespace/servicename,other-namespace/other-servicename",
+				ExtractValueFn: func(c manager.Config) any {
+					return c.KongAdminSvcs
+				},
+				ExpectedValue: []k8stypes.NamespacedName{
+					{Namespace: "namespace", Name: "servicename"},
+					{Namespace: "other-namespace", Name: "other-servicename"},
 				},
-				ExpectedValue: mo.Some(k8stypes.NamespacedName{Namespace: "namespace", Name: "servicename"}),
 			},
 			{
 				Input:                 "namespace/",
@@ -152,6 +164,23 @@ func TestConfigValidatedVars(t *testing.T) {
 				Input:                 "/name",
 				ExpectedErrorContains: "namespace cannot be empty",
 			},
+			{
+				Input:                 "namespace/servicename,servicename",
+				ExpectedErrorContains: "the expected format is namespace/name",
+			},
+		},
+		"--kong-admin-svc-label-selector": {
+			{
+				Input: "app=kong,tier in (gateway)",
+				ExtractValueFn: func(c manager.Config) any {
+					return c.KongAdminSvcLabelSelector.String()
+				},
+				ExpectedValue: "app=kong,tier in (gateway)",
+			},
+			{
+				Input:                 "app in (",
+				ExpectedErrorContains: "unable to parse requirement",
+			},
 		},
 		"--konnect-runtime-group-id": {
 			{
@@ -172,6 +201,34 @@ func TestConfigValidatedVars(t *testing.T) {
 				ExpectedValue: "5ef731c0-6081-49d6-b3ec-d4f85e58b956",
 			},
 		},
+		"--gateway-sync-quorum-percent": {
+			{
+				Input: "",
+				ExtractValueFn: func(c manager.Config) any {
+					return c.GatewaySyncQuorumPercent
+				},
+				ExpectedValue: 100,
+			},
+			{
+				Input: "50",
+				ExtractValueFn: func(c manager.Config) any {
+					return c.GatewaySyncQuorumPercent
+				},
+				ExpectedValue: 50,
+			},
+			{
+				Input:                 "0",
+				ExpectedErrorContains: "the value has to be between 1 and 100",
+			},
+			{
+				Input:                 "101",
+				ExpectedErrorContains: "the value has to be between 1 and 100",
+			},
+			{
+				Input:                 "half",
+				ExpectedErrorContains: "the expected format is an integer",
+			},
+		},
 	}
 
 	for flag, flagTestCases := range testCasesGroupedByFlag {
@@ -204,7 +261,7 @@ func TestConfigValidate(t *testing.T) {
 	t.Run("konnect", func(t *testing.T) {
 		validEnabled := func() *manager.Config {
 			return &manager.Config{
-				KongAdminSvc: mo.Some(k8stypes.NamespacedName{Name: "admin-svc", Namespace: "ns"}),
+				KongAdminSvcs: []k8stypes.NamespacedName{{Name: "admin-svc", Namespace: "ns"}},
 				Konnect: adminapi.KonnectConfig{
 					ConfigSynchronizationEnabled: true,
 					ControlPlaneID:               "fbd3036f-0f1c-4e98-b71c-d4cd61213f90",
@@ -275,8 +332,23 @@ func TestConfigValidate(t *testing.T) {
 
 		t.Run("enabled with no gateway service discovery enabled", func(t *testing.T) {
 			c := validEnabled()
-			c.KongAdminSvc = manager.OptionalNamespacedName{}
-			require.ErrorContains(t, c.Validate(), "--kong-admin-svc has to be set when using --konnect-sync-enabled")
+			c.KongAdminSvcs = nil
+			require.ErrorContains(t, c.Validate(), "gateway discovery (e.g. --kong-admin-svc) has to be enabled when using --konnect-sync-enabled")
+		})
+
+		t.Run("enabled with gateway service discovery by labels", func(t *testing.T) {
+			c := validEnabled()
+			c.KongAdminSvcs = nil
+			c.KongAdminSvcLabelSelector = labels.SelectorFromSet(labels.Set{"app": "kong"})
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("enabled with gateway discovery through DNS SRV records", func(t *testing.T) {
+			c := validEnabled()
+			c.KongAdminSvcs = nil
+			c.KongAdminDNSSRVRecords = []string{"_kong-admin._tcp.gateways.example.com"}
+			c.KongAdminDiscoveryInterval = time.Second
+			require.NoError(t, c.Validate())
 		})
 	})
 
@@ -362,6 +434,247 @@ func TestConfigValidate(t *testing.T) {
 			require.ErrorContains(t, c.Validate(), "both admin token and admin token file specified, only one allowed")
 		})
 	})
+
+	t.Run("Declarative config outputs", func(t *testing.T) {
+		validWithOutputs := func() manager.Config {
+			return manager.Config{
+				DeclarativeConfigOutputFile:      "/kong/kong.json",
+				DeclarativeConfigOutputConfigMap: mo.Some(k8stypes.NamespacedName{Namespace: "kong", Name: "kong-config"}),
+				DeclarativeConfigKongVersion:     "3.4.1",
+			}
+		}
+
+		t.Run("outputs accepted", func(t *testing.T) {
+			c := validWithOutputs()
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("outputs with kong admin svc rejected", func(t *testing.T) {
+			c := validWithOutputs()
+			c.KongAdminSvcs = []k8stypes.NamespacedName{{Namespace: "kong", Name: "admin"}}
+			require.ErrorContains(t, c.Validate(), "gateway discovery (e.g. --kong-admin-svc) can't be enabled")
+		})
+
+		t.Run("outputs with dry run rejected", func(t *testing.T) {
+			c := validWithOutputs()
+			c.DryRun = true
+			require.ErrorContains(t, c.Validate(), "--dry-run can't be set")
+		})
+
+		t.Run("outputs with invalid kong version rejected", func(t *testing.T) {
+			c := validWithOutputs()
+			c.DeclarativeConfigKongVersion = "not-a-version"
+			require.ErrorContains(t, c.Validate(), "invalid --declarative-config-kong-version")
+		})
+	})
+
+	t.Run("Gateway canary rollout", func(t *testing.T) {
+		validWithCanaries := func() manager.Config {
+			return manager.Config{
+				GatewayCanaryPercent:       25,
+				GatewayCanaryBakePeriod:    time.Minute,
+				GatewayCanaryCheckInterval: 5 * time.Second,
+				GatewayCanaryMaxErrorRate:  0.1,
+			}
+		}
+
+		t.Run("canary rollout accepted", func(t *testing.T) {
+			c := validWithCanaries()
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("disabled canary rollout ignores other settings", func(t *testing.T) {
+			c := manager.Config{GatewayCanaryMaxErrorRate: 2}
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("invalid percent rejected", func(t *testing.T) {
+			c := validWithCanaries()
+			c.GatewayCanaryPercent = 101
+			require.ErrorContains(t, c.Validate(), "--gateway-canary-percent has to be between 0 and 100")
+		})
+
+		t.Run("non-positive bake period rejected", func(t *testing.T) {
+			c := validWithCanaries()
+			c.GatewayCanaryBakePeriod = 0
+			require.ErrorContains(t, c.Validate(), "--gateway-canary-bake-period has to be positive")
+		})
+
+		t.Run("non-positive check interval rejected", func(t *testing.T) {
+			c := validWithCanaries()
+			c.GatewayCanaryCheckInterval = 0
+			require.ErrorContains(t, c.Validate(), "--gateway-canary-check-interval has to be positive")
+		})
+
+		t.Run("invalid max error rate rejected", func(t *testing.T) {
+			c := validWithCanaries()
+			c.GatewayCanaryMaxErrorRate = 1.5
+			require.ErrorContains(t, c.Validate(), "--gateway-canary-max-error-rate has to be between 0 and 1")
+		})
+
+		t.Run("proxy error rate health check accepted", func(t *testing.T) {
+			c := validWithCanaries()
+			c.GatewayCanaryHealthCheck = "proxy-error-rate"
+			c.GatewayCanaryMaxProxyErrorRate = 0.05
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("unknown health check rejected", func(t *testing.T) {
+			c := validWithCanaries()
+			c.GatewayCanaryHealthCheck = "unknown"
+			require.ErrorContains(t, c.Validate(), "--gateway-canary-health-check has to be one of")
+		})
+
+		t.Run("invalid max proxy error rate rejected", func(t *testing.T) {
+			c := validWithCanaries()
+			c.GatewayCanaryHealthCheck = "proxy-error-rate"
+			c.GatewayCanaryMaxProxyErrorRate = -0.1
+			require.ErrorContains(t, c.Validate(), "--gateway-canary-max-proxy-error-rate has to be between 0 and 1")
+		})
+	})
+
+	t.Run("Event-driven sync", func(t *testing.T) {
+		t.Run("event-driven sync accepted", func(t *testing.T) {
+			c := manager.Config{ProxySyncDebounce: time.Second, ProxySyncMaxWait: 3 * time.Second, ProxyResyncPeriod: time.Minute}
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("max wait shorter than debounce rejected", func(t *testing.T) {
+			c := manager.Config{ProxySyncDebounce: time.Second, ProxySyncMaxWait: time.Millisecond, ProxyResyncPeriod: time.Minute}
+			require.ErrorContains(t, c.Validate(), "--proxy-sync-max-wait can't be shorter than --proxy-sync-debounce")
+		})
+
+		t.Run("non-positive resync period rejected", func(t *testing.T) {
+			c := manager.Config{ProxySyncDebounce: time.Second, ProxySyncMaxWait: 3 * time.Second}
+			require.ErrorContains(t, c.Validate(), "--proxy-resync-period has to be positive")
+		})
+	})
+
+	t.Run("Gateway convergence check", func(t *testing.T) {
+		t.Run("convergence check accepted", func(t *testing.T) {
+			c := manager.Config{GatewayConvergenceTimeout: 10 * time.Second, GatewayConvergencePollInterval: 200 * time.Millisecond}
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("negative timeout rejected", func(t *testing.T) {
+			c := manager.Config{GatewayConvergenceTimeout: -time.Second}
+			require.ErrorContains(t, c.Validate(), "--gateway-convergence-timeout can't be negative")
+		})
+
+		t.Run("non-positive poll interval rejected", func(t *testing.T) {
+			c := manager.Config{GatewayConvergenceTimeout: 10 * time.Second}
+			require.ErrorContains(t, c.Validate(), "--gateway-convergence-poll-interval has to be positive")
+		})
+	})
+
+	t.Run("negative Kong Admin API credentials reload interval rejected", func(t *testing.T) {
+		c := manager.Config{KongAdminCredentialsReloadInterval: -time.Second}
+		require.ErrorContains(t, c.Validate(), "--kong-admin-credentials-reload-interval can't be negative")
+	})
+
+	t.Run("Kong Admin API discovery sources", func(t *testing.T) {
+		t.Run("discovery sources accepted", func(t *testing.T) {
+			c := manager.Config{
+				KongAdminDNSSRVRecords:     []string{"_kong-admin._tcp.gateways.example.com"},
+				KongAdminURLsFile:          "/etc/kong/admin-urls",
+				KongAdminURLsConfigMap:     mo.Some(k8stypes.NamespacedName{Namespace: "kong", Name: "admin-urls"}),
+				KongAdminDiscoveryInterval: time.Second,
+			}
+			require.NoError(t, c.Validate())
+			require.True(t, c.GatewayDiscoveryEnabled())
+		})
+
+		t.Run("non-positive discovery interval rejected", func(t *testing.T) {
+			c := manager.Config{KongAdminURLsFile: "/etc/kong/admin-urls"}
+			require.ErrorContains(t, c.Validate(), "--kong-admin-discovery-interval has to be positive")
+		})
+	})
+
+	t.Run("Kong Admin API update backoff", func(t *testing.T) {
+		t.Run("backoff accepted", func(t *testing.T) {
+			c := manager.Config{
+				KongAdminUpdateBackoffEnabled:         true,
+				KongAdminUpdateBackoffInitialInterval: time.Second,
+				KongAdminUpdateBackoffMaxInterval:     time.Minute,
+			}
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("non-positive initial interval rejected", func(t *testing.T) {
+			c := manager.Config{KongAdminUpdateBackoffEnabled: true, KongAdminUpdateBackoffMaxInterval: time.Minute}
+			require.ErrorContains(t, c.Validate(), "--kong-admin-update-backoff-initial-interval has to be positive")
+		})
+
+		t.Run("max interval shorter than initial interval rejected", func(t *testing.T) {
+			c := manager.Config{
+				KongAdminUpdateBackoffEnabled:         true,
+				KongAdminUpdateBackoffInitialInterval: time.Minute,
+				KongAdminUpdateBackoffMaxInterval:     time.Second,
+			}
+			require.ErrorContains(t, c.Validate(), "--kong-admin-update-backoff-max-interval can't be shorter than --kong-admin-update-backoff-initial-interval")
+		})
+
+		t.Run("intervals not validated when backoff disabled", func(t *testing.T) {
+			c := manager.Config{KongAdminUpdateBackoffEnabled: false}
+			require.NoError(t, c.Validate())
+		})
+	})
+
+	t.Run("tracing", func(t *testing.T) {
+		t.Run("tracing accepted", func(t *testing.T) {
+			c := manager.Config{TracingOTLPEndpoint: "http://otel-collector:4318", TracingSamplingRatio: 0.5}
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("endpoint without host rejected", func(t *testing.T) {
+			c := manager.Config{TracingOTLPEndpoint: "otel-collector:4318", TracingSamplingRatio: 1}
+			require.ErrorContains(t, c.Validate(), "invalid --tracing-otlp-endpoint")
+		})
+
+		t.Run("sampling ratio out of range rejected", func(t *testing.T) {
+			c := manager.Config{TracingOTLPEndpoint: "http://otel-collector:4318", TracingSamplingRatio: 1.5}
+			require.ErrorContains(t, c.Validate(), "--tracing-sampling-ratio has to be between 0 and 1")
+		})
+
+		t.Run("sampling ratio not validated when tracing disabled", func(t *testing.T) {
+			c := manager.Config{TracingSamplingRatio: -1}
+			require.NoError(t, c.Validate())
+		})
+	})
+
+	t.Run("audit log", func(t *testing.T) {
+		t.Run("audit log accepted", func(t *testing.T) {
+			c := manager.Config{AuditLogPath: "-", AuditLogMaxSize: 100, AuditLogMaxBackups: 0}
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("non-positive max size rejected", func(t *testing.T) {
+			c := manager.Config{AuditLogPath: "/var/log/kic/audit.log", AuditLogMaxBackups: 5}
+			require.ErrorContains(t, c.Validate(), "--audit-log-max-size has to be positive")
+		})
+
+		t.Run("negative max backups rejected", func(t *testing.T) {
+			c := manager.Config{AuditLogPath: "/var/log/kic/audit.log", AuditLogMaxSize: 100, AuditLogMaxBackups: -1}
+			require.ErrorContains(t, c.Validate(), "--audit-log-max-backups can't be negative")
+		})
+
+		t.Run("rotation not validated when audit log disabled", func(t *testing.T) {
+			c := manager.Config{AuditLogMaxSize: 0, AuditLogMaxBackups: -1}
+			require.NoError(t, c.Validate())
+		})
+	})
+
+	t.Run("Gateway drift detection", func(t *testing.T) {
+		t.Run("drift detection accepted", func(t *testing.T) {
+			c := manager.Config{GatewayDriftDetectionInterval: time.Minute, GatewayDriftAutoCorrect: true}
+			require.NoError(t, c.Validate())
+		})
+
+		t.Run("negative interval rejected", func(t *testing.T) {
+			c := manager.Config{GatewayDriftDetectionInterval: -time.Minute}
+			require.ErrorContains(t, c.Validate(), "--gateway-drift-detection-interval can't be negative")
+		})
+	})
 }
 
 func TestConfigValidateGatewayDiscovery(t *testing.T) {
@@ -402,7 +715,7 @@ func TestConfigValidateGatewayDiscovery(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			c := &manager.Config{}
 			if tc.gatewayDiscovery {
-				c.KongAdminSvc = mo.Some(k8stypes.NamespacedName{Name: "admin-svc", Namespace: "ns"})
+				c.KongAdminSvcs = []k8stypes.NamespacedName{{Name: "admin-svc", Namespace: "ns"}}
 			}
 			err := c.ValidateGatewayDiscovery(tc.dbMode)
 			if !tc.expectError {
@@ -72,10 +72,10 @@ func setupControllers(
 		// Kong Gateway Admin API Service discovery
 		// ---------------------------------------------------------------------------
 		{
-			Enabled: c.KongAdminSvc.IsPresent(),
+			Enabled: !c.AdminAPIServiceSelector().IsEmpty(),
 			Controller: &configuration.KongAdminAPIServiceReconciler{
 				Client:              mgr.GetClient(),
-				ServiceNN:           c.KongAdminSvc.OrEmpty(),
+				Services:            c.AdminAPIServiceSelector(),
 				Log:                 ctrl.LoggerFrom(ctx).WithName("controllers").WithName("KongAdminAPIService"),
 				CacheSyncTimeout:    c.CacheSyncTimeout,
 				EndpointsNotifier:   kongAdminAPIEndpointsNotifier,
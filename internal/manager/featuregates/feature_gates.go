@@ -32,6 +32,10 @@ const (
 	// RewriteURIsFeature is the name of the feature-gate for enabling/disabling konghq.com/rewrite annotation.
 	RewriteURIsFeature = "RewriteURIs"
 
+	// IncrementalTranslationFeature is the name of the feature-gate that makes KIC cache translation results of
+	// Kubernetes objects and translate only the objects that changed since the last configuration sync.
+	IncrementalTranslationFeature = "IncrementalTranslation"
+
 	// DocsURL provides a link to the documentation for feature gates in the KIC repository.
 	DocsURL = "https://github.com/Kong/kubernetes-ingress-controller/blob/main/FEATURE_GATES.md"
 )
@@ -67,10 +71,11 @@ func (fg FeatureGates) Enabled(feature string) bool {
 // NOTE: if you're adding a new feature gate, it needs to be added here.
 func GetFeatureGatesDefaults() map[string]bool {
 	return map[string]bool{
-		GatewayFeature:          true,
-		GatewayAlphaFeature:     false,
-		ExpressionRoutesFeature: false,
-		FillIDsFeature:          true,
-		RewriteURIsFeature:      false,
+		GatewayFeature:                true,
+		GatewayAlphaFeature:           false,
+		ExpressionRoutesFeature:       false,
+		FillIDsFeature:                true,
+		RewriteURIsFeature:            false,
+		IncrementalTranslationFeature: false,
 	}
 }
@@ -1,6 +1,9 @@
 package flags
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+)
 
 type ValidatedValueOpt[T any] func(*ValidatedValue[T])
 
@@ -24,6 +27,10 @@ func stringFromAny(s any) string {
 		return str
 	}
 
+	if i, ok := s.(int); ok {
+		return strconv.Itoa(i)
+	}
+
 	panic(fmt.Errorf("unknown type %T", s))
 }
 
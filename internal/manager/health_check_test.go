@@ -11,9 +11,13 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kong/go-kong/kong"
 	"github.com/phayes/freeport"
 	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/clock"
 )
 
 func TestHealthCheckServer(t *testing.T) {
@@ -122,3 +126,31 @@ func TestHealthCheckServer_Start(t *testing.T) {
 		return true
 	}, time.Second, time.Millisecond)
 }
+
+func TestGatewayCircuitBreakersCheck(t *testing.T) {
+	newClient := func(t *testing.T, updateFailure error) *adminapi.Client {
+		client, err := adminapi.NewTestClient("http://localhost:8001")
+		require.NoError(t, err)
+		backoff := adminapi.NewGatewayBackoffStrategy(adminapi.GatewayBackoffConfig{
+			InitialInterval: time.Hour,
+			MaxInterval:     time.Hour,
+		}, clock.System{})
+		if updateFailure != nil {
+			backoff.RegisterUpdateFailure(updateFailure, []byte("hash"))
+		}
+		client.AttachBackoffStrategy(backoff)
+		return client
+	}
+
+	require.NoError(t, gatewayCircuitBreakersCheck(nil), "no gateways should not affect readiness")
+	var (
+		networkErr  = errors.New("connection refused")
+		rejectedErr = kong.NewAPIError(http.StatusBadRequest, "invalid config")
+	)
+	require.NoError(t, gatewayCircuitBreakersCheck([]*adminapi.Client{newClient(t, networkErr), newClient(t, nil)}),
+		"a gateway with a closed circuit should keep the controller ready")
+	require.NoError(t, gatewayCircuitBreakersCheck([]*adminapi.Client{newClient(t, networkErr), newClient(t, rejectedErr)}),
+		"a gateway that rejected a config accepts other configs, so it should keep the controller ready")
+	require.ErrorContains(t, gatewayCircuitBreakersCheck([]*adminapi.Client{newClient(t, networkErr), newClient(t, networkErr)}),
+		"configuration updates of all 2 Kong Gateways are backed off")
+}
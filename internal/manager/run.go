@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -19,9 +20,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/audit"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/clients"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/controllers/gateway"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/capabilities"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/configfetcher"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
@@ -35,6 +38,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/telemetry"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/utils/kongconfig"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	dataplaneutil "github.com/kong/kubernetes-ingress-controller/v2/internal/util/dataplane"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util/kubernetes/object/status"
@@ -63,6 +67,25 @@ func Run(
 		return fmt.Errorf("failed to configure feature gates: %w", err)
 	}
 
+	if c.TracingOTLPEndpoint != "" {
+		shutdownTracing, err := tracing.SetupTracerProvider(ctx, setupLog.WithName("tracing"), tracing.Config{
+			Endpoint:      c.TracingOTLPEndpoint,
+			SamplingRatio: c.TracingSamplingRatio,
+			Version:       metadata.Release,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set up tracing: %w", err)
+		}
+		defer func() {
+			// The manager's context is done by now, pending spans are flushed within a timeout of their own.
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(shutdownCtx); err != nil {
+				setupLog.Error(err, "failed to flush traces")
+			}
+		}()
+	}
+
 	setupLog.Info("getting the kubernetes client configuration")
 	kubeconfig, err := c.GetKubeconfig()
 	if err != nil {
@@ -84,39 +107,119 @@ func Run(
 		return fmt.Errorf("failed to resolve configuration: %w", err)
 	}
 
-	adminAPIClientsFactory := adminapi.NewClientFactoryForWorkspace(c.KongWorkspace, c.KongAdminAPIConfig, c.KongAdminToken)
-
-	setupLog.Info("getting the kong admin api client configuration")
-	initialKongClients, err := c.adminAPIClients(
-		ctx,
-		setupLog.WithName("initialize-kong-clients"),
-		adminAPIsDiscoverer,
-		adminAPIClientsFactory,
+	// All Admin API clients share the transport, so that rotated TLS material and token are reloaded for them in place.
+	adminAPITransportReloader, err := adminapi.NewTransportReloader(
+		setupLog.WithName("admin-api-transport"),
+		c.KongAdminAPIConfig,
+		c.KongAdminToken,
+		c.KongAdminTokenPath,
+		c.KongAdminCredentialsReloadInterval,
 	)
 	if err != nil {
-		return fmt.Errorf("unable to build kong api client(s): %w", err)
+		return fmt.Errorf("failed to create kong admin api transport: %w", err)
 	}
-
-	// Get Kong configuration root(s) to validate them and extract Kong's version.
-	kongRoots, err := kongconfig.GetRoots(ctx, setupLog, c.KongAdminInitializationRetries, c.KongAdminInitializationRetryDelay, initialKongClients)
-	if err != nil {
-		return fmt.Errorf("could not retrieve Kong admin root(s): %w", err)
+	adminAPIClientsFactoryOpts := []adminapi.ClientFactoryOption{adminapi.WithTransportReloader(adminAPITransportReloader)}
+	if c.KongAdminUpdateBackoffEnabled {
+		adminAPIClientsFactoryOpts = append(adminAPIClientsFactoryOpts, adminapi.WithGatewayBackoff(adminapi.GatewayBackoffConfig{
+			InitialInterval: c.KongAdminUpdateBackoffInitialInterval,
+			MaxInterval:     c.KongAdminUpdateBackoffMaxInterval,
+		}))
 	}
-
-	kongStartUpConfig, err := kongconfig.ValidateRoots(kongRoots, c.SkipCACertificates)
-	if err != nil {
-		return fmt.Errorf("could not validate Kong admin root(s) configuration: %w", err)
+	if c.TracingOTLPEndpoint != "" {
+		adminAPIClientsFactoryOpts = append(adminAPIClientsFactoryOpts, adminapi.WithTracing())
 	}
-	dbMode := kongStartUpConfig.DBMode
-	routerFlavor := kongStartUpConfig.RouterFlavor
-	v := kongStartUpConfig.Version
-
-	err = c.ValidateGatewayDiscovery(kongStartUpConfig.DBMode)
-	if err != nil {
-		return err
+	var auditLogger *audit.Logger
+	if c.AuditLogPath != "" {
+		auditLogSink, err := audit.NewSink(c.AuditLogPath, c.AuditLogMaxSize, c.AuditLogMaxBackups)
+		if err != nil {
+			return fmt.Errorf("failed to open audit log: %w", err)
+		}
+		defer auditLogSink.Close()
+		auditLogger = audit.NewLogger(auditLogSink)
+		adminAPIClientsFactoryOpts = append(adminAPIClientsFactoryOpts,
+			adminapi.WithAuditLog(setupLog.WithName("audit"), auditLogger, c.AuditLogCaptureRequests),
+		)
 	}
+	adminAPIClientsFactory := adminapi.NewClientFactoryForWorkspace(c.KongWorkspace, c.KongAdminAPIConfig, c.KongAdminToken,
+		adminAPIClientsFactoryOpts...,
+	)
+
+	var (
+		initialKongClients []*adminapi.Client
+		discoverySources   map[string]adminapi.DiscoverySource
+		initialAdminAPIs   map[string][]adminapi.DiscoveredAdminAPI
+		dbMode             string
+		kongSemVersion     semver.Version
+		kongGateway        capabilities.Gateway
+	)
+	if c.DeclarativeConfigOutputsEnabled() {
+		// There's no Admin API to connect to, the configuration is generated for DB-less Kong of the configured
+		// version and router flavor.
+		setupLog.Info("declarative configuration outputs enabled, skipping connecting to kong admin api")
+		dbMode = "off"
+		if kongSemVersion, err = semver.ParseTolerant(c.DeclarativeConfigKongVersion); err != nil {
+			return fmt.Errorf("invalid kong version %q: %w", c.DeclarativeConfigKongVersion, err)
+		}
+		// The edition and loaded plugins of the Kong the configuration will be loaded into are not known.
+		kongGateway = capabilities.Gateway{
+			Version:      kongSemVersion,
+			RouterFlavor: c.DeclarativeConfigRouterFlavor,
+		}
+	} else {
+		setupLog.Info("getting the kong admin api client configuration")
+		discoverySources, err = c.adminAPIDiscoverySources(adminAPIsDiscoverer)
+		if err != nil {
+			return fmt.Errorf("unable to build kong admin api discovery sources: %w", err)
+		}
+		initialKongClients, initialAdminAPIs, err = c.adminAPIClients(
+			ctx,
+			setupLog.WithName("initialize-kong-clients"),
+			discoverySources,
+			adminAPIClientsFactory,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to build kong api client(s): %w", err)
+		}
 
-	kongSemVersion := semver.Version{Major: v.Major(), Minor: v.Minor(), Patch: v.Patch()}
+		// Get Kong configuration root(s) to validate them and extract Kong's version.
+		kongRoots, err := kongconfig.GetRoots(ctx, setupLog, c.KongAdminInitializationRetries, c.KongAdminInitializationRetryDelay, initialKongClients)
+		if err != nil {
+			return fmt.Errorf("could not retrieve Kong admin root(s): %w", err)
+		}
+
+		kongStartUpConfig, err := kongconfig.ValidateRoots(kongRoots, c.SkipCACertificates)
+		if err != nil {
+			return fmt.Errorf("could not validate Kong admin root(s) configuration: %w", err)
+		}
+		dbMode = kongStartUpConfig.DBMode
+		v := kongStartUpConfig.Version
+
+		err = c.ValidateGatewayDiscovery(kongStartUpConfig.DBMode)
+		if err != nil {
+			return err
+		}
+
+		kongSemVersion = semver.Version{Major: v.Major(), Minor: v.Minor(), Patch: v.Patch()}
+
+		// Kong instances may run different versions (e.g. during a rolling upgrade), the configuration is generated
+		// for capabilities all of them have.
+		kongGateways := make([]capabilities.Gateway, 0, len(kongRoots))
+		for _, root := range kongRoots {
+			g, err := kongconfig.GatewayFromRoot(root)
+			if err != nil {
+				return fmt.Errorf("could not detect Kong Gateway capabilities: %w", err)
+			}
+			kongGateways = append(kongGateways, g)
+		}
+		kongGateway = capabilities.CommonGateway(kongGateways...)
+	}
+	gatewayCapabilities := capabilities.NewRegistry(kongGateway)
+	setupLog.Info("detected Kong Gateway capabilities",
+		"version", kongSemVersion.String(),
+		"edition", kongGateway.Edition,
+		"routerFlavor", kongGateway.RouterFlavor,
+		"capabilities", gatewayCapabilities.Supported(),
+	)
 
 	kongConfig := sendconfig.Config{
 		Version:            kongSemVersion,
@@ -126,6 +229,10 @@ func Run(
 		SkipCACertificates: c.SkipCACertificates,
 		EnableReverseSync:  c.EnableReverseSync,
 		ExpressionRoutes:   featureGates.Enabled(featuregates.ExpressionRoutesFeature),
+		DryRun:             c.DryRun,
+
+		CompressDBLessConfig:     c.KongAdminCompressDBLessConfig,
+		GatewaySyncQuorumPercent: c.GatewaySyncQuorumPercent,
 	}
 	kongConfig.Init(ctx, setupLog, initialKongClients)
 
@@ -144,28 +251,59 @@ func Run(
 		return fmt.Errorf("unable to connect to Kubernetes API: %w", err)
 	}
 
+	if err := mgr.Add(adminAPITransportReloader); err != nil {
+		return fmt.Errorf("unable to add kong admin api transport reloader to manager: %w", err)
+	}
+
 	setupLog.Info("Initializing Dataplane Client")
 	eventRecorder := mgr.GetEventRecorderFor(KongClientEventRecorderComponentName)
 
 	readinessChecker := clients.NewDefaultReadinessChecker(adminAPIClientsFactory, setupLog.WithName("readiness-checker"))
+	var clientsManagerOpts []clients.AdminAPIClientsManagerOption
+	if c.DeclarativeConfigOutputsEnabled() {
+		clientsManagerOpts = append(clientsManagerOpts, clients.WithNoInitialClientsAllowed())
+	}
 	clientsManager, err := clients.NewAdminAPIClientsManager(
 		ctx,
 		logger,
 		initialKongClients,
 		readinessChecker,
+		clientsManagerOpts...,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create AdminAPIClientsManager: %w", err)
 	}
-	if c.KongAdminSvc.IsPresent() {
+	// Admin APIs discovered from Kong Admin API Services are notified about by the KongAdminAPIService reconciler,
+	// while other discovery sources are polled. Notifications of all of them are merged before reaching clientsManager.
+	var kongAdminAPIEndpointsNotifier adminapi.DiscoveredAdminAPIsNotifier = clientsManager
+	if c.GatewayDiscoveryEnabled() {
 		setupLog.Info("Running AdminAPIClientsManager loop")
 		clientsManager.Run()
+
+		merger := clients.NewDiscoveredAdminAPIsMerger(clientsManager)
+		kongAdminAPIEndpointsNotifier = merger.SourceNotifier(
+			adminAPIDiscoverySourceServices, initialAdminAPIs[adminAPIDiscoverySourceServices],
+		)
+		for name, source := range discoverySources {
+			if name == adminAPIDiscoverySourceServices {
+				continue
+			}
+			if err := mgr.Add(adminapi.NewDiscoverySourcePoller(
+				setupLog.WithName("admin-api-discovery"),
+				source,
+				merger.SourceNotifier(name, initialAdminAPIs[name]),
+				c.KongAdminDiscoveryInterval,
+				initialAdminAPIs[name],
+			)); err != nil {
+				return fmt.Errorf("unable to add admin api discovery source poller to manager: %w", err)
+			}
+		}
 	}
 
 	parserFeatureFlags := parser.NewFeatureFlags(
 		logger,
 		featureGates,
-		routerFlavor,
+		gatewayCapabilities,
 		c.UpdateStatus,
 	)
 
@@ -183,8 +321,13 @@ func Run(
 	if err != nil {
 		return fmt.Errorf("failed to create parser: %w", err)
 	}
+	configParser.InjectCapabilities(gatewayCapabilities)
 
-	updateStrategyResolver := sendconfig.NewDefaultUpdateStrategyResolver(kongConfig, logger)
+	var updateStrategyResolverOpts []sendconfig.DefaultUpdateStrategyResolverOption
+	if auditLogger != nil {
+		updateStrategyResolverOpts = append(updateStrategyResolverOpts, sendconfig.WithAuditLogger(auditLogger))
+	}
+	updateStrategyResolver := sendconfig.NewDefaultUpdateStrategyResolver(kongConfig, logger, updateStrategyResolverOpts...)
 	configurationChangeDetector := sendconfig.NewDefaultConfigurationChangeDetector(logger)
 	kongConfigFetcher := configfetcher.NewDefaultKongLastGoodConfigFetcher(parserFeatureFlags.FillIDs)
 	dataplaneClient, err := dataplane.NewKongClient(
@@ -205,12 +348,76 @@ func Run(
 	if err != nil {
 		return fmt.Errorf("failed to initialize kong data-plane client: %w", err)
 	}
+	if c.DeclarativeConfigOutputsEnabled() {
+		outputs, err := setupDeclarativeConfigOutputs(c, logger.WithName("declarative-config-outputs"))
+		if err != nil {
+			return fmt.Errorf("failed to setup declarative configuration outputs: %w", err)
+		}
+		dataplaneClient.SetDeclarativeConfigOutputs(outputs...)
+	}
+	if auditLogger != nil {
+		dataplaneClient.EnableChangedObjectsTracking()
+	}
+	if !c.DeclarativeConfigOutputsEnabled() {
+		dataplaneClient.SetVersionSkewDetection(dataplane.VersionSkewDetectionConfig{
+			Detector: kongconfig.DetectGateway,
+			Injector: configParser,
+			Initial:  kongGateway,
+		})
+	}
+	if c.GatewayCanaryPercent > 0 {
+		// With no health checkers, canaries' /status endpoints are checked.
+		var healthCheckers []dataplane.CanaryHealthChecker
+		if c.GatewayCanaryHealthCheck == dataplane.CanaryHealthCheckProxyErrorRate {
+			healthCheckers = append(healthCheckers, dataplane.NewProxyErrorRateCanaryHealthChecker(
+				time.Duration(c.ProxyTimeoutSeconds*float32(time.Second)), c.GatewayCanaryMaxProxyErrorRate,
+			))
+		}
+		dataplaneClient.SetCanaryRollout(dataplane.CanaryRolloutConfig{
+			CanaryPercent: c.GatewayCanaryPercent,
+			BakePeriod:    c.GatewayCanaryBakePeriod,
+			CheckInterval: c.GatewayCanaryCheckInterval,
+			MaxErrorRate:  c.GatewayCanaryMaxErrorRate,
+		}, healthCheckers...)
+	}
+	if c.GatewayConvergenceTimeout > 0 {
+		dataplaneClient.SetConvergenceCheck(dataplane.ConvergenceCheckConfig{
+			Timeout:      c.GatewayConvergenceTimeout,
+			PollInterval: c.GatewayConvergencePollInterval,
+		})
+	}
+	if c.GatewayDriftDetectionInterval > 0 {
+		switch {
+		case kongConfig.InMemory:
+			setupLog.Info("drift detection is supported only for Kong Gateways in DB mode, skipping")
+		case len(kongConfig.FilterTags) == 0:
+			setupLog.Info("drift detection requires tag filtering to tell managed entities apart, skipping")
+		default:
+			dataplaneClient.SetDriftDetection(dataplane.DriftDetectionConfig{
+				AutoCorrect: c.GatewayDriftAutoCorrect,
+			})
+			driftDetector := dataplane.NewDriftDetector(logger.WithName("drift-detector"), dataplaneClient, c.GatewayDriftDetectionInterval)
+			if err := mgr.Add(driftDetector); err != nil {
+				return fmt.Errorf("unable to add drift detector to manager: %w", err)
+			}
+		}
+	}
 
 	setupLog.Info("Initializing Dataplane Synchronizer")
-	synchronizer, err := setupDataplaneSynchronizer(logger, mgr, dataplaneClient, c.ProxySyncSeconds, c.InitCacheSyncDuration)
+	var synchronizerOpts []dataplane.SynchronizerOption
+	if c.EventDrivenSyncEnabled() {
+		synchronizerOpts = append(synchronizerOpts,
+			dataplane.WithEventDrivenSync(c.ProxySyncDebounce, c.ProxySyncMaxWait, c.ProxyResyncPeriod),
+			dataplane.WithGatewayClientsChangesNotifier(clientsManager),
+		)
+	}
+	synchronizer, err := setupDataplaneSynchronizer(logger, mgr, dataplaneClient, c.ProxySyncSeconds, c.InitCacheSyncDuration, synchronizerOpts...)
 	if err != nil {
 		return fmt.Errorf("unable to initialize dataplane synchronizer: %w", err)
 	}
+	if c.EventDrivenSyncEnabled() {
+		dataplaneClient.SetChangeNotifier(synchronizer)
+	}
 
 	var kubernetesStatusQueue *status.Queue
 	if c.UpdateStatus {
@@ -237,7 +444,7 @@ func Run(
 		kubernetesStatusQueue,
 		c,
 		featureGates,
-		clientsManager,
+		kongAdminAPIEndpointsNotifier,
 		adminAPIsDiscoverer,
 	)
 	for _, c := range controllers {
@@ -251,7 +458,7 @@ func Run(
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("Add readiness probe to health server")
-	healthServer.setReadyzCheck(readyzHandler(mgr, synchronizer))
+	healthServer.setReadyzCheck(readyzHandler(mgr, synchronizer, clientsManager))
 	instanceIDProvider := NewInstanceIDProvider()
 
 	if c.Konnect.ConfigSynchronizationEnabled {
@@ -319,7 +526,7 @@ func Run(
 					FeatureGates:                   featureGates,
 					MeshDetection:                  len(c.WatchNamespaces) == 0,
 					KonnectSyncEnabled:             c.Konnect.ConfigSynchronizationEnabled,
-					GatewayServiceDiscoveryEnabled: c.KongAdminSvc.IsPresent(),
+					GatewayServiceDiscoveryEnabled: c.GatewayDiscoveryEnabled(),
 				},
 			},
 			instanceIDProvider,
@@ -448,18 +655,43 @@ type IsReady interface {
 	IsReady() bool
 }
 
-func readyzHandler(mgr manager.Manager, dataplaneSynchronizer IsReady) func(*http.Request) error {
+type GatewayClientsProvider interface {
+	GatewayClients() []*adminapi.Client
+}
+
+func readyzHandler(mgr manager.Manager, dataplaneSynchronizer IsReady, gatewayClientsProvider GatewayClientsProvider) func(*http.Request) error {
 	return func(_ *http.Request) error {
 		select {
 		// If we're elected as leader then report readiness based on the readiness
-		// of dataplane synchronizer.
+		// of dataplane synchronizer and circuit breakers of Kong Gateways.
 		case <-mgr.Elected():
 			if !dataplaneSynchronizer.IsReady() {
 				return errors.New("synchronizer not yet configured")
 			}
+			if err := gatewayCircuitBreakersCheck(gatewayClientsProvider.GatewayClients()); err != nil {
+				return err
+			}
 		// If we're not the leader then just report as ready.
 		default:
 		}
 		return nil
 	}
 }
+
+// gatewayCircuitBreakersCheck returns an error when circuit breakers of configuration updates of all the gateways
+// are open, i.e. there's no gateway the configuration can be currently applied to.
+func gatewayCircuitBreakersCheck(gatewayClients []*adminapi.Client) error {
+	if len(gatewayClients) == 0 {
+		return nil
+	}
+	reasons := make([]string, 0, len(gatewayClients))
+	for _, client := range gatewayClients {
+		status := client.BackoffStatus()
+		if !status.Open {
+			return nil
+		}
+		reasons = append(reasons, fmt.Sprintf("%s: %s", client.BaseRootURL(), status.Reason))
+	}
+	return fmt.Errorf("configuration updates of all %d Kong Gateways are backed off: %s",
+		len(gatewayClients), strings.Join(reasons, "; "))
+}
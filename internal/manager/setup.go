@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/avast/retry-go/v4"
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	"github.com/kong/deck/cprint"
+	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -27,6 +30,7 @@ import (
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/clients"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/sendconfig"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/scheme"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/util"
 	dataplaneutil "github.com/kong/kubernetes-ingress-controller/v2/internal/util/dataplane"
@@ -98,6 +102,10 @@ func setupManagerOptions(ctx context.Context, logger logr.Logger, c *Config, dbm
 		if s, ok := c.IngressService.Get(); ok {
 			watchNamespaces = append(c.WatchNamespaces, s.Namespace)
 		}
+		// The same applies to Kong Admin API Services listed for gateway discovery.
+		for _, s := range c.KongAdminSvcs {
+			watchNamespaces = append(watchNamespaces, s.Namespace)
+		}
 		watched := make(map[string]cache.Config)
 		for _, n := range sets.NewString(watchNamespaces...).List() {
 			watched[n] = cache.Config{}
@@ -118,8 +126,13 @@ func leaderElectionEnabled(logger logr.Logger, c *Config, dbmode string) bool {
 		return true
 	}
 
+	if c.DeclarativeConfigOutputConfigMap.IsPresent() {
+		logger.Info("declarative configuration ConfigMap output enabled, enabling leader election")
+		return true
+	}
+
 	if dataplaneutil.IsDBLessMode(dbmode) {
-		if c.KongAdminSvc.IsPresent() {
+		if c.GatewayDiscoveryEnabled() {
 			logger.Info("DB-less mode detected with service detection, enabling leader election")
 			return true
 		}
@@ -131,12 +144,41 @@ func leaderElectionEnabled(logger logr.Logger, c *Config, dbmode string) bool {
 	return true
 }
 
+// setupDeclarativeConfigOutputs creates declarative configuration outputs configured with flags.
+func setupDeclarativeConfigOutputs(c *Config, logger logr.Logger) ([]sendconfig.DeclarativeConfigOutput, error) {
+	var outputs []sendconfig.DeclarativeConfigOutput
+	if c.DeclarativeConfigOutputFile != "" {
+		logger.Info("writing configuration to file", "path", c.DeclarativeConfigOutputFile)
+		outputs = append(outputs, sendconfig.NewUpdateStrategyFile(
+			c.DeclarativeConfigOutputFile,
+			sendconfig.DefaultContentToDBLessConfigConverter{},
+			logger,
+		))
+	}
+	if nn, ok := c.DeclarativeConfigOutputConfigMap.Get(); ok {
+		kubeClient, err := c.GetKubeClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get kubernetes client: %w", err)
+		}
+		logger.Info("writing configuration to configmaps", "configmap", nn)
+		outputs = append(outputs, sendconfig.NewUpdateStrategyConfigMaps(
+			kubeClient,
+			nn,
+			sendconfig.DefaultConfigMapShardSize,
+			sendconfig.DefaultContentToDBLessConfigConverter{},
+			logger,
+		))
+	}
+	return outputs, nil
+}
+
 func setupDataplaneSynchronizer(
 	logger logr.Logger,
 	mgr manager.Manager,
 	dataplaneClient dataplane.Client,
 	proxySyncSeconds float32,
 	initCacheSyncWait time.Duration,
+	opts ...dataplane.SynchronizerOption,
 ) (*dataplane.Synchronizer, error) {
 	if proxySyncSeconds < dataplane.DefaultSyncSeconds {
 		logger.Info(fmt.Sprintf(
@@ -149,8 +191,10 @@ func setupDataplaneSynchronizer(
 	dataplaneSynchronizer, err := dataplane.NewSynchronizer(
 		logger.WithName("dataplane-synchronizer"),
 		dataplaneClient,
-		dataplane.WithStagger(time.Duration(proxySyncSeconds*float32(time.Second))),
-		dataplane.WithInitCacheSyncDuration(initCacheSyncWait),
+		append([]dataplane.SynchronizerOption{
+			dataplane.WithStagger(time.Duration(proxySyncSeconds * float32(time.Second))),
+			dataplane.WithInitCacheSyncDuration(initCacheSyncWait),
+		}, opts...)...,
 	)
 	if err != nil {
 		return nil, err
@@ -267,53 +311,82 @@ func generateAddressFinderGetter(mgrc client.Client, ingressServiceNN k8stypes.N
 	}
 }
 
+// Names of sources Kong Admin APIs are discovered from.
+const (
+	adminAPIDiscoverySourceServices  = "services"
+	adminAPIDiscoverySourceDNSSRV    = "dns-srv"
+	adminAPIDiscoverySourceFile      = "file"
+	adminAPIDiscoverySourceConfigMap = "configmap"
+)
+
+// adminAPIDiscoverySources returns sources of Kong Admin APIs configured with flags, keyed by their names.
+func (c *Config) adminAPIDiscoverySources(discoverer AdminAPIsDiscoverer) (map[string]adminapi.DiscoverySource, error) {
+	sources := make(map[string]adminapi.DiscoverySource)
+	if !c.AdminAPIServiceSelector().IsEmpty() || c.KongAdminURLsConfigMap.IsPresent() {
+		kubeClient, err := c.GetKubeClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get kubernetes client: %w", err)
+		}
+		if services := c.AdminAPIServiceSelector(); !services.IsEmpty() {
+			sources[adminAPIDiscoverySourceServices] = serviceDiscoverySource{
+				services:   services,
+				kubeClient: kubeClient,
+				discoverer: discoverer,
+			}
+		}
+		if configMap, ok := c.KongAdminURLsConfigMap.Get(); ok {
+			sources[adminAPIDiscoverySourceConfigMap] = adminapi.NewConfigMapDiscoverySource(kubeClient, configMap)
+		}
+	}
+	for _, record := range c.KongAdminDNSSRVRecords {
+		sources[adminAPIDiscoverySourceDNSSRV+"/"+record] = adminapi.NewDNSSRVDiscoverySource(record)
+	}
+	if c.KongAdminURLsFile != "" {
+		sources[adminAPIDiscoverySourceFile] = adminapi.NewFileDiscoverySource(c.KongAdminURLsFile)
+	}
+	return sources, nil
+}
+
 // adminAPIClients returns the kong clients given the config.
 // When a list of URLs is provided via --kong-admin-url then those are used
 // to create the list of clients.
-// When a headless service name is provided via --kong-admin-svc then that is used
-// to obtain a list of endpoints via EndpointSlice lookup in kubernetes API.
+// When discovery sources are configured (e.g. headless services provided via
+// --kong-admin-svc, whose endpoints are obtained via EndpointSlice lookup in
+// kubernetes API) then Admin APIs discovered from them are used. In such case,
+// Admin APIs discovered from each of the sources are returned as well.
 func (c *Config) adminAPIClients(
 	ctx context.Context,
 	logger logr.Logger,
-	discoverer *adminapi.Discoverer,
+	discoverySources map[string]adminapi.DiscoverySource,
 	factory adminapi.ClientFactory,
-) ([]*adminapi.Client, error) {
-	httpclient, err := adminapi.MakeHTTPClient(&c.KongAdminAPIConfig, c.KongAdminToken)
-	if err != nil {
-		return nil, err
-	}
-
-	// If kong-admin-svc flag has been specified then use it to get the list
-	// of Kong Admin API endpoints.
-	if kongAdminSvc, ok := c.KongAdminSvc.Get(); ok {
-		kubeClient, err := c.GetKubeClient()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get kubernetes client: %w", err)
-		}
-		return AdminAPIClientFromServiceDiscovery(ctx, logger, kongAdminSvc, kubeClient, discoverer, factory)
+) ([]*adminapi.Client, map[string][]adminapi.DiscoveredAdminAPI, error) {
+	// If any of the discovery sources has been configured then use them to get
+	// the list of Kong Admin API endpoints.
+	if len(discoverySources) > 0 {
+		return AdminAPIClientsFromDiscoverySources(ctx, logger, discoverySources, factory)
 	}
 
 	// Otherwise fallback to the list of kong admin URLs.
 	addresses := c.KongAdminURLs
 	clients := make([]*adminapi.Client, 0, len(addresses))
 	for _, address := range addresses {
-		cl, err := adminapi.NewKongClientForWorkspace(ctx, address, c.KongWorkspace, httpclient)
+		cl, err := factory.CreateAdminAPIClient(ctx, adminapi.DiscoveredAdminAPI{Address: address})
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		clients = append(clients, cl)
 	}
 
-	return clients, nil
+	return clients, nil, nil
 }
 
 type NoAvailableEndpointsError struct {
-	serviceNN k8stypes.NamespacedName
+	sources string
 }
 
 func (e NoAvailableEndpointsError) Error() string {
-	return fmt.Sprintf("no endpoints for service: %q", e.serviceNN)
+	return fmt.Sprintf("no endpoints for %s", e.sources)
 }
 
 type AdminAPIsDiscoverer interface {
@@ -324,15 +397,63 @@ type AdminAPIClientFactory interface {
 	CreateAdminAPIClient(context.Context, adminapi.DiscoveredAdminAPI) (*adminapi.Client, error)
 }
 
+// serviceDiscoverySource discovers Admin APIs from EndpointSlices of the selected Kong Admin API Services.
+type serviceDiscoverySource struct {
+	services   adminapi.ServiceSelector
+	kubeClient client.Client
+	discoverer AdminAPIsDiscoverer
+}
+
+func (s serviceDiscoverySource) Discover(ctx context.Context) ([]adminapi.DiscoveredAdminAPI, error) {
+	// Services are resolved on every call, as the ones matching the label selector may not exist yet.
+	services, err := s.services.Resolve(ctx, s.kubeClient)
+	if err != nil {
+		return nil, err
+	}
+	discovered := sets.New[adminapi.DiscoveredAdminAPI]()
+	for _, svc := range services {
+		adminAPIs, err := s.discoverer.GetAdminAPIsForService(ctx, s.kubeClient, svc)
+		if err != nil {
+			return nil, err
+		}
+		discovered = discovered.Union(adminAPIs)
+	}
+	return discovered.UnsortedList(), nil
+}
+
+func (s serviceDiscoverySource) String() string {
+	return "services " + s.services.String()
+}
+
 func AdminAPIClientFromServiceDiscovery(
 	ctx context.Context,
 	logger logr.Logger,
-	kongAdminSvcNN k8stypes.NamespacedName,
+	kongAdminSvcs adminapi.ServiceSelector,
 	kubeClient client.Client,
 	discoverer AdminAPIsDiscoverer,
 	factory AdminAPIClientFactory,
 	retryOpts ...retry.Option,
 ) ([]*adminapi.Client, error) {
+	clients, _, err := AdminAPIClientsFromDiscoverySources(ctx, logger, map[string]adminapi.DiscoverySource{
+		adminAPIDiscoverySourceServices: serviceDiscoverySource{
+			services:   kongAdminSvcs,
+			kubeClient: kubeClient,
+			discoverer: discoverer,
+		},
+	}, factory, retryOpts...)
+	return clients, err
+}
+
+// AdminAPIClientsFromDiscoverySources creates clients of Admin APIs discovered from all the sources. It waits until
+// at least one Admin API is discovered. Admin APIs discovered from each of the sources are returned as well, keyed
+// by source names.
+func AdminAPIClientsFromDiscoverySources(
+	ctx context.Context,
+	logger logr.Logger,
+	sources map[string]adminapi.DiscoverySource,
+	factory AdminAPIClientFactory,
+	retryOpts ...retry.Option,
+) ([]*adminapi.Client, map[string][]adminapi.DiscoveredAdminAPI, error) {
 	// Retry this as we may encounter an error of getting 0 addresses,
 	// which can mean that Kong instances meant to be configured by this controller
 	// are not yet ready.
@@ -353,32 +474,48 @@ func AdminAPIClientFromServiceDiscovery(
 		}),
 	}, retryOpts...)
 
-	var adminAPIs []adminapi.DiscoveredAdminAPI
+	// Iterate over sources in a stable order, so that an Admin API discovered by multiple sources is always
+	// taken from the same one.
+	names := lo.Keys(sources)
+	sort.Strings(names)
+
+	var (
+		adminAPIs  []adminapi.DiscoveredAdminAPI
+		discovered map[string][]adminapi.DiscoveredAdminAPI
+	)
 	err := retry.Do(func() error {
-		s, err := discoverer.GetAdminAPIsForService(ctx, kubeClient, kongAdminSvcNN)
-		if err != nil {
-			return retry.Unrecoverable(err)
+		discovered = make(map[string][]adminapi.DiscoveredAdminAPI, len(sources))
+		adminAPIs = nil
+		for _, name := range names {
+			s, err := sources[name].Discover(ctx)
+			if err != nil {
+				return retry.Unrecoverable(err)
+			}
+			discovered[name] = s
+			adminAPIs = append(adminAPIs, s...)
 		}
-		if s.Len() == 0 {
-			return NoAvailableEndpointsError{serviceNN: kongAdminSvcNN}
+		if len(adminAPIs) == 0 {
+			return NoAvailableEndpointsError{
+				sources: strings.Join(lo.Map(names, func(name string, _ int) string { return sources[name].String() }), "; "),
+			}
 		}
-		adminAPIs = s.UnsortedList()
+		adminAPIs = lo.UniqBy(adminAPIs, func(d adminapi.DiscoveredAdminAPI) string { return d.Address })
 		return nil
 	},
 		retryOpts...,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	clients := make([]*adminapi.Client, 0, len(adminAPIs))
 	for _, adminAPI := range adminAPIs {
 		cl, err := factory.CreateAdminAPIClient(ctx, adminAPI)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		clients = append(clients, cl)
 	}
 
-	return clients, nil
+	return clients, discovered, nil
 }
@@ -10,6 +10,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -21,7 +24,9 @@ import (
 
 func TestAdminAPIClientFromServiceDiscovery(t *testing.T) {
 	log := logr.Discard()
-	adminAPISvcNN := k8stypes.NamespacedName{Name: "admin-api", Namespace: "kong"}
+	adminAPISvcs := adminapi.ServiceSelector{
+		Services: []k8stypes.NamespacedName{{Name: "admin-api", Namespace: "kong"}},
+	}
 	kubeClient := fake.NewClientBuilder().Build()
 	genericErr := errors.New("some generic error")
 	someDiscoveredAPI := func(address string) adminapi.DiscoveredAdminAPI {
@@ -104,7 +109,7 @@ func TestAdminAPIClientFromServiceDiscovery(t *testing.T) {
 			}
 
 			retryEveryMs := retry.Delay(time.Millisecond) // For testing purposes, we want to retry as fast as possible.
-			clients, err := manager.AdminAPIClientFromServiceDiscovery(ctx, log, adminAPISvcNN, kubeClient, discoverer, factory, retryEveryMs)
+			clients, err := manager.AdminAPIClientFromServiceDiscovery(ctx, log, adminAPISvcs, kubeClient, discoverer, factory, retryEveryMs)
 			if tc.expectedErr != nil {
 				require.ErrorContains(t, err, tc.expectedErr.Error())
 			} else {
@@ -114,3 +119,66 @@ func TestAdminAPIClientFromServiceDiscovery(t *testing.T) {
 		})
 	}
 }
+
+func TestAdminAPIClientFromServiceDiscovery_LabelSelector(t *testing.T) {
+	service := func(namespace string, lbls map[string]string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "admin-api", Labels: lbls}}
+	}
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		service("team-a", map[string]string{"app": "kong-admin"}),
+		service("team-b", map[string]string{"app": "kong-admin"}),
+		service("team-c", map[string]string{"app": "other"}),
+	).Build()
+	discoverer := mocks.NewAdminAPIDiscoverer(sets.New(adminapi.DiscoveredAdminAPI{Address: "https://localhost:8444"}), nil)
+	factory := mocks.NewAdminAPIClientFactory(nil)
+
+	clients, err := manager.AdminAPIClientFromServiceDiscovery(
+		context.Background(),
+		logr.Discard(),
+		adminapi.ServiceSelector{LabelSelector: labels.SelectorFromSet(labels.Set{"app": "kong-admin"})},
+		kubeClient,
+		discoverer,
+		factory,
+	)
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+	require.Equal(t, 2, discoverer.GetAdminAPIsForServiceCalledTimes(), "expected both Services matching labels to be queried")
+}
+
+type staticDiscoverySource []adminapi.DiscoveredAdminAPI
+
+func (s staticDiscoverySource) Discover(context.Context) ([]adminapi.DiscoveredAdminAPI, error) {
+	return s, nil
+}
+
+func (s staticDiscoverySource) String() string {
+	return "static"
+}
+
+func TestAdminAPIClientsFromDiscoverySources(t *testing.T) {
+	fromDNS := staticDiscoverySource{
+		{Address: "https://kong-1.example.com:8444"},
+		{Address: "https://kong-2.example.com:8444"},
+	}
+	fromFile := staticDiscoverySource{
+		{Address: "https://kong-2.example.com:8444"},
+		{Address: "https://10.0.0.1:8444"},
+	}
+	factory := mocks.NewAdminAPIClientFactory(nil)
+
+	clients, discovered, err := manager.AdminAPIClientsFromDiscoverySources(
+		context.Background(),
+		logr.Discard(),
+		map[string]adminapi.DiscoverySource{
+			"dns-srv/_kong-admin._tcp.example.com": fromDNS,
+			"file":                                 fromFile,
+		},
+		factory,
+	)
+	require.NoError(t, err)
+	require.Len(t, clients, 3, "expected Admin APIs discovered by multiple sources to be deduplicated")
+	require.Equal(t, map[string][]adminapi.DiscoveredAdminAPI{
+		"dns-srv/_kong-admin._tcp.example.com": fromDNS,
+		"file":                                 fromFile,
+	}, discovered)
+}
@@ -4,16 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/avast/retry-go/v4"
+	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
 	"github.com/kong/go-kong/kong"
 	"github.com/samber/lo"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/adminapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/capabilities"
 )
 
 // KongStartUpOptions includes start up configurations of Kong that could change behavior of Kong Ingress Controller.
@@ -22,6 +25,9 @@ type KongStartUpOptions struct {
 	DBMode       string
 	RouterFlavor string
 	Version      kong.Version
+	// LoadedPlugins are names of plugins loaded by all Kong instances, sorted. nil when any of them doesn't
+	// report its loaded plugins.
+	LoadedPlugins []string
 }
 
 // ValidateRoots checks if all provided kong roots are the same given that we
@@ -46,7 +52,9 @@ func ValidateRoots(roots []Root, skipCACerts bool) (*KongStartUpOptions, error)
 		return nil, err
 	}
 
-	kongVersion, err := KongVersionFromRoot(uniqs[0])
+	// Kong instances may run different versions, e.g. during a rolling upgrade. The lowest one is used, as that's
+	// what the configuration has to be compatible with.
+	kongVersion, err := lowestKongVersion(roots)
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +64,97 @@ func ValidateRoots(roots []Root, skipCACerts bool) (*KongStartUpOptions, error)
 		return nil, err
 	}
 
+	loadedPlugins, err := commonLoadedPlugins(roots)
+	if err != nil {
+		return nil, err
+	}
+
 	return &KongStartUpOptions{
-		DBMode:       dbMode,
-		RouterFlavor: routerFlavor,
-		Version:      kongVersion,
+		DBMode:        dbMode,
+		RouterFlavor:  routerFlavor,
+		Version:       kongVersion,
+		LoadedPlugins: loadedPlugins,
+	}, nil
+}
+
+// lowestKongVersion returns the lowest version of the Kong instances.
+func lowestKongVersion(roots []Root) (kong.Version, error) {
+	var lowest kong.Version
+	for i, r := range roots {
+		v, err := KongVersionFromRoot(r)
+		if err != nil {
+			return kong.Version{}, err
+		}
+		if i == 0 || toSemver(v).LT(toSemver(lowest)) {
+			lowest = v
+		}
+	}
+	return lowest, nil
+}
+
+// GatewayFromRoot describes the Kong Gateway the configuration root was fetched from.
+func GatewayFromRoot(r Root) (capabilities.Gateway, error) {
+	v, err := KongVersionFromRoot(r)
+	if err != nil {
+		return capabilities.Gateway{}, err
+	}
+	routerFlavor, err := RouterFlavorFromRoot(r)
+	if err != nil {
+		return capabilities.Gateway{}, err
+	}
+	loadedPlugins, _, err := LoadedPluginsFromRoot(r)
+	if err != nil {
+		return capabilities.Gateway{}, err
+	}
+
+	edition := capabilities.EditionOSS
+	if v.IsKongGatewayEnterprise() {
+		edition = capabilities.EditionEnterprise
+	}
+	return capabilities.Gateway{
+		Version:       toSemver(v),
+		Edition:       edition,
+		RouterFlavor:  routerFlavor,
+		LoadedPlugins: loadedPlugins,
 	}, nil
 }
 
+// DetectGateway fetches the configuration root of the Kong Gateway the client communicates with and describes
+// the gateway.
+func DetectGateway(ctx context.Context, client *adminapi.Client) (capabilities.Gateway, error) {
+	root, err := client.AdminAPIClient().Root(ctx)
+	if err != nil {
+		return capabilities.Gateway{}, fmt.Errorf("could not retrieve Kong admin root: %w", err)
+	}
+	return GatewayFromRoot(root)
+}
+
+func toSemver(v kong.Version) semver.Version {
+	return semver.Version{Major: v.Major(), Minor: v.Minor(), Patch: v.Patch()}
+}
+
+// commonLoadedPlugins returns plugins loaded by all Kong instances, sorted. nil is returned when any of them doesn't
+// report its loaded plugins.
+func commonLoadedPlugins(roots []Root) ([]string, error) {
+	var common []string
+	for i, r := range roots {
+		loaded, ok, err := LoadedPluginsFromRoot(r)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, nil
+		}
+		if i == 0 {
+			common = loaded
+			continue
+		}
+		common = lo.Intersect(common, loaded)
+	}
+	sort.Strings(common)
+	return common, nil
+}
+
 func extractConfigurationFromRoot(r Root) (map[string]any, error) {
 	rootConfig, ok := r["configuration"].(map[string]any)
 	if !ok {
@@ -112,6 +204,33 @@ func RouterFlavorFromRoot(r Root) (string, error) {
 	return routerFlavorStr, nil
 }
 
+// LoadedPluginsFromRoot returns names of plugins loaded by Kong. The second return value is false when Kong
+// doesn't report them.
+func LoadedPluginsFromRoot(r Root) ([]string, bool, error) {
+	rootConfig, err := extractConfigurationFromRoot(r)
+	if err != nil {
+		return nil, false, err
+	}
+
+	const loadedPluginsKey = "loaded_plugins"
+	loadedPlugins, exist := rootConfig[loadedPluginsKey]
+	if !exist {
+		return nil, false, nil
+	}
+	loadedPluginsMap, ok := loadedPlugins.(map[string]any)
+	if !ok {
+		return nil, false, fmt.Errorf("invalid %q type, expected a map[string]any, got %T", loadedPluginsKey, loadedPlugins)
+	}
+	names := make([]string, 0, len(loadedPluginsMap))
+	for name, loaded := range loadedPluginsMap {
+		if loaded == true {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, true, nil
+}
+
 func KongVersionFromRoot(r Root) (kong.Version, error) {
 	v := kong.VersionFromInfo(r)
 	kv, err := kong.ParseSemanticVersion(v)
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/capabilities"
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/versions"
 )
 
@@ -44,10 +45,44 @@ func TestValidateRoots(t *testing.T) {
 			assert.Equal(t, tc.expectedDBMode, kongOptions.DBMode)
 			assert.Equal(t, tc.expectedRouterFlavor, kongOptions.RouterFlavor)
 			assert.Equal(t, tc.expectedKongVersion, kongOptions.Version.String())
+			assert.Contains(t, kongOptions.LoadedPlugins, "key-auth")
+			assert.IsIncreasing(t, kongOptions.LoadedPlugins)
 		})
 	}
 }
 
+func TestValidateRoots_LoadedPlugins(t *testing.T) {
+	newRoot := func(t *testing.T, loadedPlugins map[string]any) Root {
+		var root Root
+		require.NoError(t, json.Unmarshal([]byte(dblessConfigJSON3_4_1), &root))
+		config := root["configuration"].(map[string]any)
+		if loadedPlugins == nil {
+			delete(config, "loaded_plugins")
+		} else {
+			config["loaded_plugins"] = loadedPlugins
+		}
+		return root
+	}
+
+	t.Run("plugins loaded by all instances", func(t *testing.T) {
+		kongOptions, err := ValidateRoots([]Root{
+			newRoot(t, map[string]any{"key-auth": true, "cors": true, "acl": true}),
+			newRoot(t, map[string]any{"key-auth": true, "acl": true, "session": true}),
+		}, false)
+		require.NoError(t, err)
+		require.Equal(t, []string{"acl", "key-auth"}, kongOptions.LoadedPlugins)
+	})
+
+	t.Run("instance not reporting loaded plugins", func(t *testing.T) {
+		kongOptions, err := ValidateRoots([]Root{
+			newRoot(t, map[string]any{"key-auth": true}),
+			newRoot(t, nil),
+		}, false)
+		require.NoError(t, err)
+		require.Nil(t, kongOptions.LoadedPlugins)
+	})
+}
+
 const dblessConfigJSON3_4_1 = `
 {
 	"node_id": "69d063c5-761b-4bab-a426-c89da49a9409",
@@ -803,3 +838,33 @@ const dblessConfigJSON3_4_1 = `
 	}
   }
 `
+
+func TestValidateRoots_LowestVersion(t *testing.T) {
+	newRoot := func(t *testing.T, version string) Root {
+		var root Root
+		require.NoError(t, json.Unmarshal([]byte(dblessConfigJSON3_4_1), &root))
+		root["version"] = version
+		return root
+	}
+
+	kongOptions, err := ValidateRoots([]Root{newRoot(t, "3.5.0"), newRoot(t, "3.4.1"), newRoot(t, "3.4.2")}, false)
+	require.NoError(t, err)
+	require.Equal(t, "3.4.1", kongOptions.Version.String())
+}
+
+func TestGatewayFromRoot(t *testing.T) {
+	var root Root
+	require.NoError(t, json.Unmarshal([]byte(dblessConfigJSON3_4_1), &root))
+
+	g, err := GatewayFromRoot(root)
+	require.NoError(t, err)
+	require.Equal(t, "3.4.1", g.Version.String())
+	require.Equal(t, capabilities.EditionOSS, g.Edition)
+	require.Equal(t, "traditional_compatible", g.RouterFlavor)
+	require.Contains(t, g.LoadedPlugins, "key-auth")
+
+	root["version"] = "3.4.1.0-enterprise-edition"
+	g, err = GatewayFromRoot(root)
+	require.NoError(t, err)
+	require.Equal(t, capabilities.EditionEnterprise, g.Edition)
+}
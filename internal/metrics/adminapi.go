@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// AdminAPITransportMetrics are metrics of the HTTP transport used to communicate with Kong Admin APIs.
+type AdminAPITransportMetrics struct {
+	TransportReloadCount *prometheus.CounterVec
+}
+
+const (
+	MetricNameAdminAPITransportReloadCount = "ingress_controller_admin_api_transport_reload_count"
+)
+
+// NewAdminAPITransportMetrics creates and registers AdminAPITransportMetrics.
+func NewAdminAPITransportMetrics() *AdminAPITransportMetrics {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	transportMetrics := &AdminAPITransportMetrics{}
+
+	transportMetrics.TransportReloadCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameAdminAPITransportReloadCount,
+			Help: fmt.Sprintf("Count of rebuilds of the Kong Admin API HTTP transport triggered by changes of "+
+				"TLS material or admin token files (e.g. their rotation). "+
+				"`%s` describes whether the transport was rebuilt (`%s`) or the new files were rejected (`%s`).",
+				SuccessKey, SuccessTrue, SuccessFalse,
+			),
+		},
+		[]string{SuccessKey},
+	)
+
+	metrics.Registry.Unregister(transportMetrics.TransportReloadCount)
+	metrics.Registry.MustRegister(transportMetrics.TransportReloadCount)
+
+	return transportMetrics
+}
+
+// RecordTransportReload records a rebuild of the Admin API HTTP transport.
+func (m *AdminAPITransportMetrics) RecordTransportReload(err error) {
+	success := SuccessTrue
+	if err != nil {
+		success = SuccessFalse
+	}
+	m.TransportReloadCount.With(prometheus.Labels{SuccessKey: success}).Inc()
+}
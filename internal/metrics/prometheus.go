@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"sync"
 	"time"
 
@@ -27,6 +28,47 @@ type CtrlFuncMetrics struct {
 	ConfigPushDuration *prometheus.HistogramVec
 
 	ConfigPushSuccessTime *prometheus.GaugeVec
+
+	DryRunConfigDiff *prometheus.GaugeVec
+
+	ConfigGenerationDuration *prometheus.HistogramVec
+
+	GatewayConfigInSync *prometheus.GaugeVec
+
+	GatewayInfo *prometheus.GaugeVec
+
+	GatewayCircuitBreakerOpen *prometheus.GaugeVec
+
+	GatewayVersion *prometheus.GaugeVec
+
+	GatewayDistinctVersions prometheus.Gauge
+
+	ConfigConvergenceDuration *prometheus.HistogramVec
+
+	ConfigDriftCount *prometheus.CounterVec
+
+	ConfigDriftEntities *prometheus.GaugeVec
+
+	ConfigChangeToPushDuration prometheus.Histogram
+
+	ConfigEntities *prometheus.GaugeVec
+
+	ConfigSize *prometheus.GaugeVec
+
+	TranslationPhaseDuration *prometheus.HistogramVec
+
+	StoreObjects *prometheus.GaugeVec
+
+	ObjectLastApplied *prometheus.GaugeVec
+
+	TranslationFailingObjects *prometheus.GaugeVec
+
+	PushFailingObjects *prometheus.GaugeVec
+
+	// objectsLastApplied are objects ObjectLastApplied has time series of, so that the ones of objects that are
+	// not configured anymore can be removed.
+	objectsLastApplied     map[ObjectRef]struct{}
+	objectsLastAppliedLock sync.Mutex
 }
 
 const (
@@ -46,6 +88,10 @@ const (
 	ProtocolDBLess Protocol = "db-less"
 	// ProtocolDeck indicates that configuration was sent to Kong using the DB mode protocol (deck sync).
 	ProtocolDeck Protocol = "deck"
+	// ProtocolFile indicates that configuration was written to a declarative configuration file.
+	ProtocolFile Protocol = "file"
+	// ProtocolConfigMap indicates that configuration was written to ConfigMaps holding declarative configuration.
+	ProtocolConfigMap Protocol = "configmap"
 
 	// ProtocolKey defines the key of the metric label indicating which protocol KIC used to configure Kong.
 	ProtocolKey string = "protocol"
@@ -68,6 +114,79 @@ const (
 const (
 	// DataplaneKey defines the name of the metric label indicating which dataplane this time series is relevant for.
 	DataplaneKey string = "dataplane"
+
+	// VersionKey defines the name of the metric label indicating the version of a dataplane.
+	VersionKey string = "version"
+
+	// ServiceKey defines the name of the metric label indicating the Kubernetes Service a dataplane was discovered
+	// from ("namespace/name"). It's empty for dataplanes configured with static Admin API URLs.
+	ServiceKey string = "service"
+)
+
+const (
+	// ChangeAdded indicates entities that would be added to the dataplane's configuration.
+	ChangeAdded string = "added"
+	// ChangeRemoved indicates entities that would be removed from the dataplane's configuration.
+	ChangeRemoved string = "removed"
+	// ChangeModified indicates entities that would be modified in the dataplane's configuration.
+	ChangeModified string = "modified"
+
+	// ChangeKey defines the name of the metric label indicating the kind of change of configuration entities.
+	ChangeKey string = "change"
+)
+
+const (
+	// GenerationTargetGateways indicates configuration generated once for all Kong Gateways.
+	GenerationTargetGateways string = "gateways"
+	// GenerationTargetKonnect indicates configuration generated for Konnect.
+	GenerationTargetKonnect string = "konnect"
+	// GenerationTargetDeclarativeConfigOutputs indicates configuration generated once for all declarative
+	// configuration outputs.
+	GenerationTargetDeclarativeConfigOutputs string = "declarative-config-outputs"
+
+	// GenerationTargetKey defines the name of the metric label indicating which targets the configuration was generated for.
+	GenerationTargetKey string = "target"
+)
+
+const (
+	// EntityTypeKey defines the name of the metric label indicating the type of Kong entities (e.g. `services`).
+	EntityTypeKey string = "entity_type"
+
+	// NamespaceKey defines the name of the metric label indicating the namespace of Kubernetes objects.
+	NamespaceKey string = "namespace"
+
+	// SourceKindKey defines the name of the metric label indicating the kind of Kubernetes objects Kong entities
+	// were generated from.
+	SourceKindKey string = "source_kind"
+
+	// KindKey defines the name of the metric label indicating the kind of Kubernetes objects.
+	KindKey string = "kind"
+
+	// NameKey defines the name of the metric label indicating the name of a Kubernetes object.
+	NameKey string = "name"
+
+	// PhaseKey defines the name of the metric label indicating the phase of translating Kubernetes objects
+	// into Kong configuration.
+	PhaseKey string = "phase"
+
+	// ReasonKey defines the name of the metric label indicating the category of the reason of a failure.
+	ReasonKey string = "reason"
+)
+
+const (
+	// MaxFailureNamespaces limits the number of namespaces metrics of failing objects have distinct time series for.
+	// Failing objects in namespaces beyond the limit (the ones with the fewest failing objects) are counted under
+	// NamespaceOther, so that failures spread across many namespaces don't make cardinality of the metrics unbounded.
+	MaxFailureNamespaces = 100
+
+	// NamespaceOther is the namespace label value failing objects in namespaces beyond MaxFailureNamespaces are
+	// counted under. It's not a valid name of a Kubernetes namespace, so it can't be mistaken for one.
+	NamespaceOther string = "_other"
+
+	// MaxObjectsLastApplied limits the number of objects the metric of objects' last applied time has time series
+	// of, so that it doesn't grow with the number of objects in the cluster without a bound. Objects that already
+	// have time series keep them, while new objects beyond the limit aren't recorded until others are removed.
+	MaxObjectsLastApplied = 1000
 )
 
 const (
@@ -77,6 +196,24 @@ const (
 	MetricNameTranslationCount           = "ingress_controller_translation_count"
 	MetricNameTranslationBrokenResources = "ingress_controller_translation_broken_resource_count"
 	MetricNameConfigPushDuration         = "ingress_controller_configuration_push_duration_milliseconds"
+	MetricNameDryRunConfigDiff           = "ingress_controller_dry_run_configuration_diff_entity_count"
+	MetricNameConfigGenerationDuration   = "ingress_controller_configuration_generation_duration_milliseconds"
+	MetricNameGatewayConfigInSync        = "ingress_controller_gateway_configuration_in_sync"
+	MetricNameGatewayInfo                = "ingress_controller_gateway_info"
+	MetricNameGatewayCircuitBreakerOpen  = "ingress_controller_gateway_circuit_breaker_open"
+	MetricNameGatewayVersion             = "ingress_controller_gateway_version"
+	MetricNameGatewayDistinctVersions    = "ingress_controller_gateway_distinct_versions"
+	MetricNameConfigConvergenceDuration  = "ingress_controller_configuration_convergence_duration_milliseconds"
+	MetricNameConfigDriftCount           = "ingress_controller_configuration_drift_count"
+	MetricNameConfigDriftEntities        = "ingress_controller_configuration_drift_entity_count"
+	MetricNameConfigChangeToPushDuration = "ingress_controller_configuration_change_to_push_duration_milliseconds"
+	MetricNameConfigEntities             = "ingress_controller_configuration_entity_count"
+	MetricNameConfigSize                 = "ingress_controller_configuration_size_bytes"
+	MetricNameTranslationPhaseDuration   = "ingress_controller_translation_phase_duration_milliseconds"
+	MetricNameStoreObjects               = "ingress_controller_store_object_count"
+	MetricNameObjectLastApplied          = "ingress_controller_object_last_applied"
+	MetricNameTranslationFailingObjects  = "ingress_controller_translation_failing_object_count"
+	MetricNamePushFailingObjects         = "ingress_controller_configuration_push_failing_object_count"
 )
 
 var _lock sync.Mutex
@@ -85,7 +222,9 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 	_lock.Lock()
 	defer _lock.Unlock()
 
-	controllerMetrics := &CtrlFuncMetrics{}
+	controllerMetrics := &CtrlFuncMetrics{
+		objectsLastApplied: make(map[ObjectRef]struct{}),
+	}
 
 	controllerMetrics.ConfigPushCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -93,12 +232,12 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 			Help: fmt.Sprintf(
 				"Count of successful/failed configuration pushes to Kong. "+
 					"`%s` describes the dataplane that was the target of configuration push. "+
-					"`%s` describes the configuration protocol (`%s` or `%s`) in use. "+
+					"`%s` describes the configuration protocol (one of `%s`, `%s`, `%s`, `%s`) in use. "+
 					"`%s` describes whether there were unrecoverable errors (`%s`) or not (`%s`). "+
 					"`%s` is populated in case of `%s=\"%s\"` and describes the reason of failure "+
 					"(one of `%s`, `%s`, `%s`).",
 				DataplaneKey,
-				ProtocolKey, ProtocolDBLess, ProtocolDeck,
+				ProtocolKey, ProtocolDBLess, ProtocolDeck, ProtocolFile, ProtocolConfigMap,
 				SuccessKey, SuccessFalse, SuccessTrue,
 				FailureReasonKey, SuccessKey, SuccessFalse,
 				FailureReasonConflict, FailureReasonNetwork, FailureReasonOther,
@@ -146,10 +285,10 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 			Help: fmt.Sprintf(
 				"How long it took to push the configuration to Kong, in milliseconds. "+
 					"`%s` describes the dataplane that was the target of configuration push. "+
-					"`%s` describes the configuration protocol (`%s` or `%s`) in use. "+
+					"`%s` describes the configuration protocol (one of `%s`, `%s`, `%s`, `%s`) in use. "+
 					"`%s` describes whether there were unrecoverable errors (`%s`) or not (`%s`).",
 				DataplaneKey,
-				ProtocolKey, ProtocolDBLess, ProtocolDeck,
+				ProtocolKey, ProtocolDBLess, ProtocolDeck, ProtocolFile, ProtocolConfigMap,
 				SuccessKey, SuccessFalse, SuccessTrue,
 			),
 			Buckets: prometheus.ExponentialBuckets(100, 1.33, 30),
@@ -168,12 +307,266 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 		[]string{DataplaneKey},
 	)
 
+	controllerMetrics.DryRunConfigDiff = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameDryRunConfigDiff,
+			Help: fmt.Sprintf("The number of entities that differ between the configuration generated in dry-run mode "+
+				"and the configuration currently loaded by Kong. "+
+				"`%s` describes the dataplane the configuration was compared with. "+
+				"`%s` describes the kind of difference (one of `%s`, `%s`, `%s`).",
+				DataplaneKey,
+				ChangeKey, ChangeAdded, ChangeRemoved, ChangeModified,
+			),
+		},
+		[]string{DataplaneKey, ChangeKey},
+	)
+
+	controllerMetrics.ConfigGenerationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: MetricNameConfigGenerationDuration,
+			Help: fmt.Sprintf(
+				"How long it took to generate the configuration (including its hash and serialized form) that is "+
+					"pushed to Kong, in milliseconds. The configuration is generated once per synchronization for all "+
+					"targets of the same kind. `%s` describes the targets the configuration was generated for "+
+					"(one of `%s`, `%s`, `%s`).",
+				GenerationTargetKey,
+				GenerationTargetGateways, GenerationTargetKonnect, GenerationTargetDeclarativeConfigOutputs,
+			),
+			Buckets: prometheus.ExponentialBuckets(1, 1.5, 30),
+		},
+		[]string{GenerationTargetKey},
+	)
+
+	controllerMetrics.GatewayConfigInSync = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameGatewayConfigInSync,
+			Help: fmt.Sprintf("Whether the most recent configuration was successfully pushed to Kong Gateway (1) or "+
+				"the gateway is lagging behind (0). "+
+				"`%s` describes the dataplane that was the target of configuration push.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.GatewayInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameGatewayInfo,
+			Help: fmt.Sprintf("Kong Gateways the configuration is pushed to, always 1. "+
+				"`%s` describes the dataplane. "+
+				"`%s` describes the Kong Admin API Service the dataplane was discovered from (empty if it's not discovered).",
+				DataplaneKey,
+				ServiceKey,
+			),
+		},
+		[]string{DataplaneKey, ServiceKey},
+	)
+
+	controllerMetrics.GatewayCircuitBreakerOpen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameGatewayCircuitBreakerOpen,
+			Help: fmt.Sprintf("Whether the circuit breaker of configuration updates of Kong Gateway is open (1), i.e. updates "+
+				"are backed off after the gateway failed to apply them, or closed (0). "+
+				"`%s` describes the dataplane that was the target of configuration push.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.GatewayVersion = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameGatewayVersion,
+			Help: fmt.Sprintf("Versions of Kong Gateways the configuration is pushed to, always 1. "+
+				"`%s` describes the dataplane. "+
+				"`%s` describes the version of Kong Gateway.",
+				DataplaneKey,
+				VersionKey,
+			),
+		},
+		[]string{DataplaneKey, VersionKey},
+	)
+
+	controllerMetrics.GatewayDistinctVersions = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: MetricNameGatewayDistinctVersions,
+			Help: "Number of distinct versions of Kong Gateways the configuration is pushed to. More than 1 means " +
+				"a version skew (e.g. during a rolling upgrade), in which case the configuration is generated for " +
+				"what all of them support.",
+		},
+	)
+
+	controllerMetrics.ConfigConvergenceDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: MetricNameConfigConvergenceDuration,
+			Help: fmt.Sprintf(
+				"How long it took Kong Gateway to report the pushed configuration's hash in its /status endpoint "+
+					"after the push, in milliseconds. "+
+					"`%s` describes the dataplane that was the target of configuration push. "+
+					"`%s` describes whether the gateway converged (`%s`) or not (`%s`) within the timeout.",
+				DataplaneKey,
+				SuccessKey, SuccessTrue, SuccessFalse,
+			),
+			Buckets: prometheus.ExponentialBuckets(1, 1.5, 30),
+		},
+		[]string{SuccessKey, DataplaneKey},
+	)
+
+	controllerMetrics.ConfigDriftCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: MetricNameConfigDriftCount,
+			Help: fmt.Sprintf("Count of times Kong Gateway was found running a configuration other than the one "+
+				"that was pushed to it, which made the configuration to be pushed again. "+
+				"`%s` describes the dataplane that drifted.",
+				DataplaneKey,
+			),
+		},
+		[]string{DataplaneKey},
+	)
+
+	controllerMetrics.ConfigDriftEntities = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameConfigDriftEntities,
+			Help: fmt.Sprintf("The number of managed entities that were changed in Kong Gateway running in DB mode "+
+				"out of band (e.g. through the Admin API), as found by the most recent drift detection. "+
+				"`%s` describes the dataplane that drifted. "+
+				"`%s` describes the kind of change made out of band (one of `%s`, `%s`, `%s`).",
+				DataplaneKey,
+				ChangeKey, ChangeAdded, ChangeRemoved, ChangeModified,
+			),
+		},
+		[]string{DataplaneKey, ChangeKey},
+	)
+
+	controllerMetrics.ConfigChangeToPushDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name: MetricNameConfigChangeToPushDuration,
+			Help: "How long it took from the oldest change of Kubernetes objects included in a configuration " +
+				"to the configuration being successfully pushed to Kong Gateways, in milliseconds.",
+			Buckets: prometheus.ExponentialBuckets(10, 1.5, 30),
+		},
+	)
+
+	controllerMetrics.ConfigEntities = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameConfigEntities,
+			Help: fmt.Sprintf(
+				"The number of Kong entities in the most recently translated configuration. "+
+					"`%s` describes the type of the entities (e.g. `services`, `routes`, `plugins`). "+
+					"`%s` and `%s` describe the namespace and kind of Kubernetes objects the entities were "+
+					"generated from. They're empty for entities not generated from a single object (e.g. certificates).",
+				EntityTypeKey,
+				NamespaceKey, SourceKindKey,
+			),
+		},
+		[]string{EntityTypeKey, NamespaceKey, SourceKindKey},
+	)
+
+	controllerMetrics.ConfigSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameConfigSize,
+			Help: fmt.Sprintf(
+				"Size of the most recently generated declarative configuration serialized to JSON, in bytes. "+
+					"`%s` describes which targets the configuration was generated for (one of `%s`, `%s`, `%s`).",
+				GenerationTargetKey,
+				GenerationTargetGateways, GenerationTargetKonnect, GenerationTargetDeclarativeConfigOutputs,
+			),
+		},
+		[]string{GenerationTargetKey},
+	)
+
+	controllerMetrics.TranslationPhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: MetricNameTranslationPhaseDuration,
+			Help: fmt.Sprintf(
+				"How long it took to run a phase of translating Kubernetes objects into Kong configuration, "+
+					"in milliseconds. `%s` describes the phase (e.g. `TranslateIngressRules`, `FillPlugins`).",
+				PhaseKey,
+			),
+			Buckets: prometheus.ExponentialBuckets(1, 1.5, 30),
+		},
+		[]string{PhaseKey},
+	)
+
+	controllerMetrics.StoreObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameStoreObjects,
+			Help: fmt.Sprintf(
+				"The number of Kubernetes objects in the store configuration is translated from. "+
+					"`%s` describes the kind of the objects.",
+				KindKey,
+			),
+		},
+		[]string{KindKey},
+	)
+
+	controllerMetrics.ObjectLastApplied = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameObjectLastApplied,
+			Help: fmt.Sprintf(
+				"Time (as a Unix timestamp) when the configuration generated from a Kubernetes object was last "+
+					"successfully pushed to Kong Gateways. Objects failing translation keep the time they were "+
+					"last applied at, objects that aren't configured anymore are removed. "+
+					"`%s`, `%s` and `%s` identify the object. Recorded only when status updates are enabled, "+
+					"for up to %d objects.",
+				KindKey, NamespaceKey, NameKey, MaxObjectsLastApplied,
+			),
+		},
+		[]string{KindKey, NamespaceKey, NameKey},
+	)
+
+	controllerMetrics.TranslationFailingObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNameTranslationFailingObjects,
+			Help: fmt.Sprintf(
+				"The number of Kubernetes objects that failed translation in the most recent translation. "+
+					"`%s` and `%s` describe the kind and namespace of the objects (namespaces beyond the %d with "+
+					"the most failing objects are counted as `%s`). `%s` describes the category of the reason "+
+					"of the failure (e.g. `missing_reference`, `invalid`).",
+				KindKey, NamespaceKey, MaxFailureNamespaces, NamespaceOther, ReasonKey,
+			),
+		},
+		[]string{KindKey, NamespaceKey, ReasonKey},
+	)
+
+	controllerMetrics.PushFailingObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: MetricNamePushFailingObjects,
+			Help: fmt.Sprintf(
+				"The number of Kubernetes objects whose configuration was rejected by Kong in the most recent "+
+					"configuration push. `%s` and `%s` describe the kind and namespace of the objects (namespaces "+
+					"beyond the %d with the most failing objects are counted as `%s`). `%s` describes the category "+
+					"of the reason of the failure (e.g. `invalid`).",
+				KindKey, NamespaceKey, MaxFailureNamespaces, NamespaceOther, ReasonKey,
+			),
+		},
+		[]string{KindKey, NamespaceKey, ReasonKey},
+	)
+
 	metrics.Registry.Unregister(controllerMetrics.ConfigPushCount)
 	metrics.Registry.Unregister(controllerMetrics.ConfigPushBrokenResources)
 	metrics.Registry.Unregister(controllerMetrics.TranslationCount)
 	metrics.Registry.Unregister(controllerMetrics.TranslationBrokenResources)
 	metrics.Registry.Unregister(controllerMetrics.ConfigPushDuration)
 	metrics.Registry.Unregister(controllerMetrics.ConfigPushSuccessTime)
+	metrics.Registry.Unregister(controllerMetrics.DryRunConfigDiff)
+	metrics.Registry.Unregister(controllerMetrics.ConfigGenerationDuration)
+	metrics.Registry.Unregister(controllerMetrics.GatewayConfigInSync)
+	metrics.Registry.Unregister(controllerMetrics.GatewayInfo)
+	metrics.Registry.Unregister(controllerMetrics.GatewayCircuitBreakerOpen)
+	metrics.Registry.Unregister(controllerMetrics.GatewayVersion)
+	metrics.Registry.Unregister(controllerMetrics.GatewayDistinctVersions)
+	metrics.Registry.Unregister(controllerMetrics.ConfigConvergenceDuration)
+	metrics.Registry.Unregister(controllerMetrics.ConfigDriftCount)
+	metrics.Registry.Unregister(controllerMetrics.ConfigDriftEntities)
+	metrics.Registry.Unregister(controllerMetrics.ConfigChangeToPushDuration)
+	metrics.Registry.Unregister(controllerMetrics.ConfigEntities)
+	metrics.Registry.Unregister(controllerMetrics.ConfigSize)
+	metrics.Registry.Unregister(controllerMetrics.TranslationPhaseDuration)
+	metrics.Registry.Unregister(controllerMetrics.StoreObjects)
+	metrics.Registry.Unregister(controllerMetrics.ObjectLastApplied)
+	metrics.Registry.Unregister(controllerMetrics.TranslationFailingObjects)
+	metrics.Registry.Unregister(controllerMetrics.PushFailingObjects)
 
 	metrics.Registry.MustRegister(
 		controllerMetrics.ConfigPushCount,
@@ -182,6 +575,24 @@ func NewCtrlFuncMetrics() *CtrlFuncMetrics {
 		controllerMetrics.TranslationBrokenResources,
 		controllerMetrics.ConfigPushDuration,
 		controllerMetrics.ConfigPushSuccessTime,
+		controllerMetrics.DryRunConfigDiff,
+		controllerMetrics.ConfigGenerationDuration,
+		controllerMetrics.GatewayConfigInSync,
+		controllerMetrics.GatewayInfo,
+		controllerMetrics.GatewayCircuitBreakerOpen,
+		controllerMetrics.GatewayVersion,
+		controllerMetrics.GatewayDistinctVersions,
+		controllerMetrics.ConfigConvergenceDuration,
+		controllerMetrics.ConfigDriftCount,
+		controllerMetrics.ConfigDriftEntities,
+		controllerMetrics.ConfigChangeToPushDuration,
+		controllerMetrics.ConfigEntities,
+		controllerMetrics.ConfigSize,
+		controllerMetrics.TranslationPhaseDuration,
+		controllerMetrics.StoreObjects,
+		controllerMetrics.ObjectLastApplied,
+		controllerMetrics.TranslationFailingObjects,
+		controllerMetrics.PushFailingObjects,
 	)
 
 	return controllerMetrics
@@ -204,6 +615,266 @@ func (c *CtrlFuncMetrics) RecordPushFailure(p Protocol, d time.Duration, datapla
 	c.recordPushBrokenResources(count, dpOpt)
 }
 
+// RecordConfigGeneration records the duration of generating the configuration for the target.
+func (c *CtrlFuncMetrics) RecordConfigGeneration(target string, d time.Duration) {
+	c.ConfigGenerationDuration.With(prometheus.Labels{
+		GenerationTargetKey: target,
+	}).Observe(float64(d.Milliseconds()))
+}
+
+// RecordGatewaysConfigInSync records whether the most recent configuration was pushed to each of the gateways
+// (keyed by their dataplane). Gateways that are not present anymore are removed from the metric.
+func (c *CtrlFuncMetrics) RecordGatewaysConfigInSync(inSync map[string]bool) {
+	c.GatewayConfigInSync.Reset()
+	for dataplane, ok := range inSync {
+		value := 0.0
+		if ok {
+			value = 1.0
+		}
+		c.GatewayConfigInSync.With(prometheus.Labels{DataplaneKey: dataplane}).Set(value)
+	}
+}
+
+// RecordGatewaysInfo records the Kong Admin API Service each of the gateways (keyed by their dataplane) was
+// discovered from. Gateways that are not present anymore are removed from the metric.
+func (c *CtrlFuncMetrics) RecordGatewaysInfo(services map[string]string) {
+	c.GatewayInfo.Reset()
+	for dataplane, service := range services {
+		c.GatewayInfo.With(prometheus.Labels{DataplaneKey: dataplane, ServiceKey: service}).Set(1)
+	}
+}
+
+// RecordGatewaysCircuitBreakerOpen records whether the circuit breaker of configuration updates of each of
+// the gateways (keyed by their dataplane) is open. Gateways that are not present anymore are removed from the metric.
+func (c *CtrlFuncMetrics) RecordGatewaysCircuitBreakerOpen(open map[string]bool) {
+	c.GatewayCircuitBreakerOpen.Reset()
+	for dataplane, isOpen := range open {
+		value := 0.0
+		if isOpen {
+			value = 1.0
+		}
+		c.GatewayCircuitBreakerOpen.With(prometheus.Labels{DataplaneKey: dataplane}).Set(value)
+	}
+}
+
+// RecordGatewaysVersion records versions of each of the gateways (keyed by their dataplane) and the number of
+// distinct versions among them. Gateways that are not present anymore are removed from the metric.
+func (c *CtrlFuncMetrics) RecordGatewaysVersion(versions map[string]string) {
+	c.GatewayVersion.Reset()
+	distinct := make(map[string]struct{}, len(versions))
+	for dataplane, version := range versions {
+		c.GatewayVersion.With(prometheus.Labels{DataplaneKey: dataplane, VersionKey: version}).Set(1)
+		distinct[version] = struct{}{}
+	}
+	c.GatewayDistinctVersions.Set(float64(len(distinct)))
+}
+
+// RecordConfigConvergence records how long it took the dataplane to report the pushed configuration and whether
+// it did so within the timeout.
+func (c *CtrlFuncMetrics) RecordConfigConvergence(dataplane string, d time.Duration, converged bool) {
+	success := SuccessTrue
+	if !converged {
+		success = SuccessFalse
+	}
+	c.ConfigConvergenceDuration.With(prometheus.Labels{
+		SuccessKey:   success,
+		DataplaneKey: dataplane,
+	}).Observe(float64(d.Milliseconds()))
+}
+
+// RecordConfigDrift records that the dataplane was found running a configuration other than the pushed one.
+func (c *CtrlFuncMetrics) RecordConfigDrift(dataplane string) {
+	c.ConfigDriftCount.With(prometheus.Labels{DataplaneKey: dataplane}).Inc()
+}
+
+// RecordConfigDriftEntities records the number of managed entities that were changed in the dataplane out of band.
+func (c *CtrlFuncMetrics) RecordConfigDriftEntities(dataplane string, added, removed, modified int) {
+	for change, count := range map[string]int{
+		ChangeAdded:    added,
+		ChangeRemoved:  removed,
+		ChangeModified: modified,
+	} {
+		c.ConfigDriftEntities.With(prometheus.Labels{
+			DataplaneKey: dataplane,
+			ChangeKey:    change,
+		}).Set(float64(count))
+	}
+}
+
+// RecordChangeToPush records how long it took from a change of Kubernetes objects to pushing it to Kong Gateways.
+func (c *CtrlFuncMetrics) RecordChangeToPush(d time.Duration) {
+	c.ConfigChangeToPushDuration.Observe(float64(d.Milliseconds()))
+}
+
+// EntityCount is the number of Kong entities of a type generated from Kubernetes objects of a kind in a namespace.
+type EntityCount struct {
+	EntityType string
+	Namespace  string
+	SourceKind string
+	Count      int
+}
+
+// RecordConfigEntities records the number of Kong entities in the most recently translated configuration.
+// Entities that are not present anymore are removed from the metric.
+func (c *CtrlFuncMetrics) RecordConfigEntities(counts []EntityCount) {
+	c.ConfigEntities.Reset()
+	for _, ec := range counts {
+		c.ConfigEntities.With(prometheus.Labels{
+			EntityTypeKey: ec.EntityType,
+			NamespaceKey:  ec.Namespace,
+			SourceKindKey: ec.SourceKind,
+		}).Set(float64(ec.Count))
+	}
+}
+
+// RecordConfigSize records the size of the configuration generated for the target, in bytes.
+func (c *CtrlFuncMetrics) RecordConfigSize(target string, size int) {
+	c.ConfigSize.With(prometheus.Labels{GenerationTargetKey: target}).Set(float64(size))
+}
+
+// RecordTranslationPhase records the duration of a phase of translating Kubernetes objects into Kong configuration.
+func (c *CtrlFuncMetrics) RecordTranslationPhase(phase string, d time.Duration) {
+	c.TranslationPhaseDuration.With(prometheus.Labels{PhaseKey: phase}).Observe(float64(d.Milliseconds()))
+}
+
+// RecordStoreObjects records the number of Kubernetes objects in the store by their kind.
+func (c *CtrlFuncMetrics) RecordStoreObjects(counts map[string]int) {
+	for kind, count := range counts {
+		c.StoreObjects.With(prometheus.Labels{KindKey: kind}).Set(float64(count))
+	}
+}
+
+// ObjectRef identifies a Kubernetes object in labels of metrics.
+type ObjectRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (r ObjectRef) labels() prometheus.Labels {
+	return prometheus.Labels{KindKey: r.Kind, NamespaceKey: r.Namespace, NameKey: r.Name}
+}
+
+// RecordObjectsApplied records that the configuration generated from applied objects was successfully pushed now.
+// Retained objects (e.g. failing translation) keep the time they were last applied at, while other objects that
+// were applied before (e.g. deleted ones) are removed from the metric. Up to MaxObjectsLastApplied objects are
+// recorded.
+func (c *CtrlFuncMetrics) RecordObjectsApplied(applied, retained []ObjectRef) {
+	c.objectsLastAppliedLock.Lock()
+	defer c.objectsLastAppliedLock.Unlock()
+
+	current := make(map[ObjectRef]struct{}, len(applied)+len(retained))
+	var added []ObjectRef
+	for _, obj := range applied {
+		if _, ok := c.objectsLastApplied[obj]; ok {
+			current[obj] = struct{}{}
+		} else {
+			added = append(added, obj)
+		}
+	}
+	for _, obj := range retained {
+		if _, ok := c.objectsLastApplied[obj]; ok {
+			current[obj] = struct{}{}
+		}
+	}
+	// Objects that are recorded already are kept, new ones are added in a stable order as long as the limit allows.
+	sort.Slice(added, func(i, j int) bool {
+		a, b := added[i], added[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	for _, obj := range added {
+		if len(current) >= MaxObjectsLastApplied {
+			break
+		}
+		current[obj] = struct{}{}
+	}
+
+	for obj := range c.objectsLastApplied {
+		if _, ok := current[obj]; !ok {
+			c.ObjectLastApplied.Delete(obj.labels())
+		}
+	}
+	for _, obj := range applied {
+		if _, ok := current[obj]; ok {
+			c.ObjectLastApplied.With(obj.labels()).SetToCurrentTime()
+		}
+	}
+	c.objectsLastApplied = current
+}
+
+// FailingObjectCount is the number of Kubernetes objects of a kind in a namespace failing for reasons of a category.
+type FailingObjectCount struct {
+	Kind      string
+	Namespace string
+	Reason    string
+	Count     int
+}
+
+// RecordTranslationFailingObjects records the number of Kubernetes objects that failed translation in the most recent
+// translation. Objects that are not failing anymore are removed from the metric.
+func (c *CtrlFuncMetrics) RecordTranslationFailingObjects(counts []FailingObjectCount) {
+	recordFailingObjects(c.TranslationFailingObjects, counts)
+}
+
+// RecordPushFailingObjects records the number of Kubernetes objects whose configuration was rejected by Kong in the
+// most recent configuration push. Objects that are not failing anymore are removed from the metric.
+func (c *CtrlFuncMetrics) RecordPushFailingObjects(counts []FailingObjectCount) {
+	recordFailingObjects(c.PushFailingObjects, counts)
+}
+
+func recordFailingObjects(gauge *prometheus.GaugeVec, counts []FailingObjectCount) {
+	gauge.Reset()
+	for _, fc := range limitFailureNamespaces(counts) {
+		gauge.With(prometheus.Labels{
+			KindKey:      fc.Kind,
+			NamespaceKey: fc.Namespace,
+			ReasonKey:    fc.Reason,
+		}).Add(float64(fc.Count))
+	}
+}
+
+// limitFailureNamespaces keeps counts of failing objects in up to MaxFailureNamespaces namespaces with the most
+// failing objects and moves the rest under NamespaceOther.
+func limitFailureNamespaces(counts []FailingObjectCount) []FailingObjectCount {
+	perNamespace := make(map[string]int)
+	for _, fc := range counts {
+		perNamespace[fc.Namespace] += fc.Count
+	}
+	if len(perNamespace) <= MaxFailureNamespaces {
+		return counts
+	}
+
+	namespaces := make([]string, 0, len(perNamespace))
+	for namespace := range perNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		if perNamespace[namespaces[i]] != perNamespace[namespaces[j]] {
+			return perNamespace[namespaces[i]] > perNamespace[namespaces[j]]
+		}
+		return namespaces[i] < namespaces[j]
+	})
+	kept := make(map[string]struct{}, MaxFailureNamespaces)
+	for _, namespace := range namespaces[:MaxFailureNamespaces] {
+		kept[namespace] = struct{}{}
+	}
+
+	limited := make([]FailingObjectCount, 0, len(counts))
+	for _, fc := range counts {
+		if _, ok := kept[fc.Namespace]; !ok {
+			fc.Namespace = NamespaceOther
+		}
+		limited = append(limited, fc)
+	}
+	return limited
+}
+
 // RecordTranslationSuccess records a successful configuration translation.
 func (c *CtrlFuncMetrics) RecordTranslationSuccess() {
 	c.TranslationCount.With(prometheus.Labels{
@@ -223,6 +894,21 @@ func (c *CtrlFuncMetrics) RecordTranslationBrokenResources(count int) {
 	c.TranslationBrokenResources.Set(float64(count))
 }
 
+// RecordDryRunConfigDiff records the number of entities that differ between the configuration generated
+// in dry-run mode and the configuration loaded by the dataplane.
+func (c *CtrlFuncMetrics) RecordDryRunConfigDiff(dataplane string, added, removed, modified int) {
+	for change, count := range map[string]int{
+		ChangeAdded:    added,
+		ChangeRemoved:  removed,
+		ChangeModified: modified,
+	} {
+		c.DryRunConfigDiff.With(prometheus.Labels{
+			DataplaneKey: dataplane,
+			ChangeKey:    change,
+		}).Set(float64(count))
+	}
+}
+
 type recordOption func(prometheus.Labels) prometheus.Labels
 
 func withError(err error) recordOption {
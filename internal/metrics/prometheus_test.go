@@ -10,6 +10,7 @@ import (
 
 	deckutils "github.com/kong/deck/utils"
 	"github.com/kong/go-kong/kong"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckerrors"
@@ -55,6 +56,155 @@ func TestRecordTranslation(t *testing.T) {
 	})
 }
 
+func TestRecordDryRunConfigDiff(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	require.NotPanics(t, func() {
+		m.RecordDryRunConfigDiff("https://10.0.0.1:8080", 1, 2, 3)
+	})
+}
+
+func TestRecordConfigGeneration(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	require.NotPanics(t, func() {
+		m.RecordConfigGeneration(GenerationTargetGateways, time.Millisecond)
+	})
+}
+
+func TestRecordGatewaysConfigInSync(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	require.NotPanics(t, func() {
+		m.RecordGatewaysConfigInSync(map[string]bool{
+			"https://10.0.0.1:8080": true,
+			"https://10.0.0.2:8080": false,
+		})
+	})
+}
+
+func TestRecordGatewaysInfo(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	require.NotPanics(t, func() {
+		m.RecordGatewaysInfo(map[string]string{
+			"https://10.0.0.1:8080": "kong/admin",
+			"https://10.0.0.2:8080": "",
+		})
+	})
+}
+
+func TestRecordGatewaysCircuitBreakerOpen(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	require.NotPanics(t, func() {
+		m.RecordGatewaysCircuitBreakerOpen(map[string]bool{
+			"https://10.0.0.1:8080": true,
+			"https://10.0.0.2:8080": false,
+		})
+	})
+}
+
+func TestRecordGatewaysVersion(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	require.NotPanics(t, func() {
+		m.RecordGatewaysVersion(map[string]string{
+			"https://10.0.0.1:8080": "3.4.1",
+			"https://10.0.0.2:8080": "3.5.0",
+		})
+	})
+	require.Equal(t, 2.0, testutil.ToFloat64(m.GatewayDistinctVersions))
+}
+
+func TestRecordConfigConvergenceAndDrift(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	require.NotPanics(t, func() {
+		m.RecordConfigConvergence("https://10.0.0.1:8080", time.Millisecond, true)
+		m.RecordConfigConvergence("https://10.0.0.2:8080", time.Second, false)
+		m.RecordConfigDrift("https://10.0.0.1:8080")
+		m.RecordConfigDriftEntities("https://10.0.0.1:8080", 1, 2, 3)
+	})
+}
+
+func TestRecordChangeToPush(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	require.NotPanics(t, func() {
+		m.RecordChangeToPush(time.Second)
+	})
+}
+
+func TestRecordConfigComposition(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	require.NotPanics(t, func() {
+		m.RecordConfigEntities([]EntityCount{
+			{EntityType: "services", Namespace: "default", SourceKind: "Ingress", Count: 2},
+			{EntityType: "certificates", Count: 1},
+		})
+		m.RecordConfigSize(GenerationTargetGateways, 1024)
+		m.RecordTranslationPhase("FillPlugins", time.Millisecond)
+		m.RecordStoreObjects(map[string]int{"Ingress": 2, "Service": 3})
+	})
+	require.Equal(t, 2, testutil.CollectAndCount(m.ConfigEntities))
+
+	m.RecordConfigEntities([]EntityCount{{EntityType: "services", Namespace: "default", SourceKind: "Ingress", Count: 1}})
+	require.Equal(t, 1, testutil.CollectAndCount(m.ConfigEntities), "entities not present anymore should be removed")
+	require.Equal(t, 1024.0, testutil.ToFloat64(m.ConfigSize))
+}
+
+func TestRecordObjectsApplied(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	deleted := ObjectRef{Kind: "Ingress", Namespace: "default", Name: "deleted"}
+	failing := ObjectRef{Kind: "Ingress", Namespace: "default", Name: "failing"}
+	configured := ObjectRef{Kind: "HTTPRoute", Namespace: "default", Name: "configured"}
+
+	m.RecordObjectsApplied([]ObjectRef{deleted, failing, configured}, nil)
+	require.Equal(t, 3, testutil.CollectAndCount(m.ObjectLastApplied))
+
+	m.RecordObjectsApplied([]ObjectRef{configured}, []ObjectRef{failing})
+	require.Equal(t, 2, testutil.CollectAndCount(m.ObjectLastApplied), "deleted object should be removed")
+	require.Positive(t, testutil.ToFloat64(m.ObjectLastApplied.With(failing.labels())))
+
+	m.RecordObjectsApplied(nil, []ObjectRef{{Kind: "Ingress", Namespace: "default", Name: "never-applied"}})
+	require.Equal(t, 0, testutil.CollectAndCount(m.ObjectLastApplied), "objects never applied should not be recorded")
+
+	t.Run("objects beyond the limit are not recorded", func(t *testing.T) {
+		applied := []ObjectRef{configured}
+		m.RecordObjectsApplied(applied, nil)
+		for i := 0; i < MaxObjectsLastApplied+10; i++ {
+			applied = append(applied, ObjectRef{Kind: "Ingress", Namespace: "default", Name: fmt.Sprintf("ingress-%04d", i)})
+		}
+		m.RecordObjectsApplied(applied, nil)
+		require.Equal(t, MaxObjectsLastApplied, testutil.CollectAndCount(m.ObjectLastApplied))
+		require.Positive(t, testutil.ToFloat64(m.ObjectLastApplied.With(configured.labels())),
+			"object recorded before should be kept")
+		require.Positive(t, testutil.ToFloat64(m.ObjectLastApplied.With(applied[1].labels())))
+
+		m.RecordObjectsApplied(applied[1:], nil)
+		require.Equal(t, MaxObjectsLastApplied, testutil.CollectAndCount(m.ObjectLastApplied),
+			"removed object should make room for a new one")
+	})
+}
+
+func TestRecordFailingObjects(t *testing.T) {
+	m := NewCtrlFuncMetrics()
+	m.RecordTranslationFailingObjects([]FailingObjectCount{
+		{Kind: "Ingress", Namespace: "team-a", Reason: "invalid", Count: 2},
+		{Kind: "HTTPRoute", Namespace: "team-b", Reason: "missing_reference", Count: 1},
+	})
+	require.Equal(t, 2, testutil.CollectAndCount(m.TranslationFailingObjects))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.TranslationFailingObjects.WithLabelValues("Ingress", "team-a", "invalid")))
+
+	m.RecordTranslationFailingObjects(nil)
+	require.Equal(t, 0, testutil.CollectAndCount(m.TranslationFailingObjects), "objects not failing anymore should be removed")
+
+	t.Run("namespaces beyond the limit are counted together", func(t *testing.T) {
+		counts := []FailingObjectCount{{Kind: "Ingress", Namespace: "most-failing", Reason: "invalid", Count: 10}}
+		for i := 0; i < MaxFailureNamespaces+10; i++ {
+			counts = append(counts, FailingObjectCount{Kind: "Ingress", Namespace: fmt.Sprintf("ns-%03d", i), Reason: "invalid", Count: 1})
+		}
+		m.RecordPushFailingObjects(counts)
+
+		require.Equal(t, MaxFailureNamespaces+1, testutil.CollectAndCount(m.PushFailingObjects))
+		require.Equal(t, 10.0, testutil.ToFloat64(m.PushFailingObjects.WithLabelValues("Ingress", "most-failing", "invalid")))
+		require.Equal(t, 11.0, testutil.ToFloat64(m.PushFailingObjects.WithLabelValues("Ingress", NamespaceOther, "invalid")))
+	})
+}
+
 func TestPushFailureReason(t *testing.T) {
 	apiConflictErr := kong.NewAPIError(http.StatusConflict, "conflict api error")
 	networkErr := net.UnknownNetworkError("network error")
@@ -132,3 +282,11 @@ func TestPushFailureReason(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordTransportReload(t *testing.T) {
+	m := NewAdminAPITransportMetrics()
+	require.NotPanics(t, func() {
+		m.RecordTransportReload(nil)
+		m.RecordTransportReload(errors.New("key does not match certificate"))
+	})
+}
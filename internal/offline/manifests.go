@@ -0,0 +1,141 @@
+// Package offline implements processing of Kubernetes manifests without access to a Kubernetes cluster
+// or a Kong Gateway (e.g. to preview the translated configuration in CI).
+package offline
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// StdinPath is a special path that makes ReadManifests read from the provided stdin reader.
+const StdinPath = "-"
+
+// Manifest is a single Kubernetes object manifest read from a file.
+type Manifest struct {
+	// Source is the path of the file the manifest was read from.
+	Source string
+	// GVK is the GroupVersionKind of the object.
+	GVK schema.GroupVersionKind
+	// Namespace is the namespace of the object.
+	Namespace string
+	// Name is the name of the object.
+	Name string
+	// YAML is the raw YAML document of the object.
+	YAML []byte
+}
+
+// String returns a human-readable reference to the manifest's object.
+func (m Manifest) String() string {
+	if m.Namespace == "" {
+		return fmt.Sprintf("%s %s", m.GVK.Kind, m.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", m.GVK.Kind, m.Namespace, m.Name)
+}
+
+// ReadManifests reads all YAML documents from the files under paths. StdinPath reads from stdin.
+// When no paths are provided, stdin is read. Empty documents are skipped, and List kinds are expanded
+// into their items.
+func ReadManifests(stdin io.Reader, paths ...string) ([]Manifest, error) {
+	if len(paths) == 0 {
+		paths = []string{StdinPath}
+	}
+
+	var manifests []Manifest
+	for _, path := range paths {
+		var (
+			fileManifests []Manifest
+			err           error
+		)
+		if path == StdinPath {
+			fileManifests, err = readManifestsFrom("stdin", stdin)
+		} else {
+			fileManifests, err = readManifestsFromFile(path)
+		}
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, fileManifests...)
+	}
+	return manifests, nil
+}
+
+func readManifestsFromFile(path string) ([]Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+	return readManifestsFrom(path, f)
+}
+
+func readManifestsFrom(source string, r io.Reader) ([]Manifest, error) {
+	var (
+		manifests []Manifest
+		reader    = yamlutil.NewYAMLReader(bufio.NewReader(r))
+	)
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return manifests, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read YAML document from %s: %w", source, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML document from %s: %w", source, err)
+		}
+		if len(obj.Object) == 0 {
+			// Document containing only comments.
+			continue
+		}
+
+		if obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s from %s: %w", obj.GetKind(), source, err)
+			}
+			for i := range list.Items {
+				m, err := manifestFromUnstructured(source, &list.Items[i])
+				if err != nil {
+					return nil, err
+				}
+				manifests = append(manifests, m)
+			}
+			continue
+		}
+
+		m, err := manifestFromUnstructured(source, obj)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, m)
+	}
+}
+
+func manifestFromUnstructured(source string, obj *unstructured.Unstructured) (Manifest, error) {
+	b, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to marshal %s %s from %s: %w", obj.GetKind(), obj.GetName(), source, err)
+	}
+	return Manifest{
+		Source:    source,
+		GVK:       obj.GroupVersionKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		YAML:      b,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package offline_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/offline"
+)
+
+func TestReadManifests(t *testing.T) {
+	const fileContent = `
+# A comment-only document should be skipped.
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: svc-1
+    namespace: default
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: svc-2
+    namespace: default
+---
+---
+apiVersion: networking.k8s.io/v1
+kind: IngressClass
+metadata:
+  name: kong
+`
+	const stdinContent = `
+apiVersion: configuration.konghq.com/v1
+kind: KongPlugin
+metadata:
+  name: plugin
+  namespace: default
+plugin: cors
+`
+	path := filepath.Join(t.TempDir(), "manifests.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(fileContent), 0o600))
+
+	manifests, err := offline.ReadManifests(strings.NewReader(stdinContent), path, offline.StdinPath)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(manifests))
+	for _, m := range manifests {
+		names = append(names, m.String())
+	}
+	assert.Equal(t, []string{
+		"Service default/svc-1",
+		"Service default/svc-2",
+		"IngressClass kong",
+		"KongPlugin default/plugin",
+	}, names)
+	assert.Equal(t, path, manifests[0].Source)
+	assert.Equal(t, "stdin", manifests[3].Source)
+
+	t.Log("reading from a non-existent file should fail")
+	_, err = offline.ReadManifests(nil, filepath.Join(t.TempDir(), "non-existent.yaml"))
+	require.Error(t, err)
+}
@@ -0,0 +1,228 @@
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// PluginSchemas is a set of Kong plugins' schemas keyed by plugin name. Each schema is in the format returned by
+// Kong's `GET /schemas/plugins/{name}` endpoint.
+type PluginSchemas map[string]map[string]any
+
+// LoadPluginSchemas loads plugin schemas from a directory containing "<plugin-name>.json" files, each holding
+// the response of Kong's `GET /schemas/plugins/<plugin-name>` endpoint (e.g. exported from a Kong Gateway with
+// `curl http://localhost:8001/schemas/plugins/rate-limiting > rate-limiting.json`).
+func LoadPluginSchemas(dir string) (PluginSchemas, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no plugin schemas (*.json files) found in %s", dir)
+	}
+
+	schemas := make(PluginSchemas, len(paths))
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read plugin schema %s: %w", path, err)
+		}
+		var schema map[string]any
+		if err := json.Unmarshal(b, &schema); err != nil {
+			return nil, fmt.Errorf("failed to parse plugin schema %s: %w", path, err)
+		}
+		schemas[strings.TrimSuffix(filepath.Base(path), ".json")] = schema
+	}
+	return schemas, nil
+}
+
+// ValidatePlugin validates the plugin's configuration against the plugin's schema. It returns a list of problems
+// found, or an error if there's no schema for the plugin.
+func (s PluginSchemas) ValidatePlugin(plugin kong.Plugin) ([]string, error) {
+	if plugin.Name == nil {
+		return nil, fmt.Errorf("plugin name is empty")
+	}
+	schema, ok := s[*plugin.Name]
+	if !ok {
+		return nil, fmt.Errorf("schema for plugin %q not found", *plugin.Name)
+	}
+
+	configField, ok := lookupSchemaField(schema["fields"], "config")
+	if !ok {
+		return nil, fmt.Errorf("schema for plugin %q has no config field", *plugin.Name)
+	}
+
+	// Round-trip the config through JSON to get the same representation as the schema (e.g. float64 numbers).
+	var config any = map[string]any{}
+	if plugin.Config != nil {
+		b, err := json.Marshal(plugin.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal plugin config: %w", err)
+		}
+		if err := json.Unmarshal(b, &config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal plugin config: %w", err)
+		}
+	}
+
+	var problems []string
+	validateSchemaValue("config", config, configField, &problems)
+	sort.Strings(problems)
+	return problems, nil
+}
+
+// lookupSchemaField finds a field by name in a Kong schema fields list ([{"name": {...}}, ...]).
+func lookupSchemaField(fields any, name string) (map[string]any, bool) {
+	list, _ := fields.([]any)
+	for _, f := range list {
+		entry, _ := f.(map[string]any)
+		if def, ok := entry[name].(map[string]any); ok {
+			return def, true
+		}
+	}
+	return nil, false
+}
+
+// validateSchemaValue validates value against a Kong schema field definition, appending found problems.
+func validateSchemaValue(path string, value any, field map[string]any, problems *[]string) {
+	addProblem := func(format string, args ...any) {
+		*problems = append(*problems, fmt.Sprintf("%s: %s", path, fmt.Sprintf(format, args...)))
+	}
+
+	if value == nil {
+		return
+	}
+
+	fieldType, _ := field["type"].(string)
+	switch fieldType {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			addProblem("expected a string")
+			return
+		}
+		if minLen, ok := field["len_min"].(float64); ok && float64(len(s)) < minLen {
+			addProblem("length must be at least %v", minLen)
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok {
+			addProblem("expected a number")
+			return
+		}
+		if fieldType == "integer" && n != math.Trunc(n) {
+			addProblem("expected an integer")
+		}
+		if between, ok := field["between"].([]any); ok && len(between) == 2 {
+			minValue, _ := between[0].(float64)
+			maxValue, _ := between[1].(float64)
+			if n < minValue || n > maxValue {
+				addProblem("value should be between %v and %v", minValue, maxValue)
+			}
+		}
+		if gt, ok := field["gt"].(float64); ok && n <= gt {
+			addProblem("value must be greater than %v", gt)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			addProblem("expected a boolean")
+			return
+		}
+	case "array", "set":
+		list, ok := value.([]any)
+		if !ok {
+			addProblem("expected an array")
+			return
+		}
+		if elements, ok := field["elements"].(map[string]any); ok {
+			for i, element := range list {
+				validateSchemaValue(fmt.Sprintf("%s[%d]", path, i), element, elements, problems)
+			}
+		}
+		return
+	case "map":
+		m, ok := value.(map[string]any)
+		if !ok {
+			addProblem("expected a map")
+			return
+		}
+		if values, ok := field["values"].(map[string]any); ok {
+			for k, v := range m {
+				validateSchemaValue(fmt.Sprintf("%s.%s", path, k), v, values, problems)
+			}
+		}
+		return
+	case "record":
+		validateSchemaRecord(path, value, field, problems)
+		return
+	}
+
+	if oneOf, ok := field["one_of"].([]any); ok && len(oneOf) > 0 {
+		for _, allowed := range oneOf {
+			if allowed == value {
+				return
+			}
+		}
+		addProblem("expected one of: %s", joinValues(oneOf))
+	}
+}
+
+// validateSchemaRecord validates value against a Kong schema record definition: it verifies that there are no
+// unknown fields, required fields without defaults are set, and each of the fields is valid.
+func validateSchemaRecord(path string, value any, field map[string]any, problems *[]string) {
+	record, ok := value.(map[string]any)
+	if !ok {
+		*problems = append(*problems, fmt.Sprintf("%s: expected a record", path))
+		return
+	}
+
+	fields, _ := field["fields"].([]any)
+	known := map[string]struct{}{}
+	for _, f := range fields {
+		entry, _ := f.(map[string]any)
+		for name, def := range entry {
+			known[name] = struct{}{}
+			fieldDef, _ := def.(map[string]any)
+			fieldPath := path + "." + name
+			v, set := record[name]
+			if !set || v == nil {
+				required, _ := fieldDef["required"].(bool)
+				_, hasDefault := fieldDef["default"]
+				if required && !hasDefault {
+					*problems = append(*problems, fmt.Sprintf("%s: required field missing", fieldPath))
+				}
+				continue
+			}
+			validateSchemaValue(fieldPath, v, fieldDef, problems)
+		}
+	}
+
+	// Shorthand fields are accepted by Kong as aliases of other fields (e.g. for backwards compatibility).
+	shorthands, _ := field["shorthand_fields"].([]any)
+	for _, f := range shorthands {
+		entry, _ := f.(map[string]any)
+		for name := range entry {
+			known[name] = struct{}{}
+		}
+	}
+
+	for name := range record {
+		if _, ok := known[name]; !ok {
+			*problems = append(*problems, fmt.Sprintf("%s.%s: unknown field", path, name))
+		}
+	}
+}
+
+func joinValues(values []any) string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, fmt.Sprint(v))
+	}
+	return strings.Join(out, ", ")
+}
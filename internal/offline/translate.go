@@ -0,0 +1,89 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/kong/deck/file"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/capabilities"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/deckgen"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/failures"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/featuregates"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/store"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/versions"
+)
+
+// TranslateOptions configures the offline translation.
+type TranslateOptions struct {
+	// IngressClass is the ingress class objects have to match to be translated.
+	IngressClass string
+	// FeatureGates are the feature gates the translation should be performed with.
+	FeatureGates featuregates.FeatureGates
+	// KongVersion is the version of Kong Gateway the configuration is generated for.
+	KongVersion semver.Version
+	// RouterFlavor is the router flavor of Kong Gateway the configuration is generated for.
+	RouterFlavor string
+	// SelectorTags are the decK select tags recorded in the generated configuration's _info section.
+	SelectorTags []string
+}
+
+// TranslateResult is the result of the offline translation.
+type TranslateResult struct {
+	// Content is the generated decK configuration.
+	Content *file.Content
+	// TranslationFailures are the failures that occurred during translation.
+	TranslationFailures []failures.ResourceFailure
+	// SkippedManifests are the manifests of object kinds that are not relevant for translation.
+	SkippedManifests []Manifest
+}
+
+// Translate translates the Kubernetes objects from manifests into a Kong configuration the same way the controller
+// does it, but without connecting to a Kubernetes cluster or a Kong Gateway. Plugins' configurations are not filled
+// with defaults from their schemas as these can only be retrieved from a Kong Gateway.
+func Translate(ctx context.Context, logger logr.Logger, manifests []Manifest, opts TranslateOptions) (TranslateResult, error) {
+	if opts.KongVersion.LT(versions.KICv3VersionCutoff) {
+		return TranslateResult{}, fmt.Errorf("kong version %s is not supported, minimum supported version is %s",
+			opts.KongVersion, versions.KICv3VersionCutoff)
+	}
+
+	var (
+		result    TranslateResult
+		documents [][]byte
+	)
+	for _, m := range manifests {
+		if !store.IsObjectKindSupported(m.GVK) {
+			result.SkippedManifests = append(result.SkippedManifests, m)
+			continue
+		}
+		documents = append(documents, m.YAML)
+	}
+
+	cacheStores, err := store.NewCacheStoresFromObjYAML(documents...)
+	if err != nil {
+		return TranslateResult{}, fmt.Errorf("failed to load objects into the store: %w", err)
+	}
+
+	gatewayCapabilities := capabilities.NewRegistry(capabilities.Gateway{
+		Version:      opts.KongVersion,
+		RouterFlavor: opts.RouterFlavor,
+	})
+	featureFlags := parser.NewFeatureFlags(logger, opts.FeatureGates, gatewayCapabilities, false)
+	p, err := parser.NewParser(logger, store.New(cacheStores, opts.IngressClass, logger), featureFlags)
+	if err != nil {
+		return TranslateResult{}, fmt.Errorf("failed to create parser: %w", err)
+	}
+	p.InjectCapabilities(gatewayCapabilities)
+
+	parsingResult := p.BuildKongConfig(ctx)
+	result.TranslationFailures = parsingResult.TranslationFailures
+	result.Content = deckgen.ToDeckContent(ctx, logger, parsingResult.KongState, deckgen.GenerateDeckContentParams{
+		SelectorTags:     opts.SelectorTags,
+		ExpressionRoutes: featureFlags.ExpressionRoutes,
+		PluginSchemas:    deckgen.EmptyPluginSchemaStore{},
+	})
+	return result, nil
+}
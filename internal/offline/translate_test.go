@@ -0,0 +1,86 @@
+package offline_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/featuregates"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/offline"
+)
+
+const translateTestManifests = `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: foo
+  namespace: foo-namespace
+spec:
+  ingressClassName: kong
+  rules:
+  - host: example.com
+    http:
+      paths:
+      - path: /foo
+        pathType: Prefix
+        backend:
+          service:
+            name: foo-svc
+            port:
+              number: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: foo-svc
+  namespace: foo-namespace
+spec:
+  ports:
+  - port: 80
+---
+apiVersion: configuration.konghq.com/v1
+kind: KongConsumer
+metadata:
+  name: consumer-without-username
+  namespace: foo-namespace
+  annotations:
+    kubernetes.io/ingress.class: kong
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: foo
+  namespace: foo-namespace
+`
+
+func TestTranslate(t *testing.T) {
+	manifests, err := offline.ReadManifests(strings.NewReader(translateTestManifests))
+	require.NoError(t, err)
+
+	opts := offline.TranslateOptions{
+		IngressClass: "kong",
+		FeatureGates: featuregates.GetFeatureGatesDefaults(),
+		KongVersion:  semver.MustParse("3.4.1"),
+		RouterFlavor: "traditional_compatible",
+	}
+	result, err := offline.Translate(context.Background(), logr.Discard(), manifests, opts)
+	require.NoError(t, err)
+
+	require.Len(t, result.SkippedManifests, 1)
+	assert.Equal(t, "Deployment foo-namespace/foo", result.SkippedManifests[0].String())
+
+	require.Len(t, result.Content.Services, 1)
+	assert.Equal(t, "foo-namespace.foo-svc.80", *result.Content.Services[0].Name)
+	require.Len(t, result.TranslationFailures, 1, "expected a failure for the KongConsumer without username")
+	assert.Equal(t, "consumer-without-username", result.TranslationFailures[0].CausingObjects()[0].GetName())
+
+	t.Log("translating for an unsupported Kong version should fail")
+	opts.KongVersion = semver.MustParse("3.3.0")
+	_, err = offline.Translate(context.Background(), logr.Discard(), manifests, opts)
+	require.Error(t, err)
+}
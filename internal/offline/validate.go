@@ -0,0 +1,262 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-kong/kong"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/admission"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/capabilities"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/dataplane/parser/atc"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/gatewayapi"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/featuregates"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/scheme"
+	kongv1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1"
+	kongv1beta1 "github.com/kong/kubernetes-ingress-controller/v2/pkg/apis/configuration/v1beta1"
+)
+
+// ValidateOptions configures the offline validation.
+type ValidateOptions struct {
+	// IngressClass is the ingress class objects have to match to be validated.
+	IngressClass string
+	// FeatureGates are the feature gates the validation should be performed with.
+	FeatureGates featuregates.FeatureGates
+	// RouterFlavor is the router flavor of Kong Gateway routes are validated for.
+	RouterFlavor string
+	// PluginSchemas are the schemas plugins' configurations are validated against. When nil, plugins'
+	// configurations are not validated against schemas.
+	PluginSchemas PluginSchemas
+}
+
+// ValidationProblem is a problem found in a manifest during the offline validation.
+type ValidationProblem struct {
+	// Manifest is the manifest of the invalid object.
+	Manifest Manifest
+	// Message describes the problem.
+	Message string
+}
+
+// ValidateResult is the result of the offline validation.
+type ValidateResult struct {
+	// Problems are the problems found in the manifests.
+	Problems []ValidationProblem
+	// Warnings are possible problems found in the manifests that can't be confirmed without Kong Gateway.
+	Warnings []ValidationProblem
+	// SkippedManifests are the manifests of object kinds unknown to the controller.
+	SkippedManifests []Manifest
+}
+
+// Validate runs the manifests through the same checks the admission webhook performs, but without connecting to
+// a Kubernetes cluster or a Kong Gateway. Objects referenced by the validated objects (e.g. consumers' credentials
+// Secrets or HTTPRoutes' Gateways and GatewayClasses) are looked up only among the manifests. Checks that in the
+// webhook are delegated to Kong Gateway are performed locally: routes' expressions are validated with
+// atc.ValidateExpression and plugins' configurations against opts.PluginSchemas. Regex paths are checked with Go's
+// regexp package, which doesn't support all the PCRE syntax Kong Gateway accepts (e.g. lookarounds and
+// backreferences), so paths it fails to compile are reported as warnings rather than problems.
+// Kong Gateway remains the source of truth, so it may still reject objects that pass this validation.
+func Validate(ctx context.Context, logger logr.Logger, manifests []Manifest, opts ValidateOptions) (ValidateResult, error) {
+	s, err := scheme.Get()
+	if err != nil {
+		return ValidateResult{}, fmt.Errorf("failed to create scheme: %w", err)
+	}
+	decoder := serializer.NewCodecFactory(s).UniversalDeserializer()
+
+	var (
+		result  ValidateResult
+		objects []client.Object
+		sources []Manifest
+	)
+	for _, m := range manifests {
+		obj, _, err := decoder.Decode(m.YAML, nil, nil)
+		if err != nil {
+			if runtime.IsNotRegisteredError(err) {
+				result.SkippedManifests = append(result.SkippedManifests, m)
+				continue
+			}
+			result.Problems = append(result.Problems, ValidationProblem{
+				Manifest: m,
+				Message:  fmt.Sprintf("failed to decode manifest: %s", err),
+			})
+			continue
+		}
+		if secret, ok := obj.(*corev1.Secret); ok {
+			mergeSecretStringData(secret)
+		}
+		clientObj, ok := obj.(client.Object)
+		if !ok {
+			result.SkippedManifests = append(result.SkippedManifests, m)
+			continue
+		}
+		objects = append(objects, clientObj)
+		sources = append(sources, m)
+	}
+
+	// The validator looks up referenced objects using a client. The objects from manifests are served by an in-memory
+	// client as there's no Kubernetes cluster to connect to.
+	managerClient := fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build()
+	routeService := &offlineRouteService{}
+	validator := admission.NewKongHTTPValidator(
+		logger,
+		managerClient,
+		opts.IngressClass,
+		offlineAdminAPIServicesProvider{pluginSchemas: opts.PluginSchemas, routeService: routeService},
+		parser.NewFeatureFlags(
+			logger,
+			opts.FeatureGates,
+			capabilities.NewRegistry(capabilities.Gateway{RouterFlavor: opts.RouterFlavor}),
+			false,
+		),
+	)
+
+	for i, obj := range objects {
+		routeService.warnings = nil
+		ok, msg, err := validateObject(ctx, validator, obj)
+		for _, w := range routeService.warnings {
+			result.Warnings = append(result.Warnings, ValidationProblem{Manifest: sources[i], Message: w})
+		}
+		if ok && err == nil {
+			continue
+		}
+		if err != nil {
+			if msg == "" {
+				msg = err.Error()
+			} else {
+				msg = fmt.Sprintf("%s: %s", msg, err)
+			}
+		}
+		result.Problems = append(result.Problems, ValidationProblem{Manifest: sources[i], Message: msg})
+	}
+	return result, nil
+}
+
+// validateObject validates obj with the validator method matching its type. Objects of types the admission
+// webhook doesn't validate are considered valid.
+func validateObject(ctx context.Context, validator admission.KongValidator, obj client.Object) (bool, string, error) {
+	switch o := obj.(type) {
+	case *kongv1.KongConsumer:
+		return validator.ValidateConsumer(ctx, *o)
+	case *kongv1beta1.KongConsumerGroup:
+		return validator.ValidateConsumerGroup(ctx, *o)
+	case *kongv1.KongPlugin:
+		return validator.ValidatePlugin(ctx, *o)
+	case *kongv1.KongClusterPlugin:
+		return validator.ValidateClusterPlugin(ctx, *o)
+	case *corev1.Secret:
+		return validator.ValidateCredential(ctx, *o)
+	case *gatewayapi.Gateway:
+		return validator.ValidateGateway(ctx, *o)
+	case *gatewayapi.HTTPRoute:
+		return validator.ValidateHTTPRoute(ctx, *o)
+	case *netv1.Ingress:
+		return validator.ValidateIngress(ctx, *o)
+	default:
+		return true, "", nil
+	}
+}
+
+// mergeSecretStringData merges the secret's StringData into Data the same way the Kubernetes API server does
+// when the secret is written.
+func mergeSecretStringData(secret *corev1.Secret) {
+	if len(secret.StringData) == 0 {
+		return
+	}
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte, len(secret.StringData))
+	}
+	for k, v := range secret.StringData {
+		secret.Data[k] = []byte(v)
+	}
+	secret.StringData = nil
+}
+
+// offlineAdminAPIServicesProvider is an admission.AdminAPIServicesProvider providing services that perform
+// validation locally instead of calling Kong Gateway. Services checking existence of entities in Kong Gateway
+// are not provided, so the validator skips these checks.
+type offlineAdminAPIServicesProvider struct {
+	pluginSchemas PluginSchemas
+	routeService  *offlineRouteService
+}
+
+func (offlineAdminAPIServicesProvider) GetConsumersService() (kong.AbstractConsumerService, bool) {
+	return nil, false
+}
+
+func (p offlineAdminAPIServicesProvider) GetPluginsService() (kong.AbstractPluginService, bool) {
+	if p.pluginSchemas == nil {
+		return nil, false
+	}
+	return offlinePluginService{pluginSchemas: p.pluginSchemas}, true
+}
+
+func (offlineAdminAPIServicesProvider) GetConsumerGroupsService() (kong.AbstractConsumerGroupService, bool) {
+	return nil, false
+}
+
+func (offlineAdminAPIServicesProvider) GetInfoService() (kong.AbstractInfoService, bool) {
+	return nil, false
+}
+
+func (p offlineAdminAPIServicesProvider) GetRoutesService() (kong.AbstractRouteService, bool) {
+	return p.routeService, true
+}
+
+// offlinePluginService validates plugins against PluginSchemas. Only Validate is implemented, as it's the only
+// method used by the admission validator.
+type offlinePluginService struct {
+	kong.AbstractPluginService
+	pluginSchemas PluginSchemas
+}
+
+func (s offlinePluginService) Validate(_ context.Context, plugin *kong.Plugin) (bool, string, error) {
+	problems, err := s.pluginSchemas.ValidatePlugin(*plugin)
+	if err != nil {
+		return false, "", err
+	}
+	if len(problems) > 0 {
+		return false, strings.Join(problems, ", "), nil
+	}
+	return true, "", nil
+}
+
+// offlineRouteService validates routes' expressions and regex paths locally. Only Validate is implemented, as it's
+// the only method used by the admission validator.
+type offlineRouteService struct {
+	kong.AbstractRouteService
+	// warnings collect regex paths Go's regexp package fails to compile. Kong Gateway uses PCRE, so such paths
+	// may still be valid and don't make the route invalid.
+	warnings []string
+}
+
+func (s *offlineRouteService) Validate(_ context.Context, route *kong.Route) (bool, string, error) {
+	if route.Expression != nil {
+		if err := atc.ValidateExpression(*route.Expression); err != nil {
+			return false, fmt.Sprintf("invalid expression %q: %s", *route.Expression, err), nil
+		}
+		return true, "", nil
+	}
+
+	for _, path := range route.Paths {
+		if path == nil || !strings.HasPrefix(*path, "~") {
+			continue
+		}
+		if _, err := regexp.Compile(strings.TrimPrefix(*path, "~")); err != nil {
+			warning := fmt.Sprintf("regex path %q could not be verified, Kong Gateway may still accept it: %s", *path, err)
+			if !lo.Contains(s.warnings, warning) {
+				s.warnings = append(s.warnings, warning)
+			}
+		}
+	}
+	return true, "", nil
+}
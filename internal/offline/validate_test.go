@@ -0,0 +1,216 @@
+package offline_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/manager/featuregates"
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/offline"
+)
+
+const validateTestManifests = `
+apiVersion: configuration.konghq.com/v1
+kind: KongConsumer
+metadata:
+  name: valid-consumer
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+username: valid
+credentials:
+- valid-credential
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: valid-credential
+  namespace: default
+stringData:
+  kongCredType: key-auth
+  key: secret-key
+---
+apiVersion: configuration.konghq.com/v1
+kind: KongConsumer
+metadata:
+  name: consumer-without-username
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+---
+apiVersion: configuration.konghq.com/v1
+kind: KongConsumer
+metadata:
+  name: consumer-of-other-class
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: other
+---
+apiVersion: configuration.konghq.com/v1
+kind: KongConsumer
+metadata:
+  name: consumer-with-missing-credential
+  namespace: default
+  annotations:
+    kubernetes.io/ingress.class: kong
+username: missing-credential
+credentials:
+- non-existent
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: ingress-with-pcre-regex
+  namespace: default
+  annotations:
+    konghq.com/regex-prefix: "/~"
+spec:
+  ingressClassName: kong
+  rules:
+  - http:
+      paths:
+      - path: /~/foo/(?!bar)
+        pathType: ImplementationSpecific
+        backend:
+          service:
+            name: foo
+            port:
+              number: 80
+---
+apiVersion: configuration.konghq.com/v1
+kind: KongPlugin
+metadata:
+  name: valid-plugin
+  namespace: default
+plugin: rate-limiting
+config:
+  minute: 5
+---
+apiVersion: configuration.konghq.com/v1
+kind: KongPlugin
+metadata:
+  name: invalid-plugin
+  namespace: default
+plugin: rate-limiting
+config:
+  minute: five
+  unknown: true
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: unknown
+  namespace: default
+`
+
+const rateLimitingSchema = `{
+  "fields": [
+    {"consumer": {"type": "foreign", "reference": "consumers"}},
+    {"config": {
+      "type": "record",
+      "required": true,
+      "fields": [
+        {"minute": {"type": "number", "gt": 0}},
+        {"policy": {"type": "string", "default": "local", "one_of": ["local", "cluster", "redis"]}}
+      ]
+    }}
+  ]
+}`
+
+func TestValidate(t *testing.T) {
+	manifests, err := offline.ReadManifests(strings.NewReader(validateTestManifests))
+	require.NoError(t, err)
+
+	schemasDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(schemasDir, "rate-limiting.json"), []byte(rateLimitingSchema), 0o600))
+	schemas, err := offline.LoadPluginSchemas(schemasDir)
+	require.NoError(t, err)
+
+	result, err := offline.Validate(context.Background(), logr.Discard(), manifests, offline.ValidateOptions{
+		IngressClass:  "kong",
+		FeatureGates:  featuregates.GetFeatureGatesDefaults(),
+		RouterFlavor:  "traditional_compatible",
+		PluginSchemas: schemas,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.SkippedManifests, 1)
+	assert.Equal(t, "Unknown default/unknown", result.SkippedManifests[0].String())
+
+	problems := make(map[string]string, len(result.Problems))
+	for _, p := range result.Problems {
+		problems[p.Manifest.String()] = p.Message
+	}
+	require.Len(t, problems, 3, "unexpected problems: %v", problems)
+	assert.Contains(t, problems["KongConsumer default/consumer-without-username"], "username cannot be empty")
+	assert.Contains(t, problems["KongConsumer default/consumer-with-missing-credential"], "non-existent credentials secret")
+	assert.Contains(t, problems["KongPlugin default/invalid-plugin"], "config.minute: expected a number")
+	assert.Contains(t, problems["KongPlugin default/invalid-plugin"], "config.unknown: unknown field")
+
+	// Kong Gateway uses PCRE, so regex paths Go's regexp package can't compile are only warned about.
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, "Ingress default/ingress-with-pcre-regex", result.Warnings[0].Manifest.String())
+	assert.Contains(t, result.Warnings[0].Message, "could not be verified")
+}
+
+func TestPluginSchemasValidatePlugin(t *testing.T) {
+	schemasDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(schemasDir, "rate-limiting.json"), []byte(rateLimitingSchema), 0o600))
+	schemas, err := offline.LoadPluginSchemas(schemasDir)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name             string
+		pluginName       string
+		config           map[string]any
+		expectedProblems []string
+		expectedErr      bool
+	}{
+		{
+			name:       "valid config",
+			pluginName: "rate-limiting",
+			config:     map[string]any{"minute": 5, "policy": "redis"},
+		},
+		{
+			name:       "values violating constraints",
+			pluginName: "rate-limiting",
+			config:     map[string]any{"minute": 0, "policy": "memory"},
+			expectedProblems: []string{
+				"config.minute: value must be greater than 0",
+				"config.policy: expected one of: local, cluster, redis",
+			},
+		},
+		{
+			name:        "plugin without schema",
+			pluginName:  "cors",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			problems, err := schemas.ValidatePlugin(kong.Plugin{
+				Name:   kong.String(tc.pluginName),
+				Config: kong.Configuration(tc.config),
+			})
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedProblems, problems)
+		})
+	}
+
+	t.Log("loading schemas from a directory without schemas should fail")
+	_, err = offline.LoadPluginSchemas(t.TempDir())
+	require.Error(t, err)
+}
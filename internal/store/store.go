@@ -190,6 +190,13 @@ func NewCacheStoresFromObjYAML(objs ...[]byte) (c CacheStores, err error) {
 	return NewCacheStoresFromObjs(kobjs...)
 }
 
+// IsObjectKindSupported tells whether objects of the provided GroupVersionKind can be loaded into CacheStores
+// using NewCacheStoresFromObjYAML or NewCacheStoresFromObjs.
+func IsObjectKindSupported(gvk schema.GroupVersionKind) bool {
+	_, err := mkObjFromGVK(gvk)
+	return err == nil
+}
+
 // NewCacheStoresFromObjs provides a new CacheStores object given any number of Kubernetes
 // objects that should be pre-populated. This function will sort objects into the appropriate
 // sub-storage (e.g. IngressV1, TCPIngress, e.t.c.) but will produce an error if any of the
@@ -394,6 +401,38 @@ func (c CacheStores) Delete(obj runtime.Object) error {
 	}
 }
 
+// ObjectCounts returns the number of objects stored in the CacheStores by their kind.
+func (c CacheStores) ObjectCounts() map[string]int {
+	c.l.RLock()
+	defer c.l.RUnlock()
+
+	return map[string]int{
+		// Kubernetes Core API Support
+		"Ingress":       len(c.IngressV1.ListKeys()),
+		"IngressClass":  len(c.IngressClassV1.ListKeys()),
+		"Service":       len(c.Service.ListKeys()),
+		"Secret":        len(c.Secret.ListKeys()),
+		"EndpointSlice": len(c.EndpointSlice.ListKeys()),
+		// Kubernetes Gateway API Support
+		"HTTPRoute":      len(c.HTTPRoute.ListKeys()),
+		"UDPRoute":       len(c.UDPRoute.ListKeys()),
+		"TCPRoute":       len(c.TCPRoute.ListKeys()),
+		"TLSRoute":       len(c.TLSRoute.ListKeys()),
+		"GRPCRoute":      len(c.GRPCRoute.ListKeys()),
+		"ReferenceGrant": len(c.ReferenceGrant.ListKeys()),
+		"Gateway":        len(c.Gateway.ListKeys()),
+		// Kong API Support
+		"KongPlugin":             len(c.Plugin.ListKeys()),
+		"KongClusterPlugin":      len(c.ClusterPlugin.ListKeys()),
+		"KongConsumer":           len(c.Consumer.ListKeys()),
+		"KongConsumerGroup":      len(c.ConsumerGroup.ListKeys()),
+		"KongIngress":            len(c.KongIngress.ListKeys()),
+		"TCPIngress":             len(c.TCPIngress.ListKeys()),
+		"UDPIngress":             len(c.UDPIngress.ListKeys()),
+		"IngressClassParameters": len(c.IngressClassParametersV1alpha1.ListKeys()),
+	}
+}
+
 // New creates a new object store to be used in the ingress controller.
 func New(cs CacheStores, ingressClass string, logger logr.Logger) Storer {
 	return Store{
@@ -973,6 +1012,8 @@ func mkObjFromGVK(gvk schema.GroupVersionKind) (runtime.Object, error) {
 	// ----------------------------------------------------------------------------
 	case netv1.SchemeGroupVersion.WithKind("Ingress"):
 		return &netv1.Ingress{}, nil
+	case netv1.SchemeGroupVersion.WithKind("IngressClass"):
+		return &netv1.IngressClass{}, nil
 	case corev1.SchemeGroupVersion.WithKind("Service"):
 		return &corev1.Service{}, nil
 	case corev1.SchemeGroupVersion.WithKind("Secret"):
@@ -997,6 +1038,8 @@ func mkObjFromGVK(gvk schema.GroupVersionKind) (runtime.Object, error) {
 		return &gatewayapi.TLSRoute{}, nil
 	case gatewayv1beta1.SchemeGroupVersion.WithKind("ReferenceGrant"):
 		return &gatewayapi.ReferenceGrant{}, nil
+	case gatewayv1beta1.SchemeGroupVersion.WithKind("Gateway"):
+		return &gatewayapi.Gateway{}, nil
 	// ----------------------------------------------------------------------------
 	// Kong APIs
 	// ----------------------------------------------------------------------------
@@ -89,6 +89,19 @@ spec:
 	require.NotEmpty(t, gotIng.TypeMeta.Kind)
 }
 
+func TestCacheStoresObjectCounts(t *testing.T) {
+	cs := NewCacheStores()
+	require.NoError(t, cs.Add(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "svc-1"}}))
+	require.NoError(t, cs.Add(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "svc-1"}}))
+	require.NoError(t, cs.Add(&netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ingress"}}))
+
+	counts := cs.ObjectCounts()
+	require.Equal(t, 2, counts["Service"])
+	require.Equal(t, 1, counts["Ingress"])
+	require.Equal(t, 0, counts["HTTPRoute"])
+	require.Equal(t, 0, counts["KongPlugin"])
+}
+
 func TestGetIngressClassHandling(t *testing.T) {
 	tests := []struct {
 		name string
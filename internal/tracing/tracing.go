@@ -0,0 +1,164 @@
+// Package tracing instruments the pipeline from reconciling Kubernetes objects to pushing the configuration
+// to Kong with OpenTelemetry spans, and sets up their export via OTLP.
+//
+// Spans are created with the global tracer provider that is a no-op until SetupTracerProvider is called,
+// so instrumented code doesn't need to check whether tracing is enabled.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// TracerName is the name of the tracer spans of the controller are created with.
+	TracerName = "github.com/kong/kubernetes-ingress-controller"
+
+	// ServiceName is the name of the service the controller reports in exported spans.
+	ServiceName = "kong-ingress-controller"
+
+	// DefaultSamplingRatio is the default ratio of traces that are sampled.
+	DefaultSamplingRatio = 1.0
+)
+
+// Attribute keys of spans created by the controller.
+const (
+	AttributeKeyKind             = attribute.Key("k8s.object.kind")
+	AttributeKeyNamespace        = attribute.Key("k8s.object.namespace")
+	AttributeKeyName             = attribute.Key("k8s.object.name")
+	AttributeKeyGatewayURL       = attribute.Key("kong.gateway.url")
+	AttributeKeyKonnect          = attribute.Key("kong.konnect")
+	AttributeKeyUpdateStrategy   = attribute.Key("kong.update_strategy")
+	AttributeKeyConfigHash       = attribute.Key("kong.config_hash")
+	AttributeKeyUpdateSkipped    = attribute.Key("kong.update_skipped")
+	AttributeKeyGenerationTarget = attribute.Key("kong.generation_target")
+)
+
+// Tracer returns the tracer spans of the controller are created with.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// StartSpan starts a span with the given name and attributes as a child of the span in ctx, if any.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err (when not nil) as the status of the span and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartReconcileSpan starts a span of reconciling the kind of Kubernetes object identified by nn.
+func StartReconcileSpan(ctx context.Context, kind string, nn k8stypes.NamespacedName) (context.Context, trace.Span) {
+	return StartSpan(ctx, kind+".Reconcile",
+		AttributeKeyKind.String(kind),
+		AttributeKeyNamespace.String(nn.Namespace),
+		AttributeKeyName.String(nn.Name),
+	)
+}
+
+// NewTransport wraps rt, so that requests sent with it are recorded as client spans and carry the trace
+// context in their headers (as defined by W3C Trace Context), allowing Kong to correlate its own traces.
+func NewTransport(rt http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(rt,
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return "Admin API " + r.Method
+		}),
+	)
+}
+
+// Config configures the export of spans.
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP endpoint spans are exported to (e.g. http://otel-collector:4318).
+	// When its path is empty, the default /v1/traces is used. Plain HTTP endpoints are reached without TLS.
+	Endpoint string
+	// SamplingRatio is the ratio of traces that are sampled, unless their parent decided otherwise.
+	SamplingRatio float64
+	// Version is the version of the controller reported in exported spans.
+	Version string
+}
+
+// SetupTracerProvider sets up the global tracer provider exporting spans as configured and the global propagator
+// of W3C trace context. It returns a function flushing pending spans and shutting the provider down.
+func SetupTracerProvider(ctx context.Context, logger logr.Logger, cfg Config) (func(context.Context) error, error) {
+	exporterOpts, err := exporterOptions(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := otlptracehttp.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(cfg.Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplingRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Error(err, "failed to export traces")
+	}))
+
+	return provider.Shutdown, nil
+}
+
+// ValidateEndpoint checks whether endpoint is a valid OTLP/HTTP endpoint spans can be exported to.
+func ValidateEndpoint(endpoint string) error {
+	_, err := exporterOptions(endpoint)
+	return err
+}
+
+func exporterOptions(endpoint string) ([]otlptracehttp.Option, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: %w", endpoint, err)
+	}
+	if u.Host == "" {
+		return nil, errors.New("OTLP endpoint has to be a URL with a host, e.g. http://otel-collector:4318")
+	}
+
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host)}
+	switch u.Scheme {
+	case "http":
+		opts = append(opts, otlptracehttp.WithInsecure())
+	case "https":
+	default:
+		return nil, fmt.Errorf("OTLP endpoint has to use http or https scheme, got %q", u.Scheme)
+	}
+	if u.Path != "" && u.Path != "/" {
+		opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+	}
+	return opts, nil
+}
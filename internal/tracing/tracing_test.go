@@ -0,0 +1,119 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/kong/kubernetes-ingress-controller/v2/internal/tracing"
+)
+
+// setupSpanRecorder makes spans recorded with the returned recorder for the duration of the test.
+func setupSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestSpans(t *testing.T) {
+	recorder := setupSpanRecorder(t)
+
+	ctx, reconcileSpan := tracing.StartReconcileSpan(context.Background(), "Ingress", k8stypes.NamespacedName{Namespace: "default", Name: "echo"})
+	_, childSpan := tracing.StartSpan(ctx, "child")
+	tracing.EndSpan(childSpan, errors.New("failed"))
+	tracing.EndSpan(reconcileSpan, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	child, reconcile := spans[0], spans[1]
+
+	require.Equal(t, "Ingress.Reconcile", reconcile.Name())
+	require.Equal(t, "Ingress", attributeValue(reconcile, tracing.AttributeKeyKind))
+	require.Equal(t, "default", attributeValue(reconcile, tracing.AttributeKeyNamespace))
+	require.Equal(t, "echo", attributeValue(reconcile, tracing.AttributeKeyName))
+	require.Equal(t, codes.Unset, reconcile.Status().Code)
+
+	require.Equal(t, reconcile.SpanContext().SpanID(), child.Parent().SpanID(), "child span should be a child of the span in context")
+	require.Equal(t, codes.Error, child.Status().Code)
+	require.Equal(t, "failed", child.Status().Description)
+}
+
+func TestNewTransport(t *testing.T) {
+	recorder := setupSpanRecorder(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, parent := tracing.StartSpan(context.Background(), "parent")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/config", nil)
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: tracing.NewTransport(http.DefaultTransport)}).Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	request := spans[0]
+	require.Equal(t, "Admin API POST", request.Name())
+	require.Equal(t, trace.SpanKindClient, request.SpanKind())
+	require.Equal(t, parent.SpanContext().SpanID(), request.Parent().SpanID())
+
+	require.NotEmpty(t, traceparent, "trace context should be propagated in request headers")
+	require.Contains(t, traceparent, request.SpanContext().TraceID().String())
+	require.Contains(t, traceparent, request.SpanContext().SpanID().String())
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	testCases := []struct {
+		endpoint    string
+		expectedErr string
+	}{
+		{endpoint: "http://otel-collector:4318"},
+		{endpoint: "https://otel-collector.example.com/custom/v1/traces"},
+		{endpoint: "otel-collector:4318", expectedErr: "OTLP endpoint has to be a URL with a host"},
+		{endpoint: "grpc://otel-collector:4317", expectedErr: "OTLP endpoint has to use http or https scheme"},
+		{endpoint: "http://[::1", expectedErr: "invalid OTLP endpoint"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.endpoint, func(t *testing.T) {
+			err := tracing.ValidateEndpoint(tc.endpoint)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func attributeValue(span sdktrace.ReadOnlySpan, key attribute.Key) any {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value.AsInterface()
+		}
+	}
+	return nil
+}
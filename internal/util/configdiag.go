@@ -1,15 +1,128 @@
 package util
 
-import "github.com/kong/deck/file"
+import (
+	"time"
 
-// ConfigDump contains a config dump and a flag indicating that the config was not successfully applid.
+	"github.com/kong/deck/file"
+)
+
+// ConfigDump contains a config dump and flags describing what happened to the config.
 type ConfigDump struct {
 	Config file.Content
+	// Hash is the hash of the config as it was generated, i.e. before it was sanitized for the dump.
+	Hash []byte
+	// Target is what the config was generated for (one of metrics.GenerationTarget* values).
+	Target string
+	// Failed indicates that the config was not successfully applied.
 	Failed bool
+	// DryRun indicates that the config was generated in dry-run mode, so it was never sent to Kong.
+	DryRun bool
+}
+
+// GatewaySyncStatus describes the result of the most recent configuration sync with a single Kong Gateway.
+type GatewaySyncStatus struct {
+	// URL is the Admin API URL of the Kong Gateway.
+	URL string `json:"url"`
+	// Service is the Kong Admin API Service ("namespace/name") the Kong Gateway was discovered from.
+	Service string `json:"service,omitempty"`
+	// InSync tells whether the most recent configuration was applied to the Kong Gateway.
+	InSync bool `json:"in_sync"`
+	// Skipped tells that the most recent configuration was not sent to the Kong Gateway (e.g. its staged rollout
+	// was halted), so it still runs the previous one.
+	Skipped bool `json:"skipped,omitempty"`
+	// ConfigHash is the hash of the configuration most recently applied to the Kong Gateway.
+	ConfigHash string `json:"config_hash,omitempty"`
+	// Error is the error that occurred when sending the most recent configuration to the Kong Gateway.
+	Error string `json:"error,omitempty"`
+	// CircuitOpen tells whether configuration updates of the Kong Gateway are backed off after it failed to apply them.
+	CircuitOpen bool `json:"circuit_open,omitempty"`
+	// CircuitOpenReason explains why configuration updates of the Kong Gateway are backed off.
+	CircuitOpenReason string `json:"circuit_open_reason,omitempty"`
+	// Time is the time of the most recent sync.
+	Time time.Time `json:"time"`
+}
+
+// DriftedEntity is a Kong entity whose state in a Kong Gateway differs from the desired configuration.
+type DriftedEntity struct {
+	// Kind is decK's kind of the entity, e.g. "service".
+	Kind string `json:"kind"`
+	// Name is decK's console representation of the entity, e.g. its name or ID.
+	Name string `json:"name"`
+}
+
+// GatewayConfigDrift describes the result of the most recent drift detection with a single Kong Gateway running
+// in DB mode, i.e. managed entities that were changed in it out of band.
+type GatewayConfigDrift struct {
+	// URL is the Admin API URL of the Kong Gateway.
+	URL string `json:"url"`
+	// Added are managed entities that exist in the Kong Gateway, but are not in the desired configuration.
+	Added []DriftedEntity `json:"added"`
+	// Removed are entities of the desired configuration that are missing in the Kong Gateway.
+	Removed []DriftedEntity `json:"removed"`
+	// Modified are entities of the desired configuration that differ in the Kong Gateway.
+	Modified []DriftedEntity `json:"modified"`
+	// Corrected tells whether the desired configuration was pushed to the Kong Gateway to correct the drift.
+	Corrected bool `json:"corrected"`
+	// Error is the error that occurred when detecting or correcting the drift.
+	Error string `json:"error,omitempty"`
+	// Time is the time of the most recent drift detection.
+	Time time.Time `json:"time"`
+}
+
+// GatewayVersion describes the version of a single Kong Gateway.
+type GatewayVersion struct {
+	// URL is the Admin API URL of the Kong Gateway.
+	URL string `json:"url,omitempty"`
+	// Version is the version of the Kong Gateway.
+	Version string `json:"version,omitempty"`
+	// Edition is the edition of the Kong Gateway ("oss" or "enterprise").
+	Edition string `json:"edition,omitempty"`
+	// RouterFlavor is the router flavor the Kong Gateway is running with.
+	RouterFlavor string `json:"router_flavor,omitempty"`
+	// Error is the error that occurred when detecting the version.
+	Error string `json:"error,omitempty"`
+}
+
+// GatewayVersions describes versions of Kong Gateways the configuration is pushed to. When they run different
+// versions (e.g. during a rolling upgrade), the configuration is generated for what all of them support.
+type GatewayVersions struct {
+	// Gateways are versions of each of the Kong Gateways.
+	Gateways []GatewayVersion `json:"gateways"`
+	// Skew tells whether the Kong Gateways run different versions.
+	Skew bool `json:"skew"`
+	// Target describes the Kong Gateway the configuration is generated for.
+	Target GatewayVersion `json:"target"`
+	// Time is the time of the most recent detection.
+	Time time.Time `json:"time"`
+}
+
+// ObjectFailure is a single failure of a Kubernetes object.
+type ObjectFailure struct {
+	// Message is a human-readable message describing the cause of the failure.
+	Message string `json:"message"`
+	// Reason is the category of the reason of the failure (e.g. "missing_reference").
+	Reason string `json:"reason"`
+}
+
+// FailingObject describes a Kubernetes object whose configuration failed to be translated or pushed to Kong.
+type FailingObject struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Stage is the stage the object fails at ("translation" or "push").
+	Stage string `json:"stage"`
+	// Failures are failures of the object in the most recent update.
+	Failures []ObjectFailure `json:"failures"`
+	// FirstSeen is the time since when the object has been failing at the stage.
+	FirstSeen time.Time `json:"first_seen"`
 }
 
 // ConfigDumpDiagnostic contains settings and channels for receiving diagnostic configuration dumps.
 type ConfigDumpDiagnostic struct {
 	DumpsIncludeSensitive bool
 	Configs               chan ConfigDump
+	GatewaySyncStatuses   chan []GatewaySyncStatus
+	GatewayConfigDrifts   chan []GatewayConfigDrift
+	GatewayVersions       chan GatewayVersions
+	FailingObjects        chan []FailingObject
 }
@@ -7,5 +7,9 @@ import (
 // KICv3VersionCutoff is the lowest version version of Kong Gateway supported by KIC >=v3.0.0.
 var KICv3VersionCutoff = semver.Version{Major: 3, Minor: 4, Patch: 1}
 
+// DBLessConfigCompressionVersionCutoff is the lowest Kong Gateway version DB-less configuration is sent compressed to.
+// Gateways not accepting it anyway are detected by their response and get the configuration uncompressed.
+var DBLessConfigCompressionVersionCutoff = semver.Version{Major: 3, Minor: 4}
+
 // DeckFileFormatVersion is the version of the decK file format used by KIC everywhere.
 const DeckFileFormatVersion = "3.0"
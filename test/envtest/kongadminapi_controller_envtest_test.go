@@ -18,6 +18,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/rest"
@@ -51,8 +52,15 @@ func (n *notifier) LastNotified() []adminapi.DiscoveredAdminAPI {
 }
 
 // startKongAdminAPIServiceReconciler starts KongAdminAPIServiceReconciler with
-// the manager in a separate goroutine.
-func startKongAdminAPIServiceReconciler(ctx context.Context, t *testing.T, client ctrlclient.Client, cfg *rest.Config) (
+// the manager in a separate goroutine. The reconciler selects the returned Service
+// unless configured otherwise with opts.
+func startKongAdminAPIServiceReconciler(
+	ctx context.Context,
+	t *testing.T,
+	client ctrlclient.Client,
+	cfg *rest.Config,
+	opts ...func(*configuration.KongAdminAPIServiceReconciler),
+) (
 	adminService corev1.Service,
 	adminPod corev1.Pod,
 	n *notifier,
@@ -85,18 +93,22 @@ func startKongAdminAPIServiceReconciler(ctx context.Context, t *testing.T, clien
 	adminAPIsDiscoverer, err := adminapi.NewDiscoverer(sets.New("admin"), types.ServiceScopedPodDNSStrategy)
 	require.NoError(t, err)
 
-	require.NoError(t,
-		(&configuration.KongAdminAPIServiceReconciler{
-			Client: mgr.GetClient(),
-			ServiceNN: k8stypes.NamespacedName{
+	reconciler := &configuration.KongAdminAPIServiceReconciler{
+		Client: mgr.GetClient(),
+		Services: adminapi.ServiceSelector{
+			Services: []k8stypes.NamespacedName{{
 				Name:      adminService.Name,
 				Namespace: adminService.Namespace,
-			},
-			EndpointsNotifier:   n,
-			Log:                 mgr.GetLogger(),
-			AdminAPIsDiscoverer: adminAPIsDiscoverer,
-		}).SetupWithManager(mgr),
-	)
+			}},
+		},
+		EndpointsNotifier:   n,
+		Log:                 mgr.GetLogger(),
+		AdminAPIsDiscoverer: adminAPIsDiscoverer,
+	}
+	for _, opt := range opts {
+		opt(reconciler)
+	}
+	require.NoError(t, reconciler.SetupWithManager(mgr))
 	// This wait group makes it so that we wait for manager to exit.
 	// This way we get clean test logs not mixing between tests.
 	wg := sync.WaitGroup{}
@@ -183,6 +195,10 @@ func TestKongAdminAPIController(t *testing.T) {
 						Namespace: adminPod.Namespace,
 						Name:      adminPod.Name,
 					},
+					ServiceRef: k8stypes.NamespacedName{
+						Namespace: adminService.Namespace,
+						Name:      adminService.Name,
+					},
 				},
 				{
 					Address: fmt.Sprintf("https://10-0-0-2.%s.%s.svc:8080", adminService.Name, adminService.Namespace),
@@ -190,6 +206,10 @@ func TestKongAdminAPIController(t *testing.T) {
 						Namespace: adminPod.Namespace,
 						Name:      adminPod.Name,
 					},
+					ServiceRef: k8stypes.NamespacedName{
+						Namespace: adminService.Namespace,
+						Name:      adminService.Name,
+					},
 				},
 			},
 			n.LastNotified(),
@@ -255,6 +275,10 @@ func TestKongAdminAPIController(t *testing.T) {
 						Namespace: adminPod.Namespace,
 						Name:      adminPod.Name,
 					},
+					ServiceRef: k8stypes.NamespacedName{
+						Namespace: adminService.Namespace,
+						Name:      adminService.Name,
+					},
 				},
 			},
 			n.LastNotified(),
@@ -365,6 +389,10 @@ func TestKongAdminAPIController(t *testing.T) {
 						Namespace: adminPod.Namespace,
 						Name:      adminPod.Name,
 					},
+					ServiceRef: k8stypes.NamespacedName{
+						Namespace: adminService.Namespace,
+						Name:      adminService.Name,
+					},
 				},
 				{
 					Address: fmt.Sprintf("https://10-0-0-2.%s.%s.svc:8080", adminService.Name, adminService.Namespace),
@@ -372,6 +400,10 @@ func TestKongAdminAPIController(t *testing.T) {
 						Namespace: adminPod.Namespace,
 						Name:      adminPod.Name,
 					},
+					ServiceRef: k8stypes.NamespacedName{
+						Namespace: adminService.Namespace,
+						Name:      adminService.Name,
+					},
 				},
 				{
 					Address: fmt.Sprintf("https://10-0-0-10.%s.%s.svc:8080", adminService.Name, adminService.Namespace),
@@ -379,6 +411,10 @@ func TestKongAdminAPIController(t *testing.T) {
 						Namespace: adminPod.Namespace,
 						Name:      adminPod.Name,
 					},
+					ServiceRef: k8stypes.NamespacedName{
+						Namespace: adminService.Namespace,
+						Name:      adminService.Name,
+					},
 				},
 				{
 					Address: fmt.Sprintf("https://10-0-0-20.%s.%s.svc:8080", adminService.Name, adminService.Namespace),
@@ -386,6 +422,10 @@ func TestKongAdminAPIController(t *testing.T) {
 						Namespace: adminPod.Namespace,
 						Name:      adminPod.Name,
 					},
+					ServiceRef: k8stypes.NamespacedName{
+						Namespace: adminService.Namespace,
+						Name:      adminService.Name,
+					},
 				},
 			},
 			n.LastNotified(),
@@ -451,6 +491,10 @@ func TestKongAdminAPIController(t *testing.T) {
 						Namespace: adminPod.Namespace,
 						Name:      adminPod.Name,
 					},
+					ServiceRef: k8stypes.NamespacedName{
+						Namespace: adminService.Namespace,
+						Name:      adminService.Name,
+					},
 				},
 				{
 					Address: fmt.Sprintf("https://10-0-0-2.%s.%s.svc:8080", adminService.Name, adminService.Namespace),
@@ -458,6 +502,10 @@ func TestKongAdminAPIController(t *testing.T) {
 						Namespace: adminPod.Namespace,
 						Name:      adminPod.Name,
 					},
+					ServiceRef: k8stypes.NamespacedName{
+						Namespace: adminService.Namespace,
+						Name:      adminService.Name,
+					},
 				},
 			},
 			n.LastNotified(),
@@ -486,6 +534,10 @@ func TestKongAdminAPIController(t *testing.T) {
 						Namespace: adminPod.Namespace,
 						Name:      adminPod.Name,
 					},
+					ServiceRef: k8stypes.NamespacedName{
+						Namespace: adminService.Namespace,
+						Name:      adminService.Name,
+					},
 				},
 			},
 			n.LastNotified(),
@@ -551,6 +603,10 @@ func TestKongAdminAPIController(t *testing.T) {
 						Namespace: adminPod.Namespace,
 						Name:      adminPod.Name,
 					},
+					ServiceRef: k8stypes.NamespacedName{
+						Namespace: adminService.Namespace,
+						Name:      adminService.Name,
+					},
 				},
 				{
 					Address: fmt.Sprintf("https://10-0-0-2.%s.%s.svc:8080", adminService.Name, adminService.Namespace),
@@ -558,6 +614,10 @@ func TestKongAdminAPIController(t *testing.T) {
 						Namespace: adminPod.Namespace,
 						Name:      adminPod.Name,
 					},
+					ServiceRef: k8stypes.NamespacedName{
+						Namespace: adminService.Namespace,
+						Name:      adminService.Name,
+					},
 				},
 			},
 			n.LastNotified(),
@@ -569,4 +629,49 @@ func TestKongAdminAPIController(t *testing.T) {
 		assert.Eventually(t, func() bool { return len(n.LastNotified()) == 0 }, 3*time.Second, time.Millisecond)
 		assert.Nil(t, n.LastNotified())
 	})
+
+	t.Run("EndpointSlices of relabeled Services are not matched anymore", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		selectedLabels := map[string]string{"app": "kong-admin"}
+		adminService, adminPod, n := startKongAdminAPIServiceReconciler(ctx, t, client, cfg,
+			func(r *configuration.KongAdminAPIServiceReconciler) {
+				r.Services = adminapi.ServiceSelector{LabelSelector: labels.SelectorFromSet(selectedLabels)}
+			},
+		)
+
+		// EndpointSlices inherit labels of their Services.
+		endpoints := discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: adminService.Namespace,
+				Labels: map[string]string{
+					"kubernetes.io/service-name": adminService.Name,
+					"app":                        "kong-admin",
+				},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{
+					Addresses: []string{"10.0.0.1"},
+					Conditions: discoveryv1.EndpointConditions{
+						Ready: lo.ToPtr(true),
+					},
+					TargetRef: &corev1.ObjectReference{
+						Kind:      "Pod",
+						Name:      adminPod.Name,
+						Namespace: adminPod.Namespace,
+					},
+				},
+			},
+			Ports: builder.NewEndpointPort(8080).WithName("admin").IntoSlice(),
+		}
+		require.NoError(t, client.Create(ctx, &endpoints, &ctrlclient.CreateOptions{}))
+		assert.Eventually(t, func() bool { return len(n.LastNotified()) == 1 }, 3*time.Second, time.Millisecond)
+
+		// Relabeling the Service relabels its EndpointSlices, so that they don't match the selector anymore.
+		endpoints.Labels["app"] = "something-else"
+		require.NoError(t, client.Update(ctx, &endpoints, &ctrlclient.UpdateOptions{}))
+		assert.Eventually(t, func() bool { return len(n.LastNotified()) == 0 }, 3*time.Second, time.Millisecond)
+	})
 }